@@ -0,0 +1,82 @@
+/* create table of accessory */
+CREATE TABLE IF NOT EXISTS artifact_accessory (
+    id SERIAL PRIMARY KEY NOT NULL,
+    /*
+       the artifact id of the accessory itself.
+    */
+    artifact_id int,
+    /*
+       the subject artifact id of the accessory, it is 0 when the accessory is
+       pushed before its subject artifact and is updated once the subject is pushed.
+    */
+    subject_artifact_id int,
+    /*
+       the repository and digest of the subject artifact, which are used to serve
+       the referrers API and link the accessory with the subject pushed later.
+    */
+    subject_artifact_repo varchar(255),
+    subject_artifact_digest varchar(1024),
+    /*
+       the type of the accessory, like signature.cosign.
+    */
+    type varchar(256),
+    size bigint,
+    digest varchar(1024),
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    FOREIGN KEY (artifact_id) REFERENCES artifact(id),
+    CONSTRAINT unique_artifact_accessory UNIQUE (artifact_id, subject_artifact_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_artifact_accessory_subject ON artifact_accessory (subject_artifact_repo, subject_artifact_digest);
+
+/* the artifact type declared by the OCI manifest, falls back to the media type of the config */
+ALTER TABLE artifact ADD COLUMN IF NOT EXISTS artifact_type varchar(255);
+UPDATE artifact SET artifact_type = media_type WHERE artifact_type IS NULL;
+
+/* the filters of the notification policy, e.g. the repository and tag patterns */
+ALTER TABLE notification_policy ADD COLUMN IF NOT EXISTS filters text;
+
+/* the delivery attempts of the notification jobs */
+CREATE TABLE IF NOT EXISTS notification_delivery (
+    id SERIAL PRIMARY KEY NOT NULL,
+    job_id int NOT NULL,
+    policy_id int NOT NULL,
+    success boolean NOT NULL DEFAULT false,
+    request_url varchar(1024),
+    request_headers text,
+    request_body text,
+    status_code int,
+    /* the response body is truncated to 4KB */
+    response_body text,
+    /* in milliseconds */
+    latency bigint,
+    error text,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    FOREIGN KEY (job_id) REFERENCES notification_job(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_delivery_job_id ON notification_delivery (job_id);
+CREATE INDEX IF NOT EXISTS idx_notification_delivery_policy_id ON notification_delivery (policy_id, success);
+
+/* the address of the target the notification job is sent to, the job is redelivered to the same target */
+ALTER TABLE notification_job ADD COLUMN IF NOT EXISTS notify_address varchar(1024);
+
+/* the details of the client and the HTTP outcome of the audited operation */
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS client_ip varchar(64);
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS user_agent varchar(255);
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS auth_method varchar(32);
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS status_code int;
+
+/* the custom project roles defined by the system admin, the built-in roles keep the IDs 1 to 5 */
+ALTER TABLE role ALTER COLUMN name TYPE varchar(255);
+ALTER TABLE role ADD COLUMN IF NOT EXISTS description text;
+ALTER TABLE role ADD COLUMN IF NOT EXISTS creation_time timestamp default CURRENT_TIMESTAMP;
+ALTER TABLE role ADD COLUMN IF NOT EXISTS update_time timestamp default CURRENT_TIMESTAMP;
+CREATE UNIQUE INDEX IF NOT EXISTS unique_role_name ON role (name);
+
+/* the artifact count and repository count quotas, they're unlimited for the existing projects */
+UPDATE quota SET hard = jsonb_build_object('count', -1, 'repository', -1) || hard WHERE reference = 'project';
+UPDATE quota_usage SET used = used || jsonb_build_object(
+    'count', (SELECT count(1) FROM artifact WHERE artifact.project_id = quota_usage.reference_id::integer),
+    'repository', (SELECT count(1) FROM repository WHERE repository.project_id = quota_usage.reference_id::integer))
+WHERE reference = 'project';
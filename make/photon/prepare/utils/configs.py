@@ -323,6 +323,9 @@ def parse_yaml_config(config_file_path, with_notary, with_trivy, with_chartmuseu
     else:
         config_dict['internal_tls'] = InternalTLS()
 
+    # the proxies whose forwarded headers are trusted by core
+    config_dict['trusted_proxies'] = ','.join(configs.get('trusted_proxies') or [])
+
     # metric configs
     metric_config = configs.get('metric')
     if metric_config:
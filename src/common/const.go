@@ -158,9 +158,17 @@ const (
 	// Global notification enable configuration
 	NotificationEnable = "notification_enable"
 
+	// Audit log forwarding setting items
+	AuditLogForwardEndpoint   = "audit_log_forward_endpoint"
+	AuditLogForwardInsecure   = "audit_log_forward_insecure"
+	AuditLogForwardAuthHeader = "audit_log_forward_auth_header"
+	SkipAuditLogDatabase      = "skip_audit_log_database"
+
 	// Quota setting items for project
 	QuotaPerProjectEnable = "quota_per_project_enable"
 	StoragePerProject     = "storage_per_project"
+	CountPerProject       = "count_per_project"
+	RepositoryPerProject  = "repository_per_project"
 
 	// DefaultGCTimeWindowHours is the reserve blob time window used by GC, default is 2 hours
 	DefaultGCTimeWindowHours = int64(2)
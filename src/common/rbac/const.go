@@ -70,8 +70,10 @@ const (
 	ResourceReplication        = Resource("replication")
 	ResourceDistribution       = Resource("distribution")
 	ResourceGarbageCollection  = Resource("garbage-collection")
+	ResourcePurgeAuditLog      = Resource("purge-audit")
 	ResourceReplicationAdapter = Resource("replication-adapter")
 	ResourceReplicationPolicy  = Resource("replication-policy")
+	ResourceRole               = Resource("role")
 	ResourceScanAll            = Resource("scan-all")
 	ResourceSystemVolumes      = Resource("system-volumes")
 )
@@ -48,9 +48,10 @@ func NewBuilderForUser(user *models.User, ctl project.Controller) RBACUserBuilde
 		}
 
 		return &rbacUser{
-			project:      p,
-			username:     user.Username,
-			projectRoles: roles,
+			project:            p,
+			username:           user.Username,
+			projectRoles:       roles,
+			customRolePolicies: getCustomRolePolicies(ctx, roles),
 		}
 	}
 }
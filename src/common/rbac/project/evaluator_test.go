@@ -22,8 +22,11 @@ import (
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/models"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	pkgRbac "github.com/goharbor/harbor/src/pkg/rbac"
+	rbacModel "github.com/goharbor/harbor/src/pkg/rbac/model"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 	"github.com/goharbor/harbor/src/testing/mock"
+	rbactesting "github.com/goharbor/harbor/src/testing/pkg/rbac"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -105,6 +108,34 @@ func TestProjectRoleAccess(t *testing.T) {
 	}
 }
 
+func TestCustomRoleAccess(t *testing.T) {
+	assert := assert.New(t)
+
+	mgr := &rbactesting.Manager{}
+	defer func(m pkgRbac.Manager) { rbacMgr = m }(rbacMgr)
+	rbacMgr = mgr
+	mgr.On("GetPermissionsByRole", mock.Anything, rbacModel.ProjectRoleType, int64(6)).Return([]*rbacModel.UniversalRolePermission{
+		{Resource: rbac.ResourceRepository.String(), Action: rbac.ActionPush.String()},
+		{Resource: rbac.ResourceRepository.String(), Action: rbac.ActionPull.String()},
+		{Resource: rbac.ResourceArtifactLabel.String(), Action: rbac.ActionCreate.String()},
+	}, nil)
+
+	ctl := &projecttesting.Controller{}
+	mock.OnAnything(ctl, "Get").Return(private, nil)
+	mock.OnAnything(ctl, "ListRoles").Return([]int{6}, nil)
+
+	user := &models.User{
+		UserID:   1,
+		Username: "username",
+	}
+	evaluator := NewEvaluator(ctl, NewBuilderForUser(user, ctl))
+	namespace := NewNamespace(private.ProjectID)
+	assert.True(evaluator.HasPermission(context.TODO(), namespace.Resource(rbac.ResourceRepository), rbac.ActionPush))
+	assert.True(evaluator.HasPermission(context.TODO(), namespace.Resource(rbac.ResourceArtifactLabel), rbac.ActionCreate))
+	assert.False(evaluator.HasPermission(context.TODO(), namespace.Resource(rbac.ResourceRepository), rbac.ActionDelete))
+	assert.False(evaluator.HasPermission(context.TODO(), namespace.Resource(rbac.ResourceMember), rbac.ActionCreate))
+}
+
 func BenchmarkProjectEvaluator(b *testing.B) {
 	ctl := &projecttesting.Controller{}
 	mock.OnAnything(ctl, "Get").Return(public, nil)
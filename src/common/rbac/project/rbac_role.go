@@ -15,12 +15,20 @@
 package project
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
+	pkgRbac "github.com/goharbor/harbor/src/pkg/rbac"
+	rbacModel "github.com/goharbor/harbor/src/pkg/rbac/model"
 )
 
 var (
+	rbacMgr = pkgRbac.NewManager()
+
 	rolePoliciesMap = map[string][]*types.Policy{
 		"projectAdmin": {
 			{Resource: rbac.ResourceSelf, Action: rbac.ActionRead},
@@ -121,6 +129,11 @@ var (
 			{Resource: rbac.ResourcePreatPolicy, Action: rbac.ActionUpdate},
 			{Resource: rbac.ResourcePreatPolicy, Action: rbac.ActionDelete},
 			{Resource: rbac.ResourcePreatPolicy, Action: rbac.ActionList},
+
+			{Resource: rbac.ResourceGarbageCollection, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourceGarbageCollection, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceGarbageCollection, Action: rbac.ActionUpdate},
+			{Resource: rbac.ResourceGarbageCollection, Action: rbac.ActionList},
 		},
 
 		"maintainer": {
@@ -368,3 +381,63 @@ func (role *projectRBACRole) GetPolicies() []*types.Policy {
 
 	return policies
 }
+
+// GetPoliciesOfRole returns the policies of the built-in project role without the namespace
+func GetPoliciesOfRole(roleID int) []*types.Policy {
+	role := &projectRBACRole{roleID: roleID}
+	return rolePoliciesMap[role.GetRoleName()]
+}
+
+// customRBACRole implement the RBACRole interface for the custom project roles defined by the system admin
+type customRBACRole struct {
+	projectID int64
+	roleID    int
+	policies  []*types.Policy
+}
+
+// GetRoleName returns the role name for the evaluator, the ID is used rather than the name of the
+// custom role which may conflict with the built-in roles or contain the characters like comma
+func (role *customRBACRole) GetRoleName() string {
+	return fmt.Sprintf("customRole%d", role.roleID)
+}
+
+// GetPolicies returns policies of the custom role in the project
+func (role *customRBACRole) GetPolicies() []*types.Policy {
+	policies := []*types.Policy{}
+
+	namespace := NewNamespace(role.projectID)
+	for _, policy := range role.policies {
+		policies = append(policies, &types.Policy{
+			Resource: namespace.Resource(policy.Resource),
+			Action:   policy.Action,
+			Effect:   policy.Effect,
+		})
+	}
+
+	return policies
+}
+
+// getCustomRolePolicies loads the policies of the custom roles from the database, the built-in roles are skipped
+func getCustomRolePolicies(ctx context.Context, roles []int) map[int][]*types.Policy {
+	result := map[int][]*types.Policy{}
+	for _, roleID := range roles {
+		if roleID <= rbacModel.MaxBuiltInRoleID {
+			continue
+		}
+		permissions, err := rbacMgr.GetPermissionsByRole(ctx, rbacModel.ProjectRoleType, int64(roleID))
+		if err != nil {
+			log.Errorf("failed to get the permissions of the role %d: %v", roleID, err)
+			continue
+		}
+		policies := []*types.Policy{}
+		for _, permission := range permissions {
+			policies = append(policies, &types.Policy{
+				Resource: types.Resource(permission.Resource),
+				Action:   types.Action(permission.Action),
+				Effect:   types.Effect(permission.Effect),
+			})
+		}
+		result[roleID] = policies
+	}
+	return result
+}
@@ -24,6 +24,8 @@ type rbacUser struct {
 	username     string
 	projectRoles []int
 	policies     []*types.Policy
+	// the policies of the custom roles in the project roles
+	customRolePolicies map[int][]*types.Policy
 }
 
 // GetUserName returns username of the visitor
@@ -46,6 +48,10 @@ func (pru *rbacUser) GetPolicies() []*types.Policy {
 func (pru *rbacUser) GetRoles() []types.RBACRole {
 	roles := []types.RBACRole{}
 	for _, roleID := range pru.projectRoles {
+		if policies, ok := pru.customRolePolicies[roleID]; ok {
+			roles = append(roles, &customRBACRole{projectID: pru.project.ProjectID, roleID: roleID, policies: policies})
+			continue
+		}
 		roles = append(roles, &projectRBACRole{projectID: pru.project.ProjectID, roleID: roleID})
 	}
 
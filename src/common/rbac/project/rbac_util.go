@@ -81,6 +81,14 @@ func GetPoliciesOfProject(projectID int64) []*types.Policy {
 	return policies
 }
 
+// GetAllPolicies returns all the policies of the project roles without the namespace,
+// the policies of the custom project roles must be in them
+func GetAllPolicies() []*types.Policy {
+	policies := make([]*types.Policy, len(subPoliciesForProject))
+	copy(policies, subPoliciesForProject)
+	return policies
+}
+
 func computeSubPoliciesForProject() []*types.Policy {
 	var results []*types.Policy
 
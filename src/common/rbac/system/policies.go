@@ -53,6 +53,18 @@ var (
 		{Resource: rbac.ResourceGarbageCollection, Action: rbac.ActionDelete},
 		{Resource: rbac.ResourceGarbageCollection, Action: rbac.ActionList},
 
+		{Resource: rbac.ResourcePurgeAuditLog, Action: rbac.ActionCreate},
+		{Resource: rbac.ResourcePurgeAuditLog, Action: rbac.ActionRead},
+		{Resource: rbac.ResourcePurgeAuditLog, Action: rbac.ActionUpdate},
+		{Resource: rbac.ResourcePurgeAuditLog, Action: rbac.ActionDelete},
+		{Resource: rbac.ResourcePurgeAuditLog, Action: rbac.ActionList},
+
+		{Resource: rbac.ResourceRole, Action: rbac.ActionCreate},
+		{Resource: rbac.ResourceRole, Action: rbac.ActionRead},
+		{Resource: rbac.ResourceRole, Action: rbac.ActionUpdate},
+		{Resource: rbac.ResourceRole, Action: rbac.ActionDelete},
+		{Resource: rbac.ResourceRole, Action: rbac.ActionList},
+
 		{Resource: rbac.ResourceScanAll, Action: rbac.ActionCreate},
 		{Resource: rbac.ResourceScanAll, Action: rbac.ActionRead},
 		{Resource: rbac.ResourceScanAll, Action: rbac.ActionUpdate},
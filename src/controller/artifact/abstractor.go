@@ -80,6 +80,10 @@ func (a *abstractor) AbstractMetadata(ctx context.Context, artifact *artifact.Ar
 	default:
 		return fmt.Errorf("unsupported manifest media type: %s", artifact.ManifestMediaType)
 	}
+	// the artifact type is the same with the media type of artifact if it isn't declared in manifest/index
+	if len(artifact.ArtifactType) == 0 {
+		artifact.ArtifactType = artifact.MediaType
+	}
 	return processor.Get(artifact.MediaType).AbstractMetadata(ctx, artifact, content)
 }
 
@@ -131,6 +135,19 @@ func (a *abstractor) abstractManifestV2Metadata(artifact *artifact.Artifact, con
 	}
 	// set annotations
 	artifact.Annotations = manifest.Annotations
+	if err := abstractOCIArtifact(artifact, content); err != nil {
+		return err
+	}
+	// the artifacts pushed by the older ORAS have neither the artifact type nor the config with
+	// known media type, fall back to the media type of the layer if it can be processed
+	if len(artifact.ArtifactType) == 0 && processor.Registry[artifact.MediaType] == nil {
+		for _, layer := range manifest.Layers {
+			if processor.Registry[layer.MediaType] != nil {
+				artifact.MediaType = layer.MediaType
+				break
+			}
+		}
+	}
 	return nil
 }
 
@@ -176,5 +193,38 @@ func (a *abstractor) abstractIndexMetadata(ctx context.Context, art *artifact.Ar
 		}
 	}
 
+	return abstractOCIArtifact(art, content)
+}
+
+const (
+	// the media type of the empty config defined by OCI image spec v1.1
+	mediaTypeEmptyConfig = "application/vnd.oci.empty.v1+json"
+	// the media type of the config that ORAS pushes by default
+	mediaTypeUnknownConfig = "application/vnd.unknown.config.v1+json"
+)
+
+// ociArtifact contains the fields introduced by OCI image spec v1.1 to support the artifacts
+// that refer to other artifacts, e.g. SBOMs, attestations and signatures
+type ociArtifact struct {
+	ArtifactType string         `json:"artifactType,omitempty"`
+	Subject      *v1.Descriptor `json:"subject,omitempty"`
+}
+
+// abstract the artifact type and the subject from OCI manifest/index
+func abstractOCIArtifact(artifact *artifact.Artifact, content []byte) error {
+	oci := &ociArtifact{}
+	if err := json.Unmarshal(content, oci); err != nil {
+		return err
+	}
+	artifact.ArtifactType = oci.ArtifactType
+	// the config of the artifact that declares the artifact type is usually empty, use the artifact type
+	// as the media type of artifact so that the artifact can be processed by the processor registered for the type
+	if len(oci.ArtifactType) > 0 &&
+		(artifact.MediaType == mediaTypeEmptyConfig || artifact.MediaType == mediaTypeUnknownConfig) {
+		artifact.MediaType = oci.ArtifactType
+	}
+	if oci.Subject != nil {
+		artifact.SubjectDigest = oci.Subject.Digest.String()
+	}
 	return nil
 }
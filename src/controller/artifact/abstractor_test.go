@@ -15,6 +15,7 @@
 package artifact
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/goharbor/harbor/src/controller/artifact/processor"
@@ -176,6 +177,29 @@ var (
   }
 }`
 
+	ociArtifactManifest = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "artifactType": "application/spdx+json",
+  "config": {
+    "mediaType": "application/vnd.oci.empty.v1+json",
+    "size": 2,
+    "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+  },
+  "layers": [
+    {
+      "mediaType": "application/spdx+json",
+      "size": 1024,
+      "digest": "sha256:1b930d010525941c1d56ec53b97bd057a67ae1865eebf042686d2a2d18271ced"
+    }
+  ],
+  "subject": {
+    "mediaType": "application/vnd.oci.image.manifest.v1+json",
+    "size": 7143,
+    "digest": "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f"
+  }
+}`
+
 	index = `{
   "schemaVersion": 2,
   "manifests": [
@@ -211,6 +235,8 @@ type abstractorTestSuite struct {
 	regCli     *registry.FakeClient
 	abstractor *abstractor
 	processor  *tpro.Processor
+	// the processors registered before the test
+	registry map[string]processor.Processor
 }
 
 func (a *abstractorTestSuite) SetupTest() {
@@ -223,11 +249,16 @@ func (a *abstractorTestSuite) SetupTest() {
 		regCli:  a.regCli,
 	}
 	a.processor = &tpro.Processor{}
-	// clear all registered processors
+	// clear all registered processors, they are restored after the test
+	a.registry = processor.Registry
 	processor.Registry = map[string]processor.Processor{}
 	processor.Registry[schema2.MediaTypeImageConfig] = a.processor
 }
 
+func (a *abstractorTestSuite) TearDownTest() {
+	processor.Registry = a.registry
+}
+
 // docker manifest v1
 func (a *abstractorTestSuite) TestAbstractMetadataOfV1Manifest() {
 	manifest, _, err := distribution.UnmarshalManifest(schema1.MediaTypeSignedManifest, []byte(v1Manifest))
@@ -262,9 +293,48 @@ func (a *abstractorTestSuite) TestAbstractMetadataOfV2Manifest() {
 	a.Assert().Equal(int64(1), artifact.ID)
 	a.Assert().Equal(schema2.MediaTypeManifest, artifact.ManifestMediaType)
 	a.Assert().Equal(schema2.MediaTypeImageConfig, artifact.MediaType)
+	a.Assert().Equal(schema2.MediaTypeImageConfig, artifact.ArtifactType)
 	a.Assert().Equal(int64(3043), artifact.Size)
 	a.Require().Len(artifact.Annotations, 1)
 	a.Equal("value1", artifact.Annotations["com.example.key1"])
+	a.Empty(artifact.SubjectDigest)
+}
+
+// OCI manifest with the artifact type and subject
+func (a *abstractorTestSuite) TestAbstractMetadataOfOCIArtifact() {
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(ociArtifactManifest))
+	a.Require().Nil(err)
+	a.regCli.On("PullManifest").Return(manifest, "", nil)
+	processor.Registry["application/spdx+json"] = a.processor
+	artifact := &artifact.Artifact{
+		ID: 1,
+	}
+	a.processor.On("AbstractMetadata", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	err = a.abstractor.AbstractMetadata(nil, artifact)
+	a.Require().Nil(err)
+	a.Assert().Equal(v1.MediaTypeImageManifest, artifact.ManifestMediaType)
+	a.Assert().Equal("application/spdx+json", artifact.MediaType)
+	a.Assert().Equal("application/spdx+json", artifact.ArtifactType)
+	a.Assert().Equal("sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f", artifact.SubjectDigest)
+	a.processor.AssertExpectations(a.T())
+}
+
+// OCI manifest pushed by the older ORAS without the artifact type
+func (a *abstractorTestSuite) TestAbstractMetadataOfUnknownConfig() {
+	content := strings.Replace(ociArtifactManifest, `"artifactType": "application/spdx+json",`, "", 1)
+	content = strings.Replace(content, "application/vnd.oci.empty.v1+json", "application/vnd.unknown.config.v1+json", 1)
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(content))
+	a.Require().Nil(err)
+	a.regCli.On("PullManifest").Return(manifest, "", nil)
+	processor.Registry["application/spdx+json"] = a.processor
+	artifact := &artifact.Artifact{
+		ID: 1,
+	}
+	a.processor.On("AbstractMetadata", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	err = a.abstractor.AbstractMetadata(nil, artifact)
+	a.Require().Nil(err)
+	a.Equal("application/spdx+json", artifact.MediaType)
+	a.processor.AssertExpectations(a.T())
 }
 
 // OCI index
@@ -284,6 +354,7 @@ func (a *abstractorTestSuite) TestAbstractMetadataOfIndex() {
 	a.Assert().Equal(int64(1), artifact.ID)
 	a.Assert().Equal(v1.MediaTypeImageIndex, artifact.ManifestMediaType)
 	a.Assert().Equal(v1.MediaTypeImageIndex, artifact.MediaType)
+	a.Assert().Equal(v1.MediaTypeImageIndex, artifact.ArtifactType)
 	a.Assert().Equal(int64(668), artifact.Size)
 	a.Require().Len(artifact.Annotations, 1)
 	a.Assert().Equal("value1", artifact.Annotations["com.example.key1"])
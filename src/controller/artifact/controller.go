@@ -27,6 +27,13 @@ import (
 	"github.com/goharbor/harbor/src/controller/artifact/processor/image"
 	"github.com/goharbor/harbor/src/lib/icon"
 
+	// register the processors of the SBOM documents, OPA bundles, SIF images and WASM modules
+	// into the processor registry by their media types, nothing else in the package references them
+	_ "github.com/goharbor/harbor/src/controller/artifact/processor/opa"
+	_ "github.com/goharbor/harbor/src/controller/artifact/processor/sbom"
+	_ "github.com/goharbor/harbor/src/controller/artifact/processor/sif"
+	_ "github.com/goharbor/harbor/src/controller/artifact/processor/wasm"
+
 	"github.com/goharbor/harbor/src/controller/artifact/processor"
 	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/controller/tag"
@@ -35,6 +42,8 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	"github.com/goharbor/harbor/src/pkg/artifact"
 	"github.com/goharbor/harbor/src/pkg/artifactrash"
 	"github.com/goharbor/harbor/src/pkg/artifactrash/model"
@@ -121,6 +130,7 @@ func NewController() Controller {
 		immutableMtr: rule.NewRuleMatcher(),
 		regCli:       registry.Cli,
 		abstractor:   NewAbstractor(),
+		accessoryMgr: accessory.Mgr,
 	}
 }
 
@@ -135,6 +145,7 @@ type controller struct {
 	immutableMtr match.ImmutableTagMatcher
 	regCli       registry.Client
 	abstractor   Abstractor
+	accessoryMgr accessory.Manager
 }
 
 func (c *controller) Ensure(ctx context.Context, repository, digest string, tags ...string) (bool, int64, error) {
@@ -216,9 +227,46 @@ func (c *controller) ensureArtifact(ctx context.Context, repository, digest stri
 		}
 	}
 
+	if created {
+		if err = c.ensureSubject(ctx, artifact); err != nil {
+			return false, nil, err
+		}
+	}
+
 	return created, artifact, nil
 }
 
+// persist the linkage between the artifact and the subject artifact declared by its "subject" field,
+// and link the referrers that were pushed before the artifact to it
+func (c *controller) ensureSubject(ctx context.Context, art *artifact.Artifact) error {
+	if err := c.accessoryMgr.AttachSubject(ctx, art.RepositoryName, art.Digest, art.ID); err != nil {
+		return err
+	}
+	if len(art.SubjectDigest) == 0 {
+		return nil
+	}
+
+	// the subject artifact may not exist as the referrers can be pushed before the subject
+	var subArtID int64
+	subArt, err := c.artMgr.GetByDigest(ctx, art.RepositoryName, art.SubjectDigest)
+	if err == nil {
+		subArtID = subArt.ID
+	} else if !errors.IsNotFoundErr(err) {
+		return err
+	}
+
+	// the size of the referrer is the size of its manifest rather than the total size of the artifact
+	_, desc, err := c.regCli.ManifestExist(art.RepositoryName, art.Digest)
+	if err != nil {
+		return err
+	}
+	var size int64
+	if desc != nil {
+		size = desc.Size
+	}
+	return c.accessoryMgr.Ensure(ctx, art.SubjectDigest, art.RepositoryName, subArtID, art.ID, size, art.Digest, accessorymodel.TypeSubject)
+}
+
 func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return c.artMgr.Count(ctx, query)
 }
@@ -283,24 +331,28 @@ func (c *controller) getByTag(ctx context.Context, repository, tag string, optio
 }
 
 func (c *controller) Delete(ctx context.Context, id int64) error {
-	return c.deleteDeeply(ctx, id, true)
+	_, err := c.deleteDeeply(ctx, id, true, false)
+	return err
 }
 
 // "isRoot" is used to specify whether the artifact is the root parent artifact
 // the error handling logic for the root parent artifact and others is different
-func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot bool) error {
-	art, err := c.Get(ctx, id, &Option{WithTag: true})
+// "isAccessory" is used to specify whether the artifact is an accessory of others,
+// the accessory is deleted along with its tags when its subject artifact is deleted
+// the count of the artifacts deleted is returned, including the children and accessories deleted along with it
+func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot, isAccessory bool) (int64, error) {
+	art, err := c.Get(ctx, id, &Option{WithTag: true, WithAccessory: true})
 	if err != nil {
 		// return nil if the nonexistent artifact isn't the root parent
 		if !isRoot && errors.IsErr(err, errors.NotFoundCode) {
-			return nil
+			return 0, nil
 		}
-		return err
+		return 0, err
 	}
 
 	// the child artifact is referenced by some tags, skip
-	if !isRoot && len(art.Tags) > 0 {
-		return nil
+	if !isRoot && !isAccessory && len(art.Tags) > 0 {
+		return 0, nil
 	}
 	parents, err := c.artMgr.ListReferences(ctx, &q.Query{
 		Keywords: map[string]interface{}{
@@ -308,62 +360,85 @@ func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot bool) er
 		},
 	})
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if len(parents) > 0 {
 		// the root artifact is referenced by other artifacts
 		if isRoot {
-			return errors.New(nil).WithCode(errors.ViolateForeignKeyConstraintCode).
+			return 0, errors.New(nil).WithCode(errors.ViolateForeignKeyConstraintCode).
 				WithMessage("the deleting artifact is referenced by others")
 		}
 		// the child artifact is referenced by other artifacts, skip
-		return nil
+		return 0, nil
 	}
+	deleted := int64(1)
 	// delete child artifacts if contains any
 	for _, reference := range art.References {
 		// delete reference
 		if err = c.artMgr.DeleteReference(ctx, reference.ID); err != nil &&
 			!errors.IsErr(err, errors.NotFoundCode) {
-			return err
+			return 0, err
 		}
-		if err = c.deleteDeeply(ctx, reference.ChildID, false); err != nil {
-			return err
+		count, err := c.deleteDeeply(ctx, reference.ChildID, false, false)
+		if err != nil {
+			return 0, err
 		}
+		deleted += count
 	}
 
-	// delete all tags that attached to the root artifact
-	if isRoot {
+	// delete the accessories that can't live without the artifact
+	for _, acc := range art.Accessories {
+		if !acc.IsHard() {
+			continue
+		}
+		count, err := c.deleteDeeply(ctx, acc.GetData().ArtifactID, false, true)
+		if err != nil {
+			return 0, err
+		}
+		deleted += count
+	}
+
+	// remove the linkage between the artifact and its accessories or subject artifact
+	if err = c.accessoryMgr.DeleteAccessories(ctx, q.New(q.KeyWords{"SubjectArtifactID": art.ID})); err != nil {
+		return 0, err
+	}
+	if err = c.accessoryMgr.DeleteAccessories(ctx, q.New(q.KeyWords{"ArtifactID": art.ID})); err != nil {
+		return 0, err
+	}
+
+	// delete all tags that attached to the root artifact or the accessory
+	if isRoot || isAccessory {
 		var ids []int64
 		for _, tag := range art.Tags {
 			ids = append(ids, tag.ID)
 		}
 		if err = c.tagCtl.DeleteTags(ctx, ids); err != nil {
-			return err
+			return 0, err
 		}
 	}
 
 	// remove labels added to the artifact
 	if err := c.labelMgr.RemoveAllFrom(ctx, id); err != nil {
-		return err
+		return 0, err
 	}
 
 	// delete the artifact itself
 	if err = c.artMgr.Delete(ctx, art.ID); err != nil {
 		// the child artifact doesn't exist, skip
 		if !isRoot && errors.IsErr(err, errors.NotFoundCode) {
-			return nil
+			return 0, nil
 		}
-		return err
+		return 0, err
 	}
 
 	blobs, err := c.blobMgr.List(ctx, q.New(q.KeyWords{"artifactDigest": art.Digest}))
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// clean associations between blob and project when the blob is not needed by project
 	if err := c.blobMgr.CleanupAssociationsForProject(ctx, art.ProjectID, blobs); err != nil {
-		return err
+		return 0, err
 	}
 
 	// use orm.WithTransaction here to avoid the issue:
@@ -377,7 +452,7 @@ func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot bool) er
 		})
 		return err
 	})(orm.SetTransactionOpNameToContext(ctx, "tx-delete-artifact-deeply")); err != nil && !errors.IsErr(err, errors.ConflictCode) {
-		return err
+		return 0, err
 	}
 
 	// only fire event for the root parent artifact
@@ -390,10 +465,11 @@ func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot bool) er
 			Ctx:      ctx,
 			Artifact: &art.Artifact,
 			Tags:     tags,
+			Deleted:  deleted,
 		})
 	}
 
-	return nil
+	return deleted, nil
 }
 
 func (c *controller) Copy(ctx context.Context, srcRepo, reference, dstRepo string) (int64, error) {
@@ -406,9 +482,9 @@ func (c *controller) Copy(ctx context.Context, srcRepo, reference, dstRepo strin
 // "copyDeeply" iterates the child artifacts and copy them first
 func (c *controller) copyDeeply(ctx context.Context, srcRepo, reference, dstRepo string, isRoot bool) (int64, error) {
 	var option *Option
-	// only get the tags of the root parent
+	// only get the tags and accessories of the root parent
 	if isRoot {
-		option = &Option{WithTag: true}
+		option = &Option{WithTag: true, WithAccessory: true}
 	}
 
 	srcArt, err := c.GetByReference(ctx, srcRepo, reference, option)
@@ -456,6 +532,18 @@ tags:
 	if err != nil {
 		return 0, err
 	}
+
+	// copy the accessories of the root parent and link them to the copied artifact
+	for _, acc := range srcArt.Accessories {
+		data := acc.GetData()
+		accID, err := c.copyDeeply(ctx, srcRepo, data.Digest, dstRepo, true)
+		if err != nil {
+			return 0, err
+		}
+		if err = c.accessoryMgr.Ensure(ctx, digest, dstRepo, id, accID, data.Size, data.Digest, data.Type); err != nil {
+			return 0, err
+		}
+	}
 	return id, nil
 }
 
@@ -582,6 +670,9 @@ func (c *controller) assembleArtifact(ctx context.Context, art *artifact.Artifac
 	if option.WithLabel {
 		c.populateLabels(ctx, artifact)
 	}
+	if option.WithAccessory {
+		c.populateAccessories(ctx, artifact)
+	}
 	return artifact
 }
 
@@ -617,6 +708,15 @@ func (c *controller) populateLabels(ctx context.Context, art *Artifact) {
 	art.Labels = labels
 }
 
+func (c *controller) populateAccessories(ctx context.Context, art *Artifact) {
+	accs, err := c.accessoryMgr.List(ctx, q.New(q.KeyWords{"SubjectArtifactID": art.ID}))
+	if err != nil {
+		log.Errorf("failed to list accessories of artifact %d: %v", art.ID, err)
+		return
+	}
+	art.Accessories = accs
+}
+
 func (c *controller) populateAdditionLinks(ctx context.Context, artifact *Artifact) {
 	types := processor.Get(artifact.MediaType).ListAdditionTypes(ctx, &artifact.Artifact)
 	if len(types) > 0 {
@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/distribution"
 	"github.com/goharbor/harbor/src/controller/artifact/processor/chart"
 	"github.com/goharbor/harbor/src/controller/artifact/processor/cnab"
 	"github.com/goharbor/harbor/src/controller/artifact/processor/image"
@@ -28,12 +29,15 @@ import (
 	"github.com/goharbor/harbor/src/lib/icon"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	basemodel "github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	"github.com/goharbor/harbor/src/pkg/artifact"
 	"github.com/goharbor/harbor/src/pkg/label/model"
 	repomodel "github.com/goharbor/harbor/src/pkg/repository/model"
 	model_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
 	tagtesting "github.com/goharbor/harbor/src/testing/controller/tag"
 	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
 	arttesting "github.com/goharbor/harbor/src/testing/pkg/artifact"
 	artrashtesting "github.com/goharbor/harbor/src/testing/pkg/artifactrash"
 	"github.com/goharbor/harbor/src/testing/pkg/blob"
@@ -69,6 +73,7 @@ type controllerTestSuite struct {
 	abstractor   *fakeAbstractor
 	immutableMtr *immutable.FakeMatcher
 	regCli       *registry.FakeClient
+	accMgr       *accessorytesting.Manager
 }
 
 func (c *controllerTestSuite) SetupTest() {
@@ -81,6 +86,7 @@ func (c *controllerTestSuite) SetupTest() {
 	c.abstractor = &fakeAbstractor{}
 	c.immutableMtr = &immutable.FakeMatcher{}
 	c.regCli = &registry.FakeClient{}
+	c.accMgr = &accessorytesting.Manager{}
 	c.ctl = &controller{
 		repoMgr:      c.repoMgr,
 		artMgr:       c.artMgr,
@@ -91,6 +97,7 @@ func (c *controllerTestSuite) SetupTest() {
 		abstractor:   c.abstractor,
 		immutableMtr: c.immutableMtr,
 		regCli:       c.regCli,
+		accessoryMgr: c.accMgr,
 	}
 }
 
@@ -105,7 +112,8 @@ func (c *controllerTestSuite) TestAssembleArtifact() {
 		TagOption: &tag.Option{
 			WithImmutableStatus: false,
 		},
-		WithLabel: true,
+		WithLabel:     true,
+		WithAccessory: true,
 	}
 	tg := &tag.Tag{
 		Tag: model_tag.Tag{
@@ -126,12 +134,24 @@ func (c *controllerTestSuite) TestAssembleArtifact() {
 	c.labelMgr.On("ListByArtifact", mock.Anything, mock.Anything).Return([]*model.Label{
 		lb,
 	}, nil)
+	acc := &basemodel.Default{
+		Data: accessorymodel.AccessoryData{
+			ID:                1,
+			ArtifactID:        2,
+			SubjectArtifactID: 1,
+			Type:              accessorymodel.TypeCosignSignature,
+		},
+	}
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{
+		acc,
+	}, nil)
 	artifact := c.ctl.assembleArtifact(ctx, art, option)
 	c.Require().NotNil(artifact)
 	c.Equal(art.ID, artifact.ID)
 	c.Equal(icon.DigestOfIconDefault, artifact.Icon)
 	c.Contains(artifact.Tags, tg)
 	c.Contains(artifact.Labels, lb)
+	c.Contains(artifact.Accessories, acc)
 	// TODO check other fields of option
 }
 
@@ -213,6 +233,7 @@ func (c *controllerTestSuite) TestEnsureArtifact() {
 	c.artMgr.On("GetByDigest", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
 	c.artMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
 	c.abstractor.On("AbstractMetadata").Return(nil)
+	c.accMgr.On("AttachSubject", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	created, art, err = c.ctl.ensureArtifact(orm.NewContext(nil, &ormtesting.FakeOrmer{}), "library/hello-world", digest)
 	c.Require().Nil(err)
 	c.True(created)
@@ -229,6 +250,7 @@ func (c *controllerTestSuite) TestEnsure() {
 	c.artMgr.On("GetByDigest", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
 	c.artMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
 	c.abstractor.On("AbstractMetadata").Return(nil)
+	c.accMgr.On("AttachSubject", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	c.tagCtl.On("Ensure").Return(nil)
 	_, id, err := c.ctl.Ensure(orm.NewContext(nil, &ormtesting.FakeOrmer{}), "library/hello-world", digest, "latest")
 	c.Require().Nil(err)
@@ -239,6 +261,50 @@ func (c *controllerTestSuite) TestEnsure() {
 	c.Equal(int64(1), id)
 }
 
+func (c *controllerTestSuite) TestEnsureSubject() {
+	art := &artifact.Artifact{
+		ID:             2,
+		RepositoryName: "library/hello-world",
+		Digest:         "sha256:sbom",
+	}
+
+	// the artifact doesn't declare the subject
+	c.accMgr.On("AttachSubject", mock.Anything, "library/hello-world", "sha256:sbom", int64(2)).Return(nil)
+	err := c.ctl.ensureSubject(nil, art)
+	c.Require().Nil(err)
+	c.accMgr.AssertNotCalled(c.T(), "Ensure", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// reset the mock
+	c.SetupTest()
+
+	// the subject artifact exists
+	art.SubjectDigest = "sha256:subject"
+	c.accMgr.On("AttachSubject", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.artMgr.On("GetByDigest", mock.Anything, "library/hello-world", "sha256:subject").Return(&artifact.Artifact{
+		ID: 1,
+	}, nil)
+	c.regCli.On("ManifestExist").Return(true, &distribution.Descriptor{Size: 512}, nil)
+	c.accMgr.On("Ensure", mock.Anything, "sha256:subject", "library/hello-world", int64(1), int64(2),
+		int64(512), "sha256:sbom", accessorymodel.TypeSubject).Return(nil)
+	err = c.ctl.ensureSubject(nil, art)
+	c.Require().Nil(err)
+	c.accMgr.AssertExpectations(c.T())
+
+	// reset the mock
+	c.SetupTest()
+
+	// the subject artifact hasn't been pushed yet
+	c.accMgr.On("AttachSubject", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.artMgr.On("GetByDigest", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
+	c.regCli.On("ManifestExist").Return(true, &distribution.Descriptor{Size: 512}, nil)
+	c.accMgr.On("Ensure", mock.Anything, "sha256:subject", "library/hello-world", int64(0), int64(2),
+		int64(512), "sha256:sbom", accessorymodel.TypeSubject).Return(nil)
+	err = c.ctl.ensureSubject(nil, art)
+	c.Require().Nil(err)
+	c.accMgr.AssertExpectations(c.T())
+}
+
 func (c *controllerTestSuite) TestCount() {
 	c.artMgr.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
 	total, err := c.ctl.Count(nil, nil)
@@ -406,7 +472,7 @@ func (c *controllerTestSuite) TestGetByReference() {
 func (c *controllerTestSuite) TestDeleteDeeply() {
 	// root artifact and doesn't exist
 	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
-	err := c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true)
+	_, err := c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true, false)
 	c.Require().NotNil(err)
 	c.Assert().True(errors.IsErr(err, errors.NotFoundCode))
 
@@ -415,7 +481,7 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 
 	// child artifact and doesn't exist
 	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
-	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false)
+	_, err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false, false)
 	c.Require().Nil(err)
 
 	// reset the mock
@@ -423,6 +489,7 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 
 	// child artifact and contains tags
 	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&artifact.Artifact{ID: 1}, nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{}, nil)
 	c.artMgr.On("Delete", mock.Anything, mock.Anything).Return(nil)
 	c.tagCtl.On("List").Return([]*tag.Tag{
 		{
@@ -433,7 +500,7 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 	}, nil)
 	c.repoMgr.On("Get", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{}, nil)
 	c.artrashMgr.On("Create").Return(0, nil)
-	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false)
+	_, err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false, false)
 	c.Require().Nil(err)
 
 	// reset the mock
@@ -441,6 +508,7 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 
 	// root artifact is referenced by other artifacts
 	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&artifact.Artifact{ID: 1}, nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{}, nil)
 	c.tagCtl.On("List").Return(nil, nil)
 	c.repoMgr.On("Get", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{}, nil)
 	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return([]*artifact.Reference{
@@ -448,7 +516,7 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 			ID: 1,
 		},
 	}, nil)
-	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true)
+	_, err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true, false)
 	c.Require().NotNil(err)
 
 	// reset the mock
@@ -456,6 +524,7 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 
 	// child artifact contains no tag but referenced by other artifacts
 	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&artifact.Artifact{ID: 1}, nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{}, nil)
 	c.tagCtl.On("List").Return(nil, nil)
 	c.repoMgr.On("Get", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{}, nil)
 	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return([]*artifact.Reference{
@@ -463,8 +532,63 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 			ID: 1,
 		},
 	}, nil)
-	err = c.ctl.deleteDeeply(nil, 1, false)
+	_, err = c.ctl.deleteDeeply(nil, 1, false, false)
+	c.Require().Nil(err)
+
+	// reset the mock
+	c.SetupTest()
+
+	// accessory contains tags
+	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&artifact.Artifact{ID: 1}, nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{}, nil)
+	c.tagCtl.On("List").Return([]*tag.Tag{
+		{
+			Tag: model_tag.Tag{
+				ID: 1,
+			},
+		},
+	}, nil)
+	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return([]*artifact.Reference{}, nil)
+	c.accMgr.On("DeleteAccessories", mock.Anything, mock.Anything).Return(nil)
+	c.tagCtl.On("DeleteTags").Return(nil)
+	c.labelMgr.On("RemoveAllFrom", mock.Anything, mock.Anything).Return(nil)
+	c.artMgr.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	c.blobMgr.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+	c.blobMgr.On("CleanupAssociationsForProject", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.artrashMgr.On("Create").Return(0, nil)
+	deleted, err := c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false, true)
+	c.Require().Nil(err)
+	c.Equal(int64(1), deleted)
+	c.tagCtl.AssertCalled(c.T(), "DeleteTags")
+	c.accMgr.AssertNumberOfCalls(c.T(), "DeleteAccessories", 2)
+	c.artMgr.AssertCalled(c.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestDeleteDeeplyIndex() {
+	// the index and its untagged child are deleted
+	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(func(ctx context.Context, id int64) *artifact.Artifact {
+		art := &artifact.Artifact{ID: id}
+		if id == 1 {
+			art.References = []*artifact.Reference{{ID: 1, ParentID: 1, ChildID: 2}}
+		}
+		return art
+	}, nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{}, nil)
+	c.tagCtl.On("List").Return(nil, nil)
+	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return([]*artifact.Reference{}, nil)
+	c.artMgr.On("DeleteReference", mock.Anything, mock.Anything).Return(nil)
+	c.accMgr.On("DeleteAccessories", mock.Anything, mock.Anything).Return(nil)
+	c.tagCtl.On("DeleteTags").Return(nil)
+	c.labelMgr.On("RemoveAllFrom", mock.Anything, mock.Anything).Return(nil)
+	c.artMgr.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	c.blobMgr.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+	c.blobMgr.On("CleanupAssociationsForProject", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.artrashMgr.On("Create").Return(0, nil)
+
+	deleted, err := c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true, false)
 	c.Require().Nil(err)
+	c.Equal(int64(2), deleted)
+	c.artMgr.AssertNumberOfCalls(c.T(), "Delete", 2)
 }
 
 func (c *controllerTestSuite) TestCopy() {
@@ -487,6 +611,7 @@ func (c *controllerTestSuite) TestCopy() {
 		},
 	}, nil)
 	c.tagCtl.On("Update").Return(nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{}, nil)
 	c.repoMgr.On("Get", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{
 		RepositoryID: 1,
 		Name:         "library/hello-world",
@@ -494,6 +619,7 @@ func (c *controllerTestSuite) TestCopy() {
 	c.abstractor.On("AbstractMetadata").Return(nil)
 	c.artMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
 	c.regCli.On("Copy").Return(nil)
+	c.accMgr.On("AttachSubject", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	c.tagCtl.On("Ensure").Return(nil)
 	_, err := c.ctl.Copy(orm.NewContext(nil, &ormtesting.FakeOrmer{}), "library/hello-world", "latest", "library/hello-world2")
 	c.Require().Nil(err)
@@ -0,0 +1,84 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	bundleManifestFile = ".manifest"
+	packageKeyword     = "package"
+)
+
+// bundleManifest is the manifest of the OPA bundle,
+// see https://www.openpolicyagent.org/docs/latest/management-bundles/#bundle-file-format
+type bundleManifest struct {
+	Revision string                 `json:"revision"`
+	Roots    []string               `json:"roots"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// read the manifest from the bundle tarball, the bundle without manifest owns the whole data tree
+func readBundleManifest(r io.Reader) (*bundleManifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if path.Clean("/"+header.Name) != "/"+bundleManifestFile {
+			continue
+		}
+		mani := &bundleManifest{}
+		if err := json.NewDecoder(tr).Decode(mani); err != nil {
+			return nil, err
+		}
+		if mani.Roots == nil {
+			mani.Roots = []string{""}
+		}
+		return mani, nil
+	}
+	return &bundleManifest{Roots: []string{""}}, nil
+}
+
+// policyRoot returns the root path of the policy according to its package, e.g. the root
+// of the policy with the package "kubernetes.admission" is "kubernetes/admission"
+func policyRoot(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != packageKeyword {
+			continue
+		}
+		return strings.ReplaceAll(fields[1], ".", "/"), nil
+	}
+	return "", scanner.Err()
+}
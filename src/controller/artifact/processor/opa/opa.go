@@ -0,0 +1,146 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	ps "github.com/goharbor/harbor/src/controller/artifact/processor"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// const definitions
+const (
+	// ArtifactTypeOPA defines the artifact type for OPA policy bundle
+	ArtifactTypeOPA      = "OPA"
+	AdditionTypeManifest = "MANIFEST"
+
+	// the media types used by conftest and the OPA OCI bundles
+	mediaTypeConfig      = "application/vnd.cncf.openpolicyagent.config.v1+json"
+	mediaTypePolicyLayer = "application/vnd.cncf.openpolicyagent.policy.layer.v1+rego"
+	// the bundle tarball built by "opa build"
+	mediaTypeBundleLayerSuffix = "tar+gzip"
+)
+
+func init() {
+	pc := &processor{}
+	pc.ManifestProcessor = base.NewManifestProcessor()
+	if err := ps.Register(pc, mediaTypeConfig); err != nil {
+		log.Errorf("failed to register processor for media type %s: %v", mediaTypeConfig, err)
+		return
+	}
+}
+
+type processor struct {
+	*base.ManifestProcessor
+}
+
+func (p *processor) AbstractAddition(ctx context.Context, artifact *artifact.Artifact, addition string) (*ps.Addition, error) {
+	if addition != AdditionTypeManifest {
+		return nil, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("addition %s isn't supported for %s", addition, ArtifactTypeOPA)
+	}
+
+	m, _, err := p.RegCli.PullManifest(artifact.RepositoryName, artifact.Digest)
+	if err != nil {
+		return nil, err
+	}
+	_, payload, err := m.Payload()
+	if err != nil {
+		return nil, err
+	}
+	manifest := &v1.Manifest{}
+	if err := json.Unmarshal(payload, manifest); err != nil {
+		return nil, err
+	}
+
+	var bundleMani *bundleManifest
+	roots := map[string]struct{}{}
+	for _, layer := range manifest.Layers {
+		switch {
+		// the bundle tarball contains the manifest of the bundle
+		case strings.HasSuffix(layer.MediaType, mediaTypeBundleLayerSuffix):
+			if err := p.readLayer(artifact.RepositoryName, layer.Digest.String(), func(r io.Reader) (err error) {
+				bundleMani, err = readBundleManifest(r)
+				return err
+			}); err != nil {
+				return nil, err
+			}
+		// the policies pushed by conftest as separated layers, the roots come from the packages of policies
+		case layer.MediaType == mediaTypePolicyLayer:
+			if err := p.readLayer(artifact.RepositoryName, layer.Digest.String(), func(r io.Reader) error {
+				root, err := policyRoot(r)
+				if err != nil {
+					return err
+				}
+				if len(root) > 0 {
+					roots[root] = struct{}{}
+				}
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if bundleMani != nil {
+			break
+		}
+	}
+
+	if bundleMani == nil {
+		if len(roots) == 0 {
+			return nil, errors.New(nil).WithCode(errors.NotFoundCode).
+				WithMessage("the policies of %s not found", artifact.String())
+		}
+		bundleMani = &bundleManifest{}
+		for root := range roots {
+			bundleMani.Roots = append(bundleMani.Roots, root)
+		}
+		sort.Strings(bundleMani.Roots)
+	}
+
+	content, err := json.Marshal(bundleMani)
+	if err != nil {
+		return nil, err
+	}
+	return &ps.Addition{
+		Content:     content,
+		ContentType: "application/json; charset=utf-8",
+	}, nil
+}
+
+func (p *processor) GetArtifactType(ctx context.Context, artifact *artifact.Artifact) string {
+	return ArtifactTypeOPA
+}
+
+func (p *processor) ListAdditionTypes(ctx context.Context, artifact *artifact.Artifact) []string {
+	return []string{AdditionTypeManifest}
+}
+
+func (p *processor) readLayer(repository, digest string, read func(r io.Reader) error) error {
+	_, blob, err := p.RegCli.PullBlob(repository, digest)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+	return read(blob)
+}
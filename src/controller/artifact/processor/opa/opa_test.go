@@ -0,0 +1,114 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	policyManifest     = `{"schemaVersion":2,"config":{"mediaType":"application/vnd.cncf.openpolicyagent.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[{"mediaType":"application/vnd.cncf.openpolicyagent.policy.layer.v1+rego","digest":"sha256:0bd64cfb958b68c71b46597e22185a41e784dc96e04090bc7d2a480b704c3b65","size":120,"annotations":{"org.opencontainers.image.title":"policy/deny.rego"}},{"mediaType":"application/vnd.cncf.openpolicyagent.data.layer.v1+json","digest":"sha256:1b930d010525941c1d56ec53b97bd057a67ae1865eebf042686d2a2d18271ced","size":20,"annotations":{"org.opencontainers.image.title":"data/exclusions.json"}}]}`
+	bundleManifestJSON = `{"schemaVersion":2,"config":{"mediaType":"application/vnd.cncf.openpolicyagent.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":"sha256:0bd64cfb958b68c71b46597e22185a41e784dc96e04090bc7d2a480b704c3b65","size":1024}]}`
+	policy             = `# METADATA
+# title: Deny the privileged containers
+package kubernetes.admission
+
+deny[msg] {
+  input.request.kind.kind == "Pod"
+  msg := "privileged containers are not allowed"
+}`
+)
+
+type processorTestSuite struct {
+	suite.Suite
+	processor *processor
+	regCli    *registry.FakeClient
+}
+
+func (p *processorTestSuite) SetupTest() {
+	p.regCli = &registry.FakeClient{}
+	p.processor = &processor{}
+	p.processor.ManifestProcessor = &base.ManifestProcessor{RegCli: p.regCli}
+}
+
+func (p *processorTestSuite) TestAbstractAdditionOfPolicies() {
+	// unknown addition
+	_, err := p.processor.AbstractAddition(nil, nil, "unknown_addition")
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(policyManifest))
+	p.Require().Nil(err)
+	p.regCli.On("PullManifest").Return(manifest, "", nil)
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(strings.NewReader(policy)), nil)
+	addition, err := p.processor.AbstractAddition(nil, &artifact.Artifact{}, AdditionTypeManifest)
+	p.Require().Nil(err)
+	p.Equal("application/json; charset=utf-8", addition.ContentType)
+	p.JSONEq(`{"revision":"","roots":["kubernetes/admission"]}`, string(addition.Content))
+}
+
+func (p *processorTestSuite) TestAbstractAdditionOfBundle() {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	files := map[string]string{
+		"/kubernetes/admission/policy.rego": policy,
+		"/.manifest":                        `{"revision":"v1.0.0","roots":["kubernetes"],"metadata":{"owner":"harbor"}}`,
+	}
+	for name, content := range files {
+		p.Require().Nil(tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0600,
+			Size: int64(len(content)),
+		}))
+		_, err := tw.Write([]byte(content))
+		p.Require().Nil(err)
+	}
+	p.Require().Nil(tw.Close())
+	p.Require().Nil(gw.Close())
+
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(bundleManifestJSON))
+	p.Require().Nil(err)
+	p.regCli.On("PullManifest").Return(manifest, "", nil)
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(buf), nil)
+	addition, err := p.processor.AbstractAddition(nil, &artifact.Artifact{}, AdditionTypeManifest)
+	p.Require().Nil(err)
+	p.JSONEq(`{"revision":"v1.0.0","roots":["kubernetes"],"metadata":{"owner":"harbor"}}`, string(addition.Content))
+}
+
+func (p *processorTestSuite) TestGetArtifactType() {
+	p.Assert().Equal(ArtifactTypeOPA, p.processor.GetArtifactType(nil, nil))
+}
+
+func (p *processorTestSuite) TestListAdditionTypes() {
+	additions := p.processor.ListAdditionTypes(nil, nil)
+	p.EqualValues([]string{AdditionTypeManifest}, additions)
+}
+
+func TestProcessorTestSuite(t *testing.T) {
+	suite.Run(t, &processorTestSuite{})
+}
@@ -0,0 +1,216 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+const (
+	// FormatSPDX is the format of the SPDX document
+	FormatSPDX = "SPDX"
+	// FormatCycloneDX is the format of the CycloneDX document
+	FormatCycloneDX = "CycloneDX"
+
+	spdxToolPrefix        = "Tool:"
+	cycloneDXNamespaceURL = "http://cyclonedx.org/schema/bom/"
+)
+
+// document is the summary of the SBOM document
+type document struct {
+	Format       string
+	SpecVersion  string
+	PackageCount int
+	CreatorTool  string
+}
+
+// parse the SBOM document according to its media type
+func parse(mediaType string, content []byte) (*document, error) {
+	switch mediaType {
+	case MediaTypeSPDXJSON:
+		return parseSPDXJSON(content)
+	case MediaTypeSPDX:
+		return parseSPDXTagValue(content)
+	case MediaTypeCycloneDXJSON:
+		return parseCycloneDXJSON(content)
+	case MediaTypeCycloneDXXML:
+		return parseCycloneDXXML(content)
+	default:
+		return nil, fmt.Errorf("unsupported SBOM media type: %s", mediaType)
+	}
+}
+
+type spdxDocument struct {
+	SPDXVersion  string `json:"spdxVersion"`
+	CreationInfo struct {
+		Creators []string `json:"creators"`
+	} `json:"creationInfo"`
+	Packages []json.RawMessage `json:"packages"`
+}
+
+func parseSPDXJSON(content []byte) (*document, error) {
+	doc := &spdxDocument{}
+	if err := json.Unmarshal(content, doc); err != nil {
+		return nil, err
+	}
+	return &document{
+		Format:       FormatSPDX,
+		SpecVersion:  doc.SPDXVersion,
+		PackageCount: len(doc.Packages),
+		CreatorTool:  spdxCreatorTool(doc.CreationInfo.Creators),
+	}, nil
+}
+
+// the SPDX document in tag-value format, e.g.
+// SPDXVersion: SPDX-2.2
+// Creator: Tool: syft-0.30.1
+// PackageName: alpine-baselayout
+func parseSPDXTagValue(content []byte) (*document, error) {
+	doc := &document{
+		Format: FormatSPDX,
+	}
+	var creators []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		tag, value, ok := splitTagValue(scanner.Text())
+		if !ok {
+			continue
+		}
+		switch tag {
+		case "SPDXVersion":
+			doc.SpecVersion = value
+		case "Creator":
+			creators = append(creators, value)
+		case "PackageName":
+			doc.PackageCount++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(doc.SpecVersion) == 0 {
+		return nil, fmt.Errorf("invalid SPDX document: SPDXVersion not found")
+	}
+	doc.CreatorTool = spdxCreatorTool(creators)
+	return doc, nil
+}
+
+func splitTagValue(line string) (string, string, bool) {
+	strs := strings.SplitN(line, ":", 2)
+	if len(strs) != 2 {
+		return "", "", false
+	}
+	return strings.TrimSpace(strs[0]), strings.TrimSpace(strs[1]), true
+}
+
+// the creators of SPDX document are in format "Tool: <name>-<version>", "Organization: <name>" or "Person: <name>"
+func spdxCreatorTool(creators []string) string {
+	var tools []string
+	for _, creator := range creators {
+		if strings.HasPrefix(creator, spdxToolPrefix) {
+			tools = append(tools, strings.TrimSpace(strings.TrimPrefix(creator, spdxToolPrefix)))
+		}
+	}
+	return strings.Join(tools, ", ")
+}
+
+type cycloneDXTool struct {
+	Name    string `json:"name" xml:"name"`
+	Version string `json:"version" xml:"version"`
+}
+
+func (c *cycloneDXTool) String() string {
+	if len(c.Version) == 0 {
+		return c.Name
+	}
+	return fmt.Sprintf("%s-%s", c.Name, c.Version)
+}
+
+type cycloneDXDocument struct {
+	BOMFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Metadata    struct {
+		// the tools is an array before CycloneDX 1.5 and an object containing the components and services since 1.5
+		Tools json.RawMessage `json:"tools"`
+	} `json:"metadata"`
+	Components []json.RawMessage `json:"components"`
+}
+
+func parseCycloneDXJSON(content []byte) (*document, error) {
+	doc := &cycloneDXDocument{}
+	if err := json.Unmarshal(content, doc); err != nil {
+		return nil, err
+	}
+	if doc.BOMFormat != FormatCycloneDX {
+		return nil, fmt.Errorf("invalid CycloneDX document: unexpected bomFormat %s", doc.BOMFormat)
+	}
+
+	var tools []*cycloneDXTool
+	if len(doc.Metadata.Tools) > 0 {
+		if err := json.Unmarshal(doc.Metadata.Tools, &tools); err != nil {
+			toolsObj := &struct {
+				Components []*cycloneDXTool `json:"components"`
+			}{}
+			if err := json.Unmarshal(doc.Metadata.Tools, toolsObj); err != nil {
+				return nil, err
+			}
+			tools = toolsObj.Components
+		}
+	}
+	return &document{
+		Format:       FormatCycloneDX,
+		SpecVersion:  doc.SpecVersion,
+		PackageCount: len(doc.Components),
+		CreatorTool:  cycloneDXCreatorTool(tools),
+	}, nil
+}
+
+type cycloneDXXMLDocument struct {
+	XMLName  xml.Name `xml:"bom"`
+	Metadata struct {
+		Tools []*cycloneDXTool `xml:"tools>tool"`
+	} `xml:"metadata"`
+	Components []struct{} `xml:"components>component"`
+}
+
+func parseCycloneDXXML(content []byte) (*document, error) {
+	doc := &cycloneDXXMLDocument{}
+	if err := xml.Unmarshal(content, doc); err != nil {
+		return nil, err
+	}
+	// the spec version is specified by the namespace, e.g. http://cyclonedx.org/schema/bom/1.4
+	if !strings.HasPrefix(doc.XMLName.Space, cycloneDXNamespaceURL) {
+		return nil, fmt.Errorf("invalid CycloneDX document: unexpected namespace %s", doc.XMLName.Space)
+	}
+	return &document{
+		Format:       FormatCycloneDX,
+		SpecVersion:  strings.TrimPrefix(doc.XMLName.Space, cycloneDXNamespaceURL),
+		PackageCount: len(doc.Components),
+		CreatorTool:  cycloneDXCreatorTool(doc.Metadata.Tools),
+	}, nil
+}
+
+func cycloneDXCreatorTool(tools []*cycloneDXTool) string {
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.String())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSPDXTagValue(t *testing.T) {
+	content := `SPDXVersion: SPDX-2.2
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: library/hello-world
+Creator: Organization: Anchore, Inc
+Creator: Tool: syft-0.30.1
+Created: 2021-11-01T08:00:00Z
+
+##### Package: alpine-baselayout
+
+PackageName: alpine-baselayout
+SPDXID: SPDXRef-Package-apk-alpine-baselayout
+PackageVersion: 3.2.0-r16
+
+##### Package: busybox
+
+PackageName: busybox
+SPDXID: SPDXRef-Package-apk-busybox
+PackageVersion: 1.33.1-r3
+`
+	doc, err := parse(MediaTypeSPDX, []byte(content))
+	require.Nil(t, err)
+	assert.Equal(t, FormatSPDX, doc.Format)
+	assert.Equal(t, "SPDX-2.2", doc.SpecVersion)
+	assert.Equal(t, 2, doc.PackageCount)
+	assert.Equal(t, "syft-0.30.1", doc.CreatorTool)
+
+	// invalid document
+	_, err = parse(MediaTypeSPDX, []byte("invalid"))
+	assert.NotNil(t, err)
+}
+
+func TestParseCycloneDXJSONWithToolComponents(t *testing.T) {
+	content := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {
+    "tools": {
+      "components": [
+        {"type": "application", "author": "anchore", "name": "syft", "version": "0.100.0"}
+      ]
+    }
+  },
+  "components": [
+    {"type": "library", "name": "busybox", "version": "1.36.1-r15"}
+  ]
+}`
+	doc, err := parse(MediaTypeCycloneDXJSON, []byte(content))
+	require.Nil(t, err)
+	assert.Equal(t, FormatCycloneDX, doc.Format)
+	assert.Equal(t, "1.5", doc.SpecVersion)
+	assert.Equal(t, 1, doc.PackageCount)
+	assert.Equal(t, "syft-0.100.0", doc.CreatorTool)
+
+	// not a CycloneDX document
+	_, err = parse(MediaTypeCycloneDXJSON, []byte(`{"spdxVersion": "SPDX-2.2"}`))
+	assert.NotNil(t, err)
+}
+
+func TestParseCycloneDXXML(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" serialNumber="urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" version="1">
+  <metadata>
+    <tools>
+      <tool>
+        <vendor>anchore</vendor>
+        <name>syft</name>
+        <version>0.60.3</version>
+      </tool>
+    </tools>
+  </metadata>
+  <components>
+    <component type="library">
+      <name>alpine-baselayout</name>
+      <version>3.2.0-r16</version>
+    </component>
+    <component type="library">
+      <name>busybox</name>
+      <version>1.33.1-r3</version>
+    </component>
+  </components>
+</bom>`
+	doc, err := parse(MediaTypeCycloneDXXML, []byte(content))
+	require.Nil(t, err)
+	assert.Equal(t, FormatCycloneDX, doc.Format)
+	assert.Equal(t, "1.4", doc.SpecVersion)
+	assert.Equal(t, 2, doc.PackageCount)
+	assert.Equal(t, "syft-0.60.3", doc.CreatorTool)
+}
+
+func TestParseUnsupported(t *testing.T) {
+	_, err := parse("application/json", []byte("{}"))
+	assert.NotNil(t, err)
+}
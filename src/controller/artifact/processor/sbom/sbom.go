@@ -0,0 +1,185 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	ps "github.com/goharbor/harbor/src/controller/artifact/processor"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// const definitions
+const (
+	// ArtifactTypeSBOM defines the artifact type for SBOM document
+	ArtifactTypeSBOM = "SBOM"
+	AdditionTypeSBOM = "SBOM"
+
+	// the media types of the SBOM documents
+	MediaTypeSPDXJSON      = "application/spdx+json"
+	MediaTypeSPDX          = "text/spdx"
+	MediaTypeCycloneDXJSON = "application/vnd.cyclonedx+json"
+	MediaTypeCycloneDXXML  = "application/vnd.cyclonedx+xml"
+
+	// the keys of the extra attributes abstracted from the SBOM document
+	attrFormat       = "format"
+	attrSpecVersion  = "spec_version"
+	attrPackageCount = "package_count"
+	attrCreatorTool  = "creator_tool"
+)
+
+// the SBOM document is read into memory to be parsed and returned as the addition, limit its size
+var maxDocumentSize int64 = 32 << 20
+
+var mediaTypes = []string{
+	MediaTypeSPDXJSON,
+	MediaTypeSPDX,
+	MediaTypeCycloneDXJSON,
+	MediaTypeCycloneDXXML,
+}
+
+func init() {
+	pc := &processor{}
+	pc.ManifestProcessor = base.NewManifestProcessor()
+	if err := ps.Register(pc, mediaTypes...); err != nil {
+		log.Errorf("failed to register processor for media type %v: %v", mediaTypes, err)
+		return
+	}
+}
+
+// processor processes the SBOM document enveloped by OCI manifest, the document
+// is stored in the layer and the media type of the config or the artifact type
+// declared in the manifest is one of the SBOM media types
+type processor struct {
+	*base.ManifestProcessor
+}
+
+func (p *processor) AbstractMetadata(ctx context.Context, art *artifact.Artifact, manifest []byte) error {
+	mediaType, content, err := p.pullDocument(art, manifest)
+	if err != nil {
+		// the SBOM document that is too large is still stored without the metadata
+		if errors.IsErr(err, errors.BadRequestCode) {
+			log.G(ctx).Warningf("skip abstracting the metadata of artifact %s: %v", art.String(), err)
+			return nil
+		}
+		return err
+	}
+	doc, err := parse(mediaType, content)
+	if err != nil {
+		// the SBOM document is still stored even it cannot be parsed
+		log.G(ctx).Warningf("failed to parse the SBOM document of artifact %s: %v", art.String(), err)
+		return nil
+	}
+	if art.ExtraAttrs == nil {
+		art.ExtraAttrs = map[string]interface{}{}
+	}
+	art.ExtraAttrs[attrFormat] = doc.Format
+	art.ExtraAttrs[attrSpecVersion] = doc.SpecVersion
+	art.ExtraAttrs[attrPackageCount] = doc.PackageCount
+	art.ExtraAttrs[attrCreatorTool] = doc.CreatorTool
+	return nil
+}
+
+func (p *processor) AbstractAddition(ctx context.Context, art *artifact.Artifact, addition string) (*ps.Addition, error) {
+	if addition != AdditionTypeSBOM {
+		return nil, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("addition %s isn't supported for %s", addition, ArtifactTypeSBOM)
+	}
+
+	m, _, err := p.RegCli.PullManifest(art.RepositoryName, art.Digest)
+	if err != nil {
+		return nil, err
+	}
+	_, payload, err := m.Payload()
+	if err != nil {
+		return nil, err
+	}
+	mediaType, content, err := p.pullDocument(art, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &ps.Addition{
+		Content:     content,
+		ContentType: mediaType,
+	}, nil
+}
+
+func (p *processor) GetArtifactType(ctx context.Context, artifact *artifact.Artifact) string {
+	return ArtifactTypeSBOM
+}
+
+func (p *processor) ListAdditionTypes(ctx context.Context, artifact *artifact.Artifact) []string {
+	return []string{AdditionTypeSBOM}
+}
+
+// pull the SBOM document from the layer of the manifest, returns the media type and the content of the document
+func (p *processor) pullDocument(art *artifact.Artifact, manifest []byte) (string, []byte, error) {
+	mani := &v1.Manifest{}
+	if err := json.Unmarshal(manifest, mani); err != nil {
+		return "", nil, err
+	}
+	if len(mani.Layers) == 0 {
+		return "", nil, errors.New(nil).WithCode(errors.NotFoundCode).
+			WithMessage("the SBOM document of artifact %s not found", art.String())
+	}
+
+	// use the layer with the SBOM media type, or the first layer if the layer's media type is generic,
+	// in which case the format of the document is identified by the media type of the artifact
+	layer := mani.Layers[0]
+	mediaType := art.MediaType
+	for _, l := range mani.Layers {
+		if isSBOMMediaType(l.MediaType) {
+			layer = l
+			mediaType = l.MediaType
+			break
+		}
+	}
+
+	tooLarge := errors.New(nil).WithCode(errors.BadRequestCode).
+		WithMessage("the size of the SBOM document of artifact %s exceeds the limit %d bytes", art.String(), maxDocumentSize)
+	if layer.Size > maxDocumentSize {
+		return "", nil, tooLarge
+	}
+	_, blob, err := p.RegCli.PullBlob(art.RepositoryName, layer.Digest.String())
+	if err != nil {
+		return "", nil, err
+	}
+	defer blob.Close()
+	// the size in the descriptor may be inconsistent with the blob, so the content is limited as well
+	content, err := ioutil.ReadAll(io.LimitReader(blob, maxDocumentSize+1))
+	if err != nil {
+		return "", nil, err
+	}
+	if int64(len(content)) > maxDocumentSize {
+		return "", nil, tooLarge
+	}
+	return mediaType, content, nil
+}
+
+func isSBOMMediaType(mediaType string) bool {
+	for _, mt := range mediaTypes {
+		if mt == mediaType {
+			return true
+		}
+	}
+	return false
+}
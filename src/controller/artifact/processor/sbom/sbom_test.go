@@ -0,0 +1,163 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	spdxManifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/spdx+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[{"mediaType":"application/spdx+json","digest":"sha256:0bd64cfb958b68c71b46597e22185a41e784dc96e04090bc7d2a480b704c3b65","size":1024}]}`
+	spdxJSON     = `{
+  "spdxVersion": "SPDX-2.2",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "library/hello-world",
+  "creationInfo": {
+    "created": "2021-11-01T08:00:00Z",
+    "creators": [
+      "Organization: Anchore, Inc",
+      "Tool: syft-0.30.1"
+    ]
+  },
+  "packages": [
+    {"SPDXID": "SPDXRef-Package-apk-alpine-baselayout", "name": "alpine-baselayout", "versionInfo": "3.2.0-r16"},
+    {"SPDXID": "SPDXRef-Package-apk-busybox", "name": "busybox", "versionInfo": "1.33.1-r3"}
+  ]
+}`
+	cycloneDXManifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.cyclonedx+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar","digest":"sha256:0bd64cfb958b68c71b46597e22185a41e784dc96e04090bc7d2a480b704c3b65","size":1024}]}`
+	cycloneDXJSON     = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.3",
+  "version": 1,
+  "metadata": {
+    "tools": [
+      {"vendor": "anchore", "name": "syft", "version": "0.30.1"}
+    ]
+  },
+  "components": [
+    {"type": "library", "name": "alpine-baselayout", "version": "3.2.0-r16"},
+    {"type": "library", "name": "busybox", "version": "1.33.1-r3"},
+    {"type": "library", "name": "musl", "version": "1.2.2-r3"}
+  ]
+}`
+)
+
+type processorTestSuite struct {
+	suite.Suite
+	processor *processor
+	regCli    *registry.FakeClient
+}
+
+func (p *processorTestSuite) SetupTest() {
+	p.regCli = &registry.FakeClient{}
+	p.processor = &processor{}
+	p.processor.ManifestProcessor = &base.ManifestProcessor{RegCli: p.regCli}
+}
+
+func (p *processorTestSuite) TestAbstractMetadataOfSPDX() {
+	art := &artifact.Artifact{
+		MediaType: MediaTypeSPDXJSON,
+	}
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(strings.NewReader(spdxJSON)), nil)
+	err := p.processor.AbstractMetadata(nil, art, []byte(spdxManifest))
+	p.Require().Nil(err)
+	p.Equal(FormatSPDX, art.ExtraAttrs[attrFormat])
+	p.Equal("SPDX-2.2", art.ExtraAttrs[attrSpecVersion])
+	p.Equal(2, art.ExtraAttrs[attrPackageCount])
+	p.Equal("syft-0.30.1", art.ExtraAttrs[attrCreatorTool])
+}
+
+func (p *processorTestSuite) TestAbstractMetadataOfCycloneDX() {
+	// the layer's media type is generic, the format is identified by the media type of artifact
+	art := &artifact.Artifact{
+		MediaType: MediaTypeCycloneDXJSON,
+	}
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(strings.NewReader(cycloneDXJSON)), nil)
+	err := p.processor.AbstractMetadata(nil, art, []byte(cycloneDXManifest))
+	p.Require().Nil(err)
+	p.Equal(FormatCycloneDX, art.ExtraAttrs[attrFormat])
+	p.Equal("1.3", art.ExtraAttrs[attrSpecVersion])
+	p.Equal(3, art.ExtraAttrs[attrPackageCount])
+	p.Equal("syft-0.30.1", art.ExtraAttrs[attrCreatorTool])
+}
+
+func (p *processorTestSuite) TestAbstractMetadataOfInvalidDocument() {
+	// the invalid document doesn't block the pushing
+	art := &artifact.Artifact{
+		MediaType: MediaTypeSPDXJSON,
+	}
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(strings.NewReader("invalid")), nil)
+	err := p.processor.AbstractMetadata(nil, art, []byte(spdxManifest))
+	p.Require().Nil(err)
+	p.Nil(art.ExtraAttrs)
+}
+
+func (p *processorTestSuite) TestAbstractMetadataOfTooLargeDocument() {
+	originalSize := maxDocumentSize
+	defer func() { maxDocumentSize = originalSize }()
+	maxDocumentSize = int64(len(spdxJSON) - 1)
+
+	// the size of the layer in the manifest is inconsistent with the blob
+	manifest := strings.Replace(spdxManifest, `"size":1024`, `"size":1`, 1)
+	art := &artifact.Artifact{
+		MediaType: MediaTypeSPDXJSON,
+	}
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(strings.NewReader(spdxJSON)), nil)
+	err := p.processor.AbstractMetadata(nil, art, []byte(manifest))
+	p.Require().Nil(err)
+	p.Nil(art.ExtraAttrs)
+
+	// the size of the layer in the manifest exceeds the limit
+	_, _, err = p.processor.pullDocument(art, []byte(spdxManifest))
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func (p *processorTestSuite) TestAbstractAddition() {
+	// unknown addition
+	_, err := p.processor.AbstractAddition(nil, nil, "unknown_addition")
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(spdxManifest))
+	p.Require().Nil(err)
+	p.regCli.On("PullManifest").Return(manifest, "", nil)
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(strings.NewReader(spdxJSON)), nil)
+	addition, err := p.processor.AbstractAddition(nil, &artifact.Artifact{MediaType: MediaTypeSPDXJSON}, AdditionTypeSBOM)
+	p.Require().Nil(err)
+	p.Equal(MediaTypeSPDXJSON, addition.ContentType)
+	p.Equal(spdxJSON, string(addition.Content))
+}
+
+func (p *processorTestSuite) TestGetArtifactType() {
+	p.Assert().Equal(ArtifactTypeSBOM, p.processor.GetArtifactType(nil, nil))
+}
+
+func (p *processorTestSuite) TestListAdditionTypes() {
+	additions := p.processor.ListAdditionTypes(nil, nil)
+	p.EqualValues([]string{AdditionTypeSBOM}, additions)
+}
+
+func TestProcessorTestSuite(t *testing.T) {
+	suite.Run(t, &processorTestSuite{})
+}
@@ -0,0 +1,50 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sif
+
+import (
+	"context"
+
+	ps "github.com/goharbor/harbor/src/controller/artifact/processor"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+)
+
+// const definitions
+const (
+	// ArtifactTypeSIF defines the artifact type for Singularity image
+	ArtifactTypeSIF = "SIF"
+
+	// the media type of the config that Singularity/Apptainer pushes the SIF image with
+	mediaTypeConfig = "application/vnd.sylabs.sif.config.v1+json"
+)
+
+func init() {
+	pc := &processor{}
+	pc.ManifestProcessor = base.NewManifestProcessor()
+	if err := ps.Register(pc, mediaTypeConfig); err != nil {
+		log.Errorf("failed to register processor for media type %s: %v", mediaTypeConfig, err)
+		return
+	}
+}
+
+type processor struct {
+	*base.ManifestProcessor
+}
+
+func (p *processor) GetArtifactType(ctx context.Context, artifact *artifact.Artifact) string {
+	return ArtifactTypeSIF
+}
@@ -0,0 +1,63 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sif
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	sifManifest = `{"schemaVersion":2,"config":{"mediaType":"application/vnd.sylabs.sif.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":54},"layers":[{"mediaType":"application/vnd.sylabs.sif.layer.v1.sif","digest":"sha256:0bd64cfb958b68c71b46597e22185a41e784dc96e04090bc7d2a480b704c3b65","size":2785280,"annotations":{"org.opencontainers.image.title":"alpine_latest.sif"}}]}`
+)
+
+type processorTestSuite struct {
+	suite.Suite
+	processor *processor
+	regCli    *registry.FakeClient
+}
+
+func (p *processorTestSuite) SetupTest() {
+	p.regCli = &registry.FakeClient{}
+	p.processor = &processor{}
+	p.processor.ManifestProcessor = &base.ManifestProcessor{RegCli: p.regCli}
+}
+
+func (p *processorTestSuite) TestAbstractMetadata() {
+	config := `{"created":"2021-11-01T08:00:00Z","architecture":"amd64"}`
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(bytes.NewReader([]byte(config))), nil)
+	art := &artifact.Artifact{}
+	err := p.processor.AbstractMetadata(nil, art, []byte(sifManifest))
+	p.Require().Nil(err)
+	p.Equal("amd64", art.ExtraAttrs["architecture"])
+}
+
+func (p *processorTestSuite) TestGetArtifactType() {
+	p.Assert().Equal(ArtifactTypeSIF, p.processor.GetArtifactType(nil, nil))
+}
+
+func (p *processorTestSuite) TestListAdditionTypes() {
+	p.Len(p.processor.ListAdditionTypes(nil, nil), 0)
+}
+
+func TestProcessorTestSuite(t *testing.T) {
+	suite.Run(t, &processorTestSuite{})
+}
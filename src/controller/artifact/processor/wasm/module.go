@@ -0,0 +1,116 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	// the ID of the export section, see https://webassembly.github.io/spec/core/binary/modules.html#sections
+	sectionIDExport = 7
+	// the kind of the exported function
+	exportKindFunc = 0x00
+)
+
+var (
+	moduleMagic   = []byte{0x00, 0x61, 0x73, 0x6d}
+	moduleVersion = []byte{0x01, 0x00, 0x00, 0x00}
+)
+
+// exportedFunctions returns the names of the functions exported by the WebAssembly module in binary format
+func exportedFunctions(module []byte) ([]string, error) {
+	reader := bytes.NewReader(module)
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, fmt.Errorf("invalid module header: %v", err)
+	}
+	if !bytes.Equal(header[:4], moduleMagic) {
+		return nil, fmt.Errorf("invalid module magic number")
+	}
+	// the components defined by the component model have a different version and layer
+	if !bytes.Equal(header[4:], moduleVersion) {
+		return nil, fmt.Errorf("unsupported module version %x", header[4:])
+	}
+
+	functions := []string{}
+	for {
+		id, err := reader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		size, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size of section %d: %v", id, err)
+		}
+		if size > uint64(reader.Len()) {
+			return nil, fmt.Errorf("invalid size of section %d: %d", id, size)
+		}
+		if id != sectionIDExport {
+			if _, err := reader.Seek(int64(size), io.SeekCurrent); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		section := make([]byte, size)
+		if _, err := io.ReadFull(reader, section); err != nil {
+			return nil, fmt.Errorf("invalid export section: %v", err)
+		}
+		return parseExportSection(section)
+	}
+	return functions, nil
+}
+
+// the export section contains a vector of exports, each export is composed by
+// the name(vector of bytes), the kind(one byte) and the index(unsigned LEB128)
+func parseExportSection(section []byte) ([]string, error) {
+	reader := bytes.NewReader(section)
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid count of exports: %v", err)
+	}
+	functions := []string{}
+	for i := uint64(0); i < count; i++ {
+		length, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid length of export name: %v", err)
+		}
+		if length > uint64(reader.Len()) {
+			return nil, fmt.Errorf("invalid length of export name: %d", length)
+		}
+		name := make([]byte, length)
+		if _, err := io.ReadFull(reader, name); err != nil {
+			return nil, fmt.Errorf("invalid export name: %v", err)
+		}
+		kind, err := reader.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("invalid kind of export %s: %v", name, err)
+		}
+		if _, err := binary.ReadUvarint(reader); err != nil {
+			return nil, fmt.Errorf("invalid index of export %s: %v", name, err)
+		}
+		if kind == exportKindFunc {
+			functions = append(functions, string(name))
+		}
+	}
+	return functions, nil
+}
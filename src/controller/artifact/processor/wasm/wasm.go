@@ -0,0 +1,117 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	ps "github.com/goharbor/harbor/src/controller/artifact/processor"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// const definitions
+const (
+	// ArtifactTypeWASM defines the artifact type for WebAssembly module
+	ArtifactTypeWASM    = "WASM"
+	AdditionTypeExports = "EXPORTS"
+
+	// the media types used by wasm-to-oci
+	mediaTypeConfigV1     = "application/vnd.wasm.config.v1+json"
+	mediaTypeContentLayer = "application/vnd.wasm.content.layer.v1+wasm"
+	// the media types defined by the Wasm OCI artifact layout of CNCF TAG Runtime
+	mediaTypeConfigV0  = "application/vnd.wasm.config.v0+json"
+	mediaTypeWasmLayer = "application/wasm"
+)
+
+func init() {
+	pc := &processor{}
+	pc.ManifestProcessor = base.NewManifestProcessor()
+	mediaTypes := []string{
+		mediaTypeConfigV1,
+		mediaTypeConfigV0,
+	}
+	if err := ps.Register(pc, mediaTypes...); err != nil {
+		log.Errorf("failed to register processor for media type %v: %v", mediaTypes, err)
+		return
+	}
+}
+
+type processor struct {
+	*base.ManifestProcessor
+}
+
+func (p *processor) AbstractAddition(ctx context.Context, artifact *artifact.Artifact, addition string) (*ps.Addition, error) {
+	if addition != AdditionTypeExports {
+		return nil, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("addition %s isn't supported for %s", addition, ArtifactTypeWASM)
+	}
+
+	m, _, err := p.RegCli.PullManifest(artifact.RepositoryName, artifact.Digest)
+	if err != nil {
+		return nil, err
+	}
+	_, payload, err := m.Payload()
+	if err != nil {
+		return nil, err
+	}
+	manifest := &v1.Manifest{}
+	if err := json.Unmarshal(payload, manifest); err != nil {
+		return nil, err
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != mediaTypeContentLayer && layer.MediaType != mediaTypeWasmLayer {
+			continue
+		}
+		_, blob, err := p.RegCli.PullBlob(artifact.RepositoryName, layer.Digest.String())
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(blob)
+		blob.Close()
+		if err != nil {
+			return nil, err
+		}
+		functions, err := exportedFunctions(content)
+		if err != nil {
+			return nil, errors.New(err).WithCode(errors.BadRequestCode).
+				WithMessage("failed to parse the WebAssembly module of %s: %v", artifact.String(), err)
+		}
+		additionContent, err := json.Marshal(functions)
+		if err != nil {
+			return nil, err
+		}
+		return &ps.Addition{
+			Content:     additionContent,
+			ContentType: "application/json; charset=utf-8",
+		}, nil
+	}
+	return nil, errors.New(nil).WithCode(errors.NotFoundCode).
+		WithMessage("the WebAssembly module of %s not found", artifact.String())
+}
+
+func (p *processor) GetArtifactType(ctx context.Context, artifact *artifact.Artifact) string {
+	return ArtifactTypeWASM
+}
+
+func (p *processor) ListAdditionTypes(ctx context.Context, artifact *artifact.Artifact) []string {
+	return []string{AdditionTypeExports}
+}
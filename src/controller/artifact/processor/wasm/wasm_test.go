@@ -0,0 +1,110 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/base"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	wasmManifest = `{"schemaVersion":2,"config":{"mediaType":"application/vnd.wasm.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[{"mediaType":"application/vnd.wasm.content.layer.v1+wasm","digest":"sha256:0bd64cfb958b68c71b46597e22185a41e784dc96e04090bc7d2a480b704c3b65","size":38}]}`
+	// the module exports the function "add" and the memory "memory", with a custom section "test"
+	wasmModule = []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, // header
+		0x00, 0x05, 0x04, 0x74, 0x65, 0x73, 0x74, // custom section
+		0x07, 0x10, 0x02, // export section with 2 exports
+		0x03, 0x61, 0x64, 0x64, 0x00, 0x00, // function "add"
+		0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, // memory "memory"
+	}
+)
+
+type processorTestSuite struct {
+	suite.Suite
+	processor *processor
+	regCli    *registry.FakeClient
+}
+
+func (p *processorTestSuite) SetupTest() {
+	p.regCli = &registry.FakeClient{}
+	p.processor = &processor{}
+	p.processor.ManifestProcessor = &base.ManifestProcessor{RegCli: p.regCli}
+}
+
+func (p *processorTestSuite) TestAbstractMetadata() {
+	config := `{"created":"2021-11-01T08:00:00Z","author":"harbor","architecture":"wasm","os":"wasip1"}`
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(bytes.NewReader([]byte(config))), nil)
+	art := &artifact.Artifact{}
+	err := p.processor.AbstractMetadata(nil, art, []byte(wasmManifest))
+	p.Require().Nil(err)
+	p.Equal("wasm", art.ExtraAttrs["architecture"])
+	p.Equal("wasip1", art.ExtraAttrs["os"])
+}
+
+func (p *processorTestSuite) TestAbstractAddition() {
+	// unknown addition
+	_, err := p.processor.AbstractAddition(nil, nil, "unknown_addition")
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(wasmManifest))
+	p.Require().Nil(err)
+	p.regCli.On("PullManifest").Return(manifest, "", nil)
+	p.regCli.On("PullBlob").Return(0, ioutil.NopCloser(bytes.NewReader(wasmModule)), nil)
+	addition, err := p.processor.AbstractAddition(nil, &artifact.Artifact{}, AdditionTypeExports)
+	p.Require().Nil(err)
+	p.Equal("application/json; charset=utf-8", addition.ContentType)
+	p.Equal(`["add"]`, string(addition.Content))
+}
+
+func (p *processorTestSuite) TestExportedFunctions() {
+	// invalid magic number
+	_, err := exportedFunctions([]byte{0x01, 0x02, 0x03, 0x04, 0x01, 0x00, 0x00, 0x00})
+	p.NotNil(err)
+
+	// component isn't supported
+	_, err = exportedFunctions([]byte{0x00, 0x61, 0x73, 0x6d, 0x0d, 0x00, 0x01, 0x00})
+	p.NotNil(err)
+
+	// truncated section
+	_, err = exportedFunctions(wasmModule[:20])
+	p.NotNil(err)
+
+	// no export section
+	functions, err := exportedFunctions(wasmModule[:15])
+	p.Require().Nil(err)
+	p.Len(functions, 0)
+}
+
+func (p *processorTestSuite) TestGetArtifactType() {
+	p.Assert().Equal(ArtifactTypeWASM, p.processor.GetArtifactType(nil, nil))
+}
+
+func (p *processorTestSuite) TestListAdditionTypes() {
+	additions := p.processor.ListAdditionTypes(nil, nil)
+	p.EqualValues([]string{AdditionTypeExports}, additions)
+}
+
+func TestProcessorTestSuite(t *testing.T) {
+	suite.Run(t, &processorTestSuite{})
+}
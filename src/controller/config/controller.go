@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/lib/config"
@@ -27,6 +28,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/audit"
 	"github.com/goharbor/harbor/src/pkg/user"
 )
 
@@ -112,6 +114,21 @@ func (c *controller) validateCfg(ctx context.Context, cfgs map[string]interface{
 		}
 	}
 
+	// check if the audit log forward endpoints are supported
+	if nv, ok := cfgs[common.AuditLogForwardEndpoint]; ok {
+		if endpoints, ok := nv.(string); ok {
+			for _, endpoint := range strings.Split(endpoints, ",") {
+				endpoint = strings.TrimSpace(endpoint)
+				if len(endpoint) == 0 {
+					continue
+				}
+				if err := audit.ValidateForwardEndpoint(endpoint); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	err := mgr.ValidateCfg(ctx, cfgs)
 	if err != nil {
 		return errors.BadRequestError(err)
@@ -31,6 +31,11 @@ type AuditResolver interface {
 	ResolveToAuditLog() (*am.AuditLog, error)
 }
 
+// ClientResolver - interface to fill the details of the client triggering the event into AuditLog
+type ClientResolver interface {
+	FillAuditLog(auditLog *am.AuditLog)
+}
+
 // Name ...
 func (h *Handler) Name() string {
 	return "AuditLog"
@@ -49,6 +54,9 @@ func (h *Handler) Handle(ctx context.Context, value interface{}) error {
 			log.Errorf("failed to handler event %v", err)
 			return err
 		}
+		if resolver, ok := value.(ClientResolver); ok {
+			resolver.FillAuditLog(al)
+		}
 		auditLog = al
 	default:
 		log.Errorf("Can not handler this event type! %#v", v)
@@ -41,6 +41,7 @@ func init() {
 	notifier.Subscribe(event.TopicDeleteArtifact, &replication.Handler{})
 	notifier.Subscribe(event.TopicCreateTag, &replication.Handler{})
 	notifier.Subscribe(event.TopicDeleteTag, &replication.Handler{})
+	notifier.Subscribe(event.TopicScanningCompleted, &replication.Handler{})
 
 	// p2p preheat
 	notifier.Subscribe(event.TopicPushArtifact, &p2p.Handler{})
@@ -60,6 +61,8 @@ func init() {
 	// internal
 	notifier.Subscribe(event.TopicPullArtifact, &internal.Handler{})
 	notifier.Subscribe(event.TopicPushArtifact, &internal.Handler{})
+	notifier.Subscribe(event.TopicDeleteArtifact, &internal.Handler{})
+	notifier.Subscribe(event.TopicDeleteRepository, &internal.Handler{})
 
 	task.RegisterTaskStatusChangePostFunc(job.Replication, func(ctx context.Context, taskID int64, status string) error {
 		notification.AddEvent(ctx, &metadata.ReplicationMetaData{
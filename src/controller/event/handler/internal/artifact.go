@@ -20,10 +20,12 @@ import (
 
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/controller/tag"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
 )
 
 // Handler preprocess artifact event data
@@ -42,6 +44,14 @@ func (a *Handler) Handle(ctx context.Context, value interface{}) error {
 		return a.onPull(ctx, v.ArtifactEvent)
 	case *event.PushArtifactEvent:
 		return a.onPush(ctx, v.ArtifactEvent)
+	case *event.DeleteArtifactEvent:
+		deleted := v.Deleted
+		if deleted <= 0 {
+			deleted = 1
+		}
+		return a.releaseQuota(ctx, v.Artifact.ProjectID, types.ResourceList{types.ResourceCount: -deleted})
+	case *event.DeleteRepositoryEvent:
+		return a.releaseQuota(ctx, v.ProjectID, types.ResourceList{types.ResourceRepository: -1})
 	default:
 		log.Errorf("Can not handler this event type! %#v", v)
 	}
@@ -98,3 +108,26 @@ func (a *Handler) onPush(ctx context.Context, event *event.ArtifactEvent) error
 
 	return nil
 }
+
+// releaseQuota releases the artifact count or repository count from the quota usage of the project once the artifact
+// or repository is deleted, the storage is released by GC. The usage is refreshed to reconcile it only when the release fails.
+func (a *Handler) releaseQuota(ctx context.Context, projectID int64, resources types.ResourceList) error {
+	referenceID := quota.ReferenceID(projectID)
+	enabled, err := quota.Ctl.IsEnabled(ctx, quota.ProjectReference, referenceID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+	err = quota.Ctl.Request(ctx, quota.ProjectReference, referenceID, resources, func() error { return nil })
+	if err == nil {
+		return nil
+	}
+	log.Warningf("failed to release %s from the quota of project %d, refresh it, error: %v", resources.String(), projectID, err)
+	if err := quota.Ctl.Refresh(ctx, quota.ProjectReference, referenceID, quota.IgnoreLimitation(true)); err != nil {
+		log.Errorf("failed to refresh the quota of project %d, error: %v", projectID, err)
+		return err
+	}
+	return nil
+}
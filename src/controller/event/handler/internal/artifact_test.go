@@ -1 +1,96 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+	quotatesting "github.com/goharbor/harbor/src/testing/controller/quota"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ArtifactHandlerTestSuite struct {
+	suite.Suite
+
+	originalQuotaCtl quota.Controller
+	quotaCtl         *quotatesting.Controller
+}
+
+func (suite *ArtifactHandlerTestSuite) SetupTest() {
+	suite.originalQuotaCtl = quota.Ctl
+	suite.quotaCtl = &quotatesting.Controller{}
+	quota.Ctl = suite.quotaCtl
+	mock.OnAnything(suite.quotaCtl, "IsEnabled").Return(true, nil)
+}
+
+func (suite *ArtifactHandlerTestSuite) TearDownTest() {
+	quota.Ctl = suite.originalQuotaCtl
+}
+
+// requested returns the resources requested from the quota of the project
+func (suite *ArtifactHandlerTestSuite) requested() types.ResourceList {
+	for _, call := range suite.quotaCtl.Calls {
+		if call.Method == "Request" {
+			return call.Arguments.Get(3).(types.ResourceList)
+		}
+	}
+	return nil
+}
+
+func (suite *ArtifactHandlerTestSuite) TestDeleteArtifact() {
+	mock.OnAnything(suite.quotaCtl, "Request").Return(nil)
+
+	// the index is deleted along with its 2 children
+	err := (&Handler{}).Handle(context.TODO(), &event.DeleteArtifactEvent{
+		ArtifactEvent: &event.ArtifactEvent{Artifact: &artifact.Artifact{ID: 1, ProjectID: 1}},
+		Deleted:       3,
+	})
+	suite.Require().Nil(err)
+	suite.Equal(types.ResourceList{types.ResourceCount: -3}, suite.requested())
+	suite.quotaCtl.AssertNotCalled(suite.T(), "Refresh", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ArtifactHandlerTestSuite) TestDeleteRepository() {
+	mock.OnAnything(suite.quotaCtl, "Request").Return(nil)
+
+	err := (&Handler{}).Handle(context.TODO(), &event.DeleteRepositoryEvent{ProjectID: 1, Repository: "library/hello-world"})
+	suite.Require().Nil(err)
+	suite.Equal(types.ResourceList{types.ResourceRepository: -1}, suite.requested())
+	suite.quotaCtl.AssertNotCalled(suite.T(), "Refresh", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ArtifactHandlerTestSuite) TestReleaseFailed() {
+	// the usage out of sync is reconciled by refreshing
+	mock.OnAnything(suite.quotaCtl, "Request").Return(errors.New("quota usage is negative"))
+	mock.OnAnything(suite.quotaCtl, "Refresh").Return(nil)
+
+	err := (&Handler{}).Handle(context.TODO(), &event.DeleteArtifactEvent{
+		ArtifactEvent: &event.ArtifactEvent{Artifact: &artifact.Artifact{ID: 1, ProjectID: 1}},
+		Deleted:       1,
+	})
+	suite.Require().Nil(err)
+	suite.quotaCtl.AssertCalled(suite.T(), "Refresh", mock.Anything, quota.ProjectReference, "1", mock.Anything)
+}
+
+func TestArtifactHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &ArtifactHandlerTestSuite{})
+}
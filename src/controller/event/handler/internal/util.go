@@ -32,6 +32,12 @@ func autoScan(ctx context.Context, a *artifact.Artifact, tags ...string) error {
 	if !proj.AutoScan() {
 		return nil
 	}
+	// the artifact is scanned by the scan gating before the push event is sent
+	if proj.ScanGating() != "" {
+		if reports, err := scan.DefaultController.GetReport(ctx, a, nil); err == nil && len(reports) > 0 {
+			return nil
+		}
+	}
 
 	// transaction here to work with the image index
 	return orm.WithTransaction(func(ctx context.Context) error {
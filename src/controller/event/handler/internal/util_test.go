@@ -25,6 +25,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/orm"
 	pkg "github.com/goharbor/harbor/src/pkg/artifact"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	scanmodel "github.com/goharbor/harbor/src/pkg/scan/dao/scan"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
 	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
@@ -109,6 +110,23 @@ func (suite *AutoScanTestSuite) TestAutoScanFailed() {
 	suite.Error(autoScan(ctx, art))
 }
 
+func (suite *AutoScanTestSuite) TestScannedByScanGating() {
+	mock.OnAnything(suite.projectController, "Get").Return(&proModels.Project{
+		Metadata: map[string]string{
+			proModels.ProMetaAutoScan:   "true",
+			proModels.ProMetaScanGating: proModels.ScanGatingReject,
+		},
+	}, nil)
+
+	mock.OnAnything(suite.scanController, "GetReport").Return([]*scanmodel.Report{{UUID: "uuid"}}, nil)
+
+	ctx := orm.NewContext(nil, &ormtesting.FakeOrmer{})
+	art := &artifact.Artifact{}
+
+	suite.Nil(autoScan(ctx, art))
+	suite.scanController.AssertNotCalled(suite.T(), "Scan", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *AutoScanTestSuite) TestWithArtifactEvent() {
 	mock.OnAnything(suite.projectController, "Get").Return(&proModels.Project{
 		Metadata: map[string]string{
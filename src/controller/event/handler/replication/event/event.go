@@ -18,11 +18,12 @@ import "github.com/goharbor/harbor/src/pkg/reg/model"
 
 // const definitions
 const (
-	EventTypeArtifactPush   = "artifact_push"
-	EventTypeArtifactDelete = "artifact_delete"
-	EventTypeTagDelete      = "tag_delete"
-	EventTypeChartUpload    = "chart_upload"
-	EventTypeChartDelete    = "chart_delete"
+	EventTypeArtifactPush    = "artifact_push"
+	EventTypeArtifactScanned = "artifact_scanned"
+	EventTypeArtifactDelete  = "artifact_delete"
+	EventTypeTagDelete       = "tag_delete"
+	EventTypeChartUpload     = "chart_upload"
+	EventTypeChartDelete     = "chart_delete"
 )
 
 // Event is the model that defines the image/chart pull/push event
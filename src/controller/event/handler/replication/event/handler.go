@@ -39,7 +39,11 @@ func Handle(ctx context.Context, event *Event) error {
 	switch event.Type {
 	case EventTypeArtifactPush, EventTypeChartUpload, EventTypeTagDelete,
 		EventTypeArtifactDelete, EventTypeChartDelete:
-		policies, err = getRelatedPolicies(ctx, event.Resource)
+		policies, err = getRelatedPolicies(ctx, event.Resource, false)
+	case EventTypeArtifactScanned:
+		// the severity is unknown when the artifact is pushed, so the policies with
+		// severity filter are triggered once the scan of the artifact completes
+		policies, err = getRelatedPolicies(ctx, event.Resource, true)
 	default:
 		return fmt.Errorf("unsupported event type %s", event.Type)
 	}
@@ -62,7 +66,7 @@ func Handle(ctx context.Context, event *Event) error {
 	return nil
 }
 
-func getRelatedPolicies(ctx context.Context, resource *model.Resource) ([]*repctlmodel.Policy, error) {
+func getRelatedPolicies(ctx context.Context, resource *model.Resource, severityFiltered bool) ([]*repctlmodel.Policy, error) {
 	policies, err := replication.Ctl.ListPolicies(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -90,6 +94,11 @@ func getRelatedPolicies(ctx context.Context, resource *model.Resource) ([]*repct
 		if resource.Deleted && !policy.ReplicateDeletion {
 			continue
 		}
+		// only the policies with severity filter are triggered by the scan,
+		// the others have been triggered by the push already
+		if severityFiltered && !hasSeverityFilter(policy) {
+			continue
+		}
 
 		resources, err := filter.DoFilterResources([]*model.Resource{resource}, policy.Filters)
 		if err != nil {
@@ -104,3 +113,12 @@ func getRelatedPolicies(ctx context.Context, resource *model.Resource) ([]*repct
 	}
 	return result, nil
 }
+
+func hasSeverityFilter(policy *repctlmodel.Policy) bool {
+	for _, f := range policy.Filters {
+		if f.Type == model.FilterTypeSeverity {
+			return true
+		}
+	}
+	return false
+}
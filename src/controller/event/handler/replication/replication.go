@@ -18,14 +18,22 @@ import (
 	"context"
 	"strconv"
 
+	ctlartifact "github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/event"
 	repevent "github.com/goharbor/harbor/src/controller/event/handler/replication/event"
 	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/log"
-	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/artifact"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
+// declared as a variable so that the tests can inspect the resolved replication events
+var handleEvent = repevent.Handle
+
 // Handler ...
 type Handler struct {
 }
@@ -53,6 +61,10 @@ func (r *Handler) Handle(ctx context.Context, value interface{}) error {
 	if ok {
 		return r.handleDeleteTag(ctx, deleteTagEvent)
 	}
+	scanImageEvent, ok := value.(*event.ScanImageEvent)
+	if ok {
+		return r.handleImageScanned(ctx, scanImageEvent)
+	}
 	return nil
 }
 
@@ -64,7 +76,7 @@ func (r *Handler) IsStateful() bool {
 func (r *Handler) handlePushArtifact(ctx context.Context, event *event.PushArtifactEvent) error {
 	art := event.Artifact
 	public := false
-	prj, err := project.Ctl.Get(orm.Context(), art.ProjectID, project.Metadata(true))
+	prj, err := project.Ctl.Get(ctx, art.ProjectID, project.Metadata(true))
 	if err != nil {
 		log.Errorf("failed to get project: %d, error: %v", art.ProjectID, err)
 		return err
@@ -84,14 +96,61 @@ func (r *Handler) handlePushArtifact(ctx context.Context, event *event.PushArtif
 				},
 				Artifacts: []*model.Artifact{
 					{
-						Type:   art.Type,
-						Digest: art.Digest,
-						Tags:   event.Tags,
+						Type:        art.Type,
+						Digest:      art.Digest,
+						Tags:        event.Tags,
+						Annotations: art.Annotations,
+						Severity:    severity(ctx, art),
 					}},
 			},
 		},
 	}
-	return repevent.Handle(ctx, e)
+	return handleEvent(ctx, e)
+}
+
+func (r *Handler) handleImageScanned(ctx context.Context, event *event.ScanImageEvent) error {
+	if event.Artifact == nil {
+		return nil
+	}
+	art, err := ctlartifact.Ctl.GetByReference(ctx, event.Artifact.Repository, event.Artifact.Digest,
+		&ctlartifact.Option{WithTag: true})
+	if err != nil {
+		log.Errorf("failed to get artifact: %s@%s, error: %v", event.Artifact.Repository, event.Artifact.Digest, err)
+		return err
+	}
+	prj, err := project.Ctl.Get(ctx, art.ProjectID, project.Metadata(true))
+	if err != nil {
+		log.Errorf("failed to get project: %d, error: %v", art.ProjectID, err)
+		return err
+	}
+	var tags []string
+	for _, t := range art.Tags {
+		tags = append(tags, t.Name)
+	}
+
+	e := &repevent.Event{
+		Type: repevent.EventTypeArtifactScanned,
+		Resource: &model.Resource{
+			Type: model.ResourceTypeArtifact,
+			Metadata: &model.ResourceMetadata{
+				Repository: &model.Repository{
+					Name: art.RepositoryName,
+					Metadata: map[string]interface{}{
+						"public": strconv.FormatBool(prj.IsPublic()),
+					},
+				},
+				Artifacts: []*model.Artifact{
+					{
+						Type:        art.Type,
+						Digest:      art.Digest,
+						Tags:        tags,
+						Annotations: art.Annotations,
+						Severity:    severity(ctx, &art.Artifact),
+					}},
+			},
+		},
+	}
+	return handleEvent(ctx, e)
 }
 
 func (r *Handler) handleDeleteArtifact(ctx context.Context, event *event.DeleteArtifactEvent) error {
@@ -106,21 +165,23 @@ func (r *Handler) handleDeleteArtifact(ctx context.Context, event *event.DeleteA
 				},
 				Artifacts: []*model.Artifact{
 					{
-						Type:   art.Type,
-						Digest: art.Digest,
-						Tags:   event.Tags,
+						Type:        art.Type,
+						Digest:      art.Digest,
+						Tags:        event.Tags,
+						Annotations: art.Annotations,
+						Severity:    severity(ctx, art),
 					}},
 			},
 			Deleted: true,
 		},
 	}
-	return repevent.Handle(ctx, e)
+	return handleEvent(ctx, e)
 }
 
 func (r *Handler) handleCreateTag(ctx context.Context, event *event.CreateTagEvent) error {
 	art := event.AttachedArtifact
 	public := false
-	prj, err := project.Ctl.Get(orm.Context(), art.ProjectID, project.Metadata(true))
+	prj, err := project.Ctl.Get(ctx, art.ProjectID, project.Metadata(true))
 	if err != nil {
 		log.Errorf("failed to get project: %d, error: %v", art.ProjectID, err)
 		return err
@@ -140,14 +201,16 @@ func (r *Handler) handleCreateTag(ctx context.Context, event *event.CreateTagEve
 				},
 				Artifacts: []*model.Artifact{
 					{
-						Type:   art.Type,
-						Digest: art.Digest,
-						Tags:   []string{event.Tag},
+						Type:        art.Type,
+						Digest:      art.Digest,
+						Tags:        []string{event.Tag},
+						Annotations: art.Annotations,
+						Severity:    severity(ctx, art),
 					}},
 			},
 		},
 	}
-	return repevent.Handle(ctx, e)
+	return handleEvent(ctx, e)
 }
 
 func (r *Handler) handleDeleteTag(ctx context.Context, event *event.DeleteTagEvent) error {
@@ -171,5 +234,27 @@ func (r *Handler) handleDeleteTag(ctx context.Context, event *event.DeleteTagEve
 			IsDeleteTag: true,
 		},
 	}
-	return repevent.Handle(ctx, e)
+	return handleEvent(ctx, e)
+}
+
+// severity returns the highest severity among the successful scan summaries of the artifact,
+// empty if the artifact isn't scanned or the scan reports are already removed
+func severity(ctx context.Context, art *artifact.Artifact) string {
+	summaries, err := scan.DefaultController.GetSummary(ctx, &ctlartifact.Artifact{Artifact: *art},
+		[]string{v1.MimeTypeNativeReport, v1.MimeTypeGenericVulnerabilityReport})
+	if err != nil {
+		log.Debugf("failed to get the scan summaries of artifact %s@%s: %v", art.RepositoryName, art.Digest, err)
+		return ""
+	}
+	var sev vuln.Severity
+	for _, summary := range summaries {
+		sum, ok := summary.(*vuln.NativeReportSummary)
+		if !ok || sum.ScanStatus != job.SuccessStatus.String() {
+			continue
+		}
+		if len(sev) == 0 || sum.Severity.Code() > sev.Code() {
+			sev = sum.Severity
+		}
+	}
+	return sev.String()
 }
@@ -0,0 +1,223 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"testing"
+
+	ctlartifact "github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/event"
+	repevent "github.com/goharbor/harbor/src/controller/event/handler/replication/event"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/controller/tag"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/reg/filter"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	model_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+
+	originalArtifactCtl ctlartifact.Controller
+	originalProjectCtl  project.Controller
+	originalScanCtl     scan.Controller
+	artifactCtl         *artifacttesting.Controller
+	projectCtl          *projecttesting.Controller
+	scanCtl             *scantesting.Controller
+
+	events []*repevent.Event
+}
+
+func (suite *HandlerTestSuite) SetupTest() {
+	suite.originalArtifactCtl = ctlartifact.Ctl
+	suite.artifactCtl = &artifacttesting.Controller{}
+	ctlartifact.Ctl = suite.artifactCtl
+	suite.originalProjectCtl = project.Ctl
+	suite.originalScanCtl = scan.DefaultController
+	suite.projectCtl = &projecttesting.Controller{}
+	project.Ctl = suite.projectCtl
+	suite.scanCtl = &scantesting.Controller{}
+	scan.DefaultController = suite.scanCtl
+
+	suite.events = nil
+	handleEvent = func(ctx context.Context, e *repevent.Event) error {
+		suite.events = append(suite.events, e)
+		return nil
+	}
+
+	mock.OnAnything(suite.projectCtl, "Get").Return(&proModels.Project{ProjectID: 1}, nil)
+}
+
+func (suite *HandlerTestSuite) TearDownTest() {
+	ctlartifact.Ctl = suite.originalArtifactCtl
+	project.Ctl = suite.originalProjectCtl
+	scan.DefaultController = suite.originalScanCtl
+	handleEvent = repevent.Handle
+}
+
+func (suite *HandlerTestSuite) newArtifact() *artifact.Artifact {
+	return &artifact.Artifact{
+		ID:             1,
+		ProjectID:      1,
+		RepositoryName: "library/hello-world",
+		Type:           "IMAGE",
+		Digest:         "sha256:92c7f9c92844bbbb5d0a101b22f7c2a7949e40f8ea90c8b3bc396879d95e899a",
+		Annotations: map[string]string{
+			"org.opencontainers.image.vendor": "goharbor",
+		},
+	}
+}
+
+func (suite *HandlerTestSuite) mockSummary(severity vuln.Severity) {
+	mock.OnAnything(suite.scanCtl, "GetSummary").Return(map[string]interface{}{
+		v1.MimeTypeNativeReport: &vuln.NativeReportSummary{
+			ScanStatus: "Success",
+			Severity:   severity,
+		},
+	}, nil)
+}
+
+// filter runs the filters of the replication policy through the resource of the handled event
+func (suite *HandlerTestSuite) filter(filters ...*model.Filter) []*model.Resource {
+	suite.Require().Len(suite.events, 1)
+	resources, err := filter.DoFilterResources([]*model.Resource{suite.events[0].Resource}, filters)
+	suite.Require().Nil(err)
+	return resources
+}
+
+func (suite *HandlerTestSuite) TestFiltersThroughHandlers() {
+	events := map[string]func() interface{}{
+		"push artifact": func() interface{} {
+			return &event.PushArtifactEvent{ArtifactEvent: &event.ArtifactEvent{
+				Repository: "library/hello-world",
+				Artifact:   suite.newArtifact(),
+				Tags:       []string{"latest"},
+			}}
+		},
+		"delete artifact": func() interface{} {
+			return &event.DeleteArtifactEvent{ArtifactEvent: &event.ArtifactEvent{
+				Repository: "library/hello-world",
+				Artifact:   suite.newArtifact(),
+				Tags:       []string{"latest"},
+			}}
+		},
+		"create tag": func() interface{} {
+			return &event.CreateTagEvent{
+				Repository:       "library/hello-world",
+				Tag:              "latest",
+				AttachedArtifact: suite.newArtifact(),
+			}
+		},
+	}
+	cases := []struct {
+		name     string
+		severity vuln.Severity
+		filters  []*model.Filter
+		matched  bool
+	}{
+		{
+			name:     "severity at the threshold",
+			severity: vuln.Medium,
+			filters:  []*model.Filter{{Type: model.FilterTypeSeverity, Value: vuln.Medium.String()}},
+			matched:  true,
+		},
+		{
+			name:     "severity above the threshold",
+			severity: vuln.Critical,
+			filters:  []*model.Filter{{Type: model.FilterTypeSeverity, Value: vuln.High.String()}},
+			matched:  false,
+		},
+		{
+			name:     "annotation matched",
+			severity: vuln.Low,
+			filters:  []*model.Filter{{Type: model.FilterTypeAnnotation, Value: "org.opencontainers.image.vendor=go*"}},
+			matched:  true,
+		},
+		{
+			name:     "annotation not matched",
+			severity: vuln.Low,
+			filters:  []*model.Filter{{Type: model.FilterTypeAnnotation, Value: "org.opencontainers.image.vendor=docker"}},
+			matched:  false,
+		},
+	}
+
+	for handler, newEvent := range events {
+		for _, c := range cases {
+			suite.SetupTest()
+			suite.mockSummary(c.severity)
+
+			suite.Require().Nil((&Handler{}).Handle(context.TODO(), newEvent()))
+			resources := suite.filter(c.filters...)
+			if c.matched {
+				suite.Len(resources, 1, "%s: %s", handler, c.name)
+			} else {
+				suite.Empty(resources, "%s: %s", handler, c.name)
+			}
+			suite.TearDownTest()
+		}
+	}
+}
+
+func (suite *HandlerTestSuite) TestUnscannedArtifact() {
+	mock.OnAnything(suite.scanCtl, "GetSummary").Return(nil, errors.NotFoundError(nil))
+
+	err := (&Handler{}).Handle(context.TODO(), &event.PushArtifactEvent{ArtifactEvent: &event.ArtifactEvent{
+		Repository: "library/hello-world",
+		Artifact:   suite.newArtifact(),
+		Tags:       []string{"latest"},
+	}})
+	suite.Require().Nil(err)
+	suite.Empty(suite.events[0].Resource.Metadata.Artifacts[0].Severity)
+	suite.Empty(suite.filter(&model.Filter{Type: model.FilterTypeSeverity, Value: vuln.Critical.String()}))
+	suite.Len(suite.filter(&model.Filter{Type: model.FilterTypeName, Value: "library/**"}), 1)
+}
+
+func (suite *HandlerTestSuite) TestImageScanned() {
+	art := suite.newArtifact()
+	suite.artifactCtl.On("GetByReference", mock.Anything, art.RepositoryName, art.Digest, mock.Anything).
+		Return(&ctlartifact.Artifact{Artifact: *art, Tags: []*tag.Tag{{Tag: model_tag.Tag{Name: "latest"}}}}, nil)
+	suite.mockSummary(vuln.Low)
+
+	err := (&Handler{}).Handle(context.TODO(), &event.ScanImageEvent{
+		EventType: event.TopicScanningCompleted,
+		Artifact: &v1.Artifact{
+			NamespaceID: art.ProjectID,
+			Repository:  art.RepositoryName,
+			Digest:      art.Digest,
+		},
+	})
+	suite.Require().Nil(err)
+	suite.Require().Len(suite.events, 1)
+	suite.Equal(repevent.EventTypeArtifactScanned, suite.events[0].Type)
+	suite.Equal(art.RepositoryName, suite.events[0].Resource.Metadata.Repository.Name)
+	suite.Equal([]string{"latest"}, suite.events[0].Resource.Metadata.Artifacts[0].Tags)
+	suite.Len(suite.filter(&model.Filter{Type: model.FilterTypeSeverity, Value: vuln.Medium.String()}), 1)
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &HandlerTestSuite{})
+}
@@ -0,0 +1,147 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/lib/selector/selectors/doublestar"
+	"github.com/goharbor/harbor/src/lib/selector/selectors/label"
+	"github.com/goharbor/harbor/src/lib/selector/selectors/severity"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	pkg_label "github.com/goharbor/harbor/src/pkg/label"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+)
+
+var (
+	artifactMgr = artifact.Mgr
+	labelMgr    = pkg_label.Mgr
+)
+
+// matchFilters checks whether the event matches the filters of the policy. The event matches
+// when the repository or any of the resources matches all the filters applicable to the event
+func matchFilters(ctx context.Context, filters *policy_model.Filters, eventType string, payload *model.Payload) (bool, error) {
+	if filters == nil || filters.IsEmpty() || payload == nil || payload.EventData == nil {
+		return true, nil
+	}
+	data := payload.EventData
+	// the artifact has been removed when the delete event is sent, so its labels can't be matched
+	matchLabels := len(filters.Labels) > 0 && eventType != event.TopicDeleteArtifact
+
+	var selectors []selector.Selector
+	if len(filters.Repository) > 0 {
+		// the events without repository, e.g. the replication events, aren't filtered by the repository
+		if data.Repository == nil {
+			return true, nil
+		}
+		selectors = append(selectors, doublestar.New(doublestar.RepoMatches, filters.Repository, ""))
+	}
+
+	// the resource level filters are only applicable to the events with resources
+	if len(data.Resources) == 0 {
+		return selectAny(selectors, []*selector.Candidate{newCandidate(data, nil)})
+	}
+	if len(filters.Tag) > 0 {
+		selectors = append(selectors, doublestar.New(doublestar.Matches, filters.Tag, `{"untagged": false}`))
+	}
+	if matchLabels {
+		selectors = append(selectors, label.New(label.With, strings.Join(filters.Labels, ","), ""))
+	}
+	if len(filters.Severity) > 0 && eventType == event.TopicScanningCompleted {
+		selectors = append(selectors, severity.New(severity.Gte, vuln.ParseSeverityVersion3(filters.Severity).Code(), ""))
+	}
+
+	var candidates []*selector.Candidate
+	for _, res := range data.Resources {
+		candidate := newCandidate(data, res)
+		if matchLabels {
+			labels, err := artifactLabels(ctx, data.Repository, res.Digest)
+			if err != nil {
+				return false, err
+			}
+			candidate.Labels = labels
+		}
+		candidates = append(candidates, candidate)
+	}
+	return selectAny(selectors, candidates)
+}
+
+// selectAny returns true if any candidate is selected by all the selectors
+func selectAny(selectors []selector.Selector, candidates []*selector.Candidate) (bool, error) {
+	var err error
+	for _, s := range selectors {
+		if candidates, err = s.Select(candidates); err != nil {
+			return false, err
+		}
+	}
+	return len(candidates) > 0, nil
+}
+
+func newCandidate(data *model.EventData, res *model.Resource) *selector.Candidate {
+	candidate := &selector.Candidate{}
+	if data.Repository != nil {
+		candidate.Namespace = data.Repository.Namespace
+		candidate.Repository = data.Repository.Name
+	}
+	if res == nil {
+		return candidate
+	}
+	if len(res.Tag) > 0 {
+		candidate.Tags = []string{res.Tag}
+	}
+	candidate.Digest = res.Digest
+	candidate.VulnerabilitySeverity = uint(vulnerabilitySeverity(res.ScanOverview))
+	return candidate
+}
+
+// artifactLabels returns the names of the labels added to the artifact, the artifact removed
+// before the notification is sent has no labels
+func artifactLabels(ctx context.Context, repository *model.Repository, digest string) ([]string, error) {
+	if repository == nil || len(digest) == 0 {
+		return nil, nil
+	}
+	art, err := artifactMgr.GetByDigest(ctx, repository.RepoFullName, digest)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	labels, err := labelMgr.ListByArtifact(ctx, art.ID)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, l := range labels {
+		names = append(names, l.Name)
+	}
+	return names, nil
+}
+
+// vulnerabilitySeverity returns the code of the highest severity in the scan overview
+func vulnerabilitySeverity(overview map[string]interface{}) int {
+	code := 0
+	for mimeType, v := range overview {
+		// the summaries are in different types, so convert them by JSON
+		data, err := json.Marshal(v)
+		if err != nil {
+			log.Warningf("failed to marshal the scan summary of %s: %v", mimeType, err)
+			continue
+		}
+		summary := &vuln.NativeReportSummary{}
+		if err := json.Unmarshal(data, summary); err != nil {
+			log.Warningf("failed to unmarshal the scan summary of %s: %v", mimeType, err)
+			continue
+		}
+		if c := summary.Severity.Code(); c > code {
+			code = c
+		}
+	}
+	return code
+}
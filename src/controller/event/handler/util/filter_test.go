@@ -0,0 +1,139 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/label/model"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	notifier_model "github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	artifacttesting "github.com/goharbor/harbor/src/testing/pkg/artifact"
+	labeltesting "github.com/goharbor/harbor/src/testing/pkg/label"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPayload(eventType string) *notifier_model.Payload {
+	return &notifier_model.Payload{
+		Type:     eventType,
+		OccurAt:  1600000000,
+		Operator: "admin",
+		EventData: &notifier_model.EventData{
+			Resources: []*notifier_model.Resource{
+				{
+					Digest:      "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180",
+					Tag:         "latest",
+					ResourceURL: "harbor.domain/library/nginx:latest",
+				},
+			},
+			Repository: &notifier_model.Repository{
+				Name:         "nginx",
+				Namespace:    "library",
+				RepoFullName: "library/nginx",
+			},
+		},
+	}
+}
+
+func TestMatchFilters(t *testing.T) {
+	payload := newPayload(event.TopicPushArtifact)
+
+	cases := []struct {
+		filters *policy_model.Filters
+		matched bool
+	}{
+		{nil, true},
+		{&policy_model.Filters{}, true},
+		{&policy_model.Filters{Repository: "ngin*"}, true},
+		{&policy_model.Filters{Repository: "redis"}, false},
+		{&policy_model.Filters{Tag: "lat*"}, true},
+		{&policy_model.Filters{Tag: "v*"}, false},
+		{&policy_model.Filters{Repository: "nginx", Tag: "v*"}, false},
+		// the severity filter is only applicable to the scan completed events
+		{&policy_model.Filters{Severity: "High"}, true},
+	}
+	for _, c := range cases {
+		matched, err := matchFilters(context.TODO(), c.filters, event.TopicPushArtifact, payload)
+		require.Nil(t, err)
+		assert.Equal(t, c.matched, matched, "filters: %+v", c.filters)
+	}
+
+	// the tag filter isn't applicable to the events without resources
+	payload.EventData.Resources = nil
+	matched, err := matchFilters(context.TODO(), &policy_model.Filters{Repository: "nginx", Tag: "v*"}, event.TopicPushArtifact, payload)
+	require.Nil(t, err)
+	assert.True(t, matched)
+}
+
+func TestMatchFiltersBySeverity(t *testing.T) {
+	payload := newPayload(event.TopicScanningCompleted)
+	payload.EventData.Resources[0].ScanOverview = map[string]interface{}{
+		"application/vnd.security.vulnerability.report; version=1.1": &vuln.NativeReportSummary{
+			ScanStatus: "Success",
+			Severity:   vuln.Medium,
+		},
+	}
+	matched, err := matchFilters(context.TODO(), &policy_model.Filters{Severity: "Medium"}, event.TopicScanningCompleted, payload)
+	require.Nil(t, err)
+	assert.True(t, matched)
+
+	matched, err = matchFilters(context.TODO(), &policy_model.Filters{Severity: "High"}, event.TopicScanningCompleted, payload)
+	require.Nil(t, err)
+	assert.False(t, matched)
+
+	// no scan overview
+	payload.EventData.Resources[0].ScanOverview = nil
+	matched, err = matchFilters(context.TODO(), &policy_model.Filters{Severity: "Low"}, event.TopicScanningCompleted, payload)
+	require.Nil(t, err)
+	assert.False(t, matched)
+}
+
+func TestMatchFiltersByLabels(t *testing.T) {
+	artMgr, lblMgr := artifactMgr, labelMgr
+	defer func() {
+		artifactMgr, labelMgr = artMgr, lblMgr
+	}()
+	artifactMock := &artifacttesting.Manager{}
+	labelMock := &labeltesting.Manager{}
+	artifactMgr, labelMgr = artifactMock, labelMock
+
+	payload := newPayload(event.TopicPushArtifact)
+	digest := payload.EventData.Resources[0].Digest
+	artifactMock.On("GetByDigest", context.TODO(), "library/nginx", digest).Return(&artifact.Artifact{ID: 1}, nil)
+	labelMock.On("ListByArtifact", context.TODO(), int64(1)).Return([]*model.Label{{Name: "prod"}, {Name: "web"}}, nil)
+
+	matched, err := matchFilters(context.TODO(), &policy_model.Filters{Labels: []string{"prod"}}, event.TopicPushArtifact, payload)
+	require.Nil(t, err)
+	assert.True(t, matched)
+
+	matched, err = matchFilters(context.TODO(), &policy_model.Filters{Labels: []string{"prod", "db"}}, event.TopicPushArtifact, payload)
+	require.Nil(t, err)
+	assert.False(t, matched)
+}
+
+func TestMatchFiltersOfRemovedArtifact(t *testing.T) {
+	artMgr := artifactMgr
+	defer func() {
+		artifactMgr = artMgr
+	}()
+	artifactMock := &artifacttesting.Manager{}
+	artifactMgr = artifactMock
+	artifactMock.On("GetByDigest", context.TODO(), "library/nginx", "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180").
+		Return(nil, errors.NotFoundError(nil))
+
+	// the label filter isn't applicable to the delete events
+	filters := &policy_model.Filters{Repository: "nginx", Labels: []string{"prod"}}
+	matched, err := matchFilters(context.TODO(), filters, event.TopicDeleteArtifact, newPayload(event.TopicDeleteArtifact))
+	require.Nil(t, err)
+	assert.True(t, matched)
+	artifactMock.AssertNotCalled(t, "GetByDigest", context.TODO(), "library/nginx", "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180")
+
+	// the artifact removed before the notification is sent has no labels
+	matched, err = matchFilters(context.TODO(), filters, event.TopicPushArtifact, newPayload(event.TopicPushArtifact))
+	require.Nil(t, err)
+	assert.False(t, matched)
+}
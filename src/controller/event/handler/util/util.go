@@ -1,6 +1,7 @@
 package util
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/distribution"
+	job_model "github.com/goharbor/harbor/src/pkg/notification/job/model"
 	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
 	"github.com/goharbor/harbor/src/pkg/notifier/event"
 	notifyModel "github.com/goharbor/harbor/src/pkg/notifier/model"
@@ -25,11 +27,23 @@ func SendHookWithPolicies(policies []*policy_model.Policy, payload *notifyModel.
 
 	errRet := false
 	for _, ply := range policies {
+		matched, err := matchFilters(orm.Context(), ply.Filters, eventType, payload)
+		if err != nil {
+			errRet = true
+			log.Errorf("failed to match the filters of policy %d: %v", ply.ID, err)
+			continue
+		}
+		if !matched {
+			log.Debugf("event %s doesn't match the filters of policy %d, skip it", eventType, ply.ID)
+			continue
+		}
+
 		targets := ply.Targets
 		for _, target := range targets {
 			evt := &event.Event{}
 			hookMetadata := &event.HookMetaData{
 				EventType: eventType,
+				ProjectID: ply.ProjectID,
 				PolicyID:  ply.ID,
 				Payload:   payload,
 				Target:    &target,
@@ -53,6 +67,61 @@ func SendHookWithPolicies(policies []*policy_model.Policy, payload *notifyModel.
 	return nil
 }
 
+// ResendHook publishes the payload of the notification job again to the target of the policy which the job was
+// sent to, a new notification job is created for the redelivery. The filters of the policy aren't applied as they
+// have been checked when the original job was created
+func ResendHook(ply *policy_model.Policy, j *job_model.Job) error {
+	payload := &notifyModel.Payload{}
+	if err := json.Unmarshal([]byte(j.JobDetail), payload); err != nil {
+		return fmt.Errorf("failed to unmarshal the payload of the notification job %d: %v", j.ID, err)
+	}
+	target, err := resendTarget(ply, j)
+	if err != nil {
+		return err
+	}
+	evt := &event.Event{}
+	hookMetadata := &event.HookMetaData{
+		EventType: j.EventType,
+		ProjectID: ply.ProjectID,
+		PolicyID:  ply.ID,
+		Payload:   payload,
+		Target:    target,
+	}
+	if err := evt.Build(hookMetadata); err != nil {
+		return fmt.Errorf("failed to build hook notify event metadata: %v", err)
+	}
+	if err := evt.Publish(); err != nil {
+		return fmt.Errorf("failed to publish hook notify event: %v", err)
+	}
+	log.Debugf("republished the payload of notification job %d by topic %s", j.ID, target.Type)
+	return nil
+}
+
+// resendTarget returns the target of the policy which the notification job was sent to. The address of the target
+// isn't recorded by the jobs created before upgrading, they're only redelivered when the target can't be ambiguous
+func resendTarget(ply *policy_model.Policy, j *job_model.Job) (*policy_model.EventTarget, error) {
+	var targets []policy_model.EventTarget
+	for _, target := range ply.Targets {
+		if target.Type != j.NotifyType {
+			continue
+		}
+		if len(j.NotifyAddress) > 0 && target.Address != j.NotifyAddress {
+			continue
+		}
+		targets = append(targets, target)
+	}
+	switch len(targets) {
+	case 0:
+		return nil, fmt.Errorf("the target %s %s of the notification job %d isn't found in the policy %d",
+			j.NotifyType, j.NotifyAddress, j.ID, ply.ID)
+	case 1:
+		return &targets[0], nil
+	default:
+		return nil, fmt.Errorf("multiple targets of notify type %s found in the policy %d, "+
+			"can't determine the one the notification job %d was sent to", j.NotifyType, ply.ID, j.ID)
+	}
+}
+
 // GetNameFromImgRepoFullName gets image name from repo full name with format `repoName/imageName`
 func GetNameFromImgRepoFullName(repo string) string {
 	idx := strings.Index(repo, "/")
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/goharbor/harbor/src/common"
+	job_model "github.com/goharbor/harbor/src/pkg/notification/job/model"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
 )
 
 func TestMain(m *testing.M) {
@@ -58,3 +60,92 @@ func TestBuildImageResourceURL(t *testing.T) {
 		})
 	}
 }
+
+func TestResendHook(t *testing.T) {
+	ply := &policy_model.Policy{
+		ID:        1,
+		ProjectID: 1,
+		Targets: []policy_model.EventTarget{
+			{
+				Type:    "http",
+				Address: "http://127.0.0.1:8080",
+			},
+		},
+	}
+
+	// invalid payload
+	err := ResendHook(ply, &job_model.Job{ID: 1, NotifyType: "http", JobDetail: "invalid"})
+	if err == nil {
+		t.Errorf("ResendHook() expected error for the invalid payload")
+	}
+
+	// no target of the notify type
+	err = ResendHook(ply, &job_model.Job{ID: 1, NotifyType: "slack", JobDetail: `{"type":"PUSH_ARTIFACT"}`})
+	if err == nil {
+		t.Errorf("ResendHook() expected error for the missing target")
+	}
+}
+
+func TestResendTarget(t *testing.T) {
+	ply := &policy_model.Policy{
+		ID: 1,
+		Targets: []policy_model.EventTarget{
+			{
+				Type:    "http",
+				Address: "http://127.0.0.1:8080",
+			},
+			{
+				Type:    "http",
+				Address: "http://127.0.0.1:8081",
+			},
+			{
+				Type:    "slack",
+				Address: "https://hooks.slack.com/services/xxx",
+			},
+		},
+	}
+	cases := []struct {
+		name    string
+		job     *job_model.Job
+		address string
+		wantErr bool
+	}{
+		{
+			name:    "matched by the address",
+			job:     &job_model.Job{NotifyType: "http", NotifyAddress: "http://127.0.0.1:8081"},
+			address: "http://127.0.0.1:8081",
+		},
+		{
+			name:    "the address is removed from the policy",
+			job:     &job_model.Job{NotifyType: "http", NotifyAddress: "http://127.0.0.1:8082"},
+			wantErr: true,
+		},
+		{
+			name:    "the only target of the type without the address recorded",
+			job:     &job_model.Job{NotifyType: "slack"},
+			address: "https://hooks.slack.com/services/xxx",
+		},
+		{
+			name:    "ambiguous targets without the address recorded",
+			job:     &job_model.Job{NotifyType: "http"},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			target, err := resendTarget(ply, c.job)
+			if c.wantErr {
+				if err == nil {
+					t.Errorf("resendTarget() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resendTarget() unexpected error: %v", err)
+			}
+			if target.Address != c.address {
+				t.Errorf("resendTarget() = %s, want %s", target.Address, c.address)
+			}
+		})
+	}
+}
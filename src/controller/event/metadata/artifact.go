@@ -85,6 +85,8 @@ type DeleteArtifactEventMetadata struct {
 	Ctx      context.Context
 	Artifact *artifact.Artifact
 	Tags     []string
+	// the count of the artifacts deleted, including the children and accessories deleted along with the artifact
+	Deleted int64
 }
 
 // Resolve to the event from the metadata
@@ -97,6 +99,7 @@ func (d *DeleteArtifactEventMetadata) Resolve(event *event.Event) error {
 			Tags:       d.Tags,
 			OccurAt:    time.Now(),
 		},
+		Deleted: d.Deleted,
 	}
 	ctx, exist := security.FromContext(d.Ctx)
 	if exist {
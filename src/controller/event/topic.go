@@ -19,6 +19,7 @@ import (
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"time"
 
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/selector"
 	"github.com/goharbor/harbor/src/pkg/artifact"
 	"github.com/goharbor/harbor/src/pkg/audit/model"
@@ -51,6 +52,30 @@ const (
 	TopicTagRetention    = "TAG_RETENTION"
 )
 
+// Client is the details of the client which triggers the event, they're recorded in the audit log
+type Client struct {
+	ClientIP   string
+	UserAgent  string
+	AuthMethod string
+	StatusCode int
+}
+
+// SetClient sets the details of the client and the status code of the response
+func (c *Client) SetClient(client lib.ClientInfo, statusCode int) {
+	c.ClientIP = client.IP
+	c.UserAgent = client.UserAgent
+	c.AuthMethod = client.AuthMethod
+	c.StatusCode = statusCode
+}
+
+// FillAuditLog fills the details of the client into the audit log
+func (c *Client) FillAuditLog(auditLog *model.AuditLog) {
+	auditLog.ClientIP = c.ClientIP
+	auditLog.UserAgent = c.UserAgent
+	auditLog.AuthMethod = c.AuthMethod
+	auditLog.StatusCode = c.StatusCode
+}
+
 // CreateProjectEvent is the creating project event
 type CreateProjectEvent struct {
 	EventType string
@@ -58,6 +83,7 @@ type CreateProjectEvent struct {
 	Project   string
 	Operator  string
 	OccurAt   time.Time
+	Client
 }
 
 // ResolveToAuditLog ...
@@ -84,6 +110,7 @@ type DeleteProjectEvent struct {
 	Project   string
 	Operator  string
 	OccurAt   time.Time
+	Client
 }
 
 // ResolveToAuditLog ...
@@ -110,6 +137,7 @@ type DeleteRepositoryEvent struct {
 	Repository string
 	Operator   string
 	OccurAt    time.Time
+	Client
 }
 
 // ResolveToAuditLog ...
@@ -138,6 +166,7 @@ type ArtifactEvent struct {
 	Tags       []string // when the artifact is pushed by digest, the tag here will be null
 	Operator   string
 	OccurAt    time.Time
+	Client
 }
 
 func (a *ArtifactEvent) String() string {
@@ -214,6 +243,8 @@ func (p *PullArtifactEvent) String() string {
 // DeleteArtifactEvent is the deleting artifact event
 type DeleteArtifactEvent struct {
 	*ArtifactEvent
+	// the count of the artifacts deleted, including the children and accessories deleted along with the artifact
+	Deleted int64
 }
 
 // ResolveToAuditLog ...
@@ -240,6 +271,7 @@ type CreateTagEvent struct {
 	AttachedArtifact *artifact.Artifact
 	Operator         string
 	OccurAt          time.Time
+	Client
 }
 
 // ResolveToAuditLog ...
@@ -268,6 +300,7 @@ type DeleteTagEvent struct {
 	AttachedArtifact *artifact.Artifact
 	Operator         string
 	OccurAt          time.Time
+	Client
 }
 
 // ResolveToAuditLog ...
@@ -37,6 +37,9 @@ func init() {
 	if err := task.RegisterTaskStatusChangePostFunc(GCVendorType, gcTaskStatusChange); err != nil {
 		log.Fatalf("failed to register the task status change post for the gc job, error %v", err)
 	}
+	if err := task.RegisterCheckInProcessor(GCVendorType, gcTaskCheckIn); err != nil {
+		log.Fatalf("failed to register the checkin processor for the gc job, error %v", err)
+	}
 }
 
 func gcCallback(ctx context.Context, p string) error {
@@ -57,3 +60,27 @@ func gcTaskStatusChange(ctx context.Context, taskID int64, status string) error
 
 	return nil
 }
+
+// gcTaskCheckIn persists the sweep progress checked in by the gc job in the extra attributes of the task
+func gcTaskCheckIn(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
+	if sc.CheckIn == "" {
+		return nil
+	}
+
+	progress := &Progress{}
+	if err := json.Unmarshal([]byte(sc.CheckIn), progress); err != nil {
+		log.Errorf("failed to resolve checkin of gc task %d: %v", t.ID, err)
+		return err
+	}
+
+	extraAttrs := t.ExtraAttrs
+	if extraAttrs == nil {
+		extraAttrs = map[string]interface{}{}
+	}
+	extraAttrs[progressKey] = progress
+	if err := task.Mgr.UpdateExtraAttrs(ctx, t.ID, extraAttrs); err != nil {
+		log.G(ctx).WithField("error", err).Errorf("failed to update the progress of gc task %d", t.ID)
+		return err
+	}
+	return nil
+}
@@ -2,9 +2,11 @@ package gc
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/task"
@@ -25,11 +27,19 @@ const (
 	SchedulerCallback = "GARBAGE_COLLECTION"
 	// GCVendorType ...
 	GCVendorType = "GARBAGE_COLLECTION"
+
+	// the key of the sweep progress in the extra attributes of the task
+	progressKey = "progress"
+	// SystemVendorID is the vendor ID of the system wide gc executions and schedule
+	SystemVendorID = -1
+	// the max count of the consecutive executions resuming the sweep, the next one marks the candidates
+	// again to avoid resuming the left candidates endlessly, e.g. the same blob fails to delete every time
+	maxConsecutiveResumes = 3
 )
 
 // Controller manages the tags
 type Controller interface {
-	// Start start a manual gc job
+	// Start start a manual gc job, the gc is restricted to the scope of the policy if it's set
 	Start(ctx context.Context, policy Policy, trigger string) (int64, error)
 	// Stop stop a gc job
 	Stop(ctx context.Context, id int64) error
@@ -48,12 +58,12 @@ type Controller interface {
 	// GetTaskLog gets log of the specific task
 	GetTaskLog(ctx context.Context, id int64) ([]byte, error)
 
-	// GetSchedule get the current gc schedule
-	GetSchedule(ctx context.Context) (*scheduler.Schedule, error)
-	// CreateSchedule create the gc schedule with cron type & string
+	// GetSchedule get the current gc schedule of the project, the system wide one is returned if the project ID is -1
+	GetSchedule(ctx context.Context, projectID int64) (*scheduler.Schedule, error)
+	// CreateSchedule create the gc schedule with cron type & string, the schedule belongs to the project in the scope of the policy
 	CreateSchedule(ctx context.Context, cronType, cron string, policy Policy) (int64, error)
-	// DeleteSchedule remove the gc schedule
-	DeleteSchedule(ctx context.Context) error
+	// DeleteSchedule remove the gc schedule of the project, the system wide one is removed if the project ID is -1
+	DeleteSchedule(ctx context.Context, projectID int64) error
 }
 
 // NewController creates an instance of the default repository controller
@@ -73,13 +83,40 @@ type controller struct {
 
 // Start starts the manual GC
 func (c *controller) Start(ctx context.Context, policy Policy, trigger string) (int64, error) {
+	// the system wide gc and the scoped ones may delete the same blobs, so only one gc runs at a time
+	count, err := c.exeMgr.Count(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"VendorType": GCVendorType,
+			"Status":     job.RunningStatus.String(),
+		},
+	})
+	if err != nil {
+		return -1, err
+	}
+	if count > 0 {
+		return -1, errors.ConflictError(nil).WithMessage("a previous gc job is still running")
+	}
+
 	para := make(map[string]interface{})
 	para["delete_untagged"] = policy.DeleteUntagged
 	para["dry_run"] = policy.DryRun
 	para["redis_url_reg"] = policy.ExtraAttrs["redis_url_reg"]
 	para["time_window"] = policy.ExtraAttrs["time_window"]
+	para["workers"] = policy.ExtraAttrs["workers"]
+	if policy.Scope != nil {
+		para["project_id"] = policy.Scope.ProjectID
+		para["project_name"] = policy.Scope.ProjectName
+		para["repository"] = policy.Scope.Repository
+	} else if !policy.DryRun {
+		// only the system wide gc resumes as the candidates left are out of any scope
+		resume, err := c.shouldResume(ctx)
+		if err != nil {
+			return -1, err
+		}
+		para["resume"] = resume
+	}
 
-	execID, err := c.exeMgr.Create(ctx, GCVendorType, -1, trigger, para)
+	execID, err := c.exeMgr.Create(ctx, GCVendorType, policy.vendorID(), trigger, para)
 	if err != nil {
 		return -1, err
 	}
@@ -96,6 +133,59 @@ func (c *controller) Start(ctx context.Context, policy Policy, trigger string) (
 	return execID, nil
 }
 
+// shouldResume returns true when the latest execution failed or was stopped in the sweep phase, the candidates
+// left by it are swept directly by the next execution. The execution failed before the sweep, or following too
+// many consecutive resumed ones, marks the candidates again.
+func (c *controller) shouldResume(ctx context.Context) (bool, error) {
+	execs, err := c.exeMgr.List(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"VendorType": GCVendorType,
+			"VendorID":   SystemVendorID,
+		},
+		Sorts:      []*q.Sort{q.NewSort("start_time", true)},
+		PageNumber: 1,
+		PageSize:   maxConsecutiveResumes,
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(execs) == 0 {
+		return false, nil
+	}
+	latest := execs[0]
+	if dryRun, ok := latest.ExtraAttrs["dry_run"].(bool); ok && dryRun {
+		return false, nil
+	}
+	if latest.Status != job.ErrorStatus.String() && latest.Status != job.StoppedStatus.String() {
+		return false, nil
+	}
+
+	if len(execs) == maxConsecutiveResumes {
+		resumed := 0
+		for _, exec := range execs {
+			if resume, ok := exec.ExtraAttrs["resume"].(bool); ok && resume {
+				resumed++
+			}
+		}
+		if resumed == maxConsecutiveResumes {
+			log.G(ctx).Infof("the latest %d gc executions resumed the sweep, mark the candidates again", resumed)
+			return false, nil
+		}
+	}
+
+	// the progress is checked in once the sweep starts, the execution without it failed or was stopped before the sweep
+	tasks, err := c.taskMgr.List(ctx, q.New(q.KeyWords{"ExecutionID": latest.ID}))
+	if err != nil {
+		return false, err
+	}
+	for _, tk := range tasks {
+		if convertProgress(tk) != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Stop ...
 func (c *controller) Stop(ctx context.Context, id int64) error {
 	return c.exeMgr.Stop(ctx, id)
@@ -138,7 +228,18 @@ func (c *controller) GetExecution(ctx context.Context, id int64) (*Execution, er
 		return nil, errors.New(nil).WithCode(errors.NotFoundCode).
 			WithMessage("garbage collection execution %d not found", id)
 	}
-	return convertExecution(execs[0]), nil
+	execution := convertExecution(execs[0])
+
+	tasks, err := c.taskMgr.List(ctx, q.New(q.KeyWords{"ExecutionID": id}))
+	if err != nil {
+		return nil, err
+	}
+	for _, tk := range tasks {
+		if progress := convertProgress(tk); progress != nil {
+			execution.Progress = progress
+		}
+	}
+	return execution, nil
 }
 
 // GetTask ...
@@ -184,8 +285,8 @@ func (c *controller) GetTaskLog(ctx context.Context, id int64) ([]byte, error) {
 }
 
 // GetSchedule ...
-func (c *controller) GetSchedule(ctx context.Context) (*scheduler.Schedule, error) {
-	sch, err := c.schedulerMgr.ListSchedules(ctx, q.New(q.KeyWords{"VendorType": GCVendorType}))
+func (c *controller) GetSchedule(ctx context.Context, projectID int64) (*scheduler.Schedule, error) {
+	sch, err := c.schedulerMgr.ListSchedules(ctx, q.New(q.KeyWords{"VendorType": GCVendorType, "VendorID": projectID}))
 	if err != nil {
 		return nil, err
 	}
@@ -202,12 +303,23 @@ func (c *controller) GetSchedule(ctx context.Context) (*scheduler.Schedule, erro
 func (c *controller) CreateSchedule(ctx context.Context, cronType, cron string, policy Policy) (int64, error) {
 	extras := make(map[string]interface{})
 	extras["delete_untagged"] = policy.DeleteUntagged
-	return c.schedulerMgr.Schedule(ctx, GCVendorType, -1, cronType, cron, SchedulerCallback, policy, extras)
+	return c.schedulerMgr.Schedule(ctx, GCVendorType, policy.vendorID(), cronType, cron, SchedulerCallback, policy, extras)
 }
 
 // DeleteSchedule ...
-func (c *controller) DeleteSchedule(ctx context.Context) error {
-	return c.schedulerMgr.UnScheduleByVendor(ctx, GCVendorType, -1)
+func (c *controller) DeleteSchedule(ctx context.Context, projectID int64) error {
+	// the scheduler removes the schedules of all the vendors when the vendor ID isn't positive,
+	// so the schedules are removed by ID to keep the project ones when removing the system wide one
+	schs, err := c.schedulerMgr.ListSchedules(ctx, q.New(q.KeyWords{"VendorType": GCVendorType, "VendorID": projectID}))
+	if err != nil {
+		return err
+	}
+	for _, sch := range schs {
+		if err := c.schedulerMgr.UnScheduleByID(ctx, sch.ID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func convertExecution(exec *task.Execution) *Execution {
@@ -238,3 +350,22 @@ func convertTask(task *task.Task) *Task {
 		EndTime:        task.EndTime,
 	}
 }
+
+// convertProgress returns the sweep progress in the extra attributes of the task, nil is returned if there is no one
+func convertProgress(task *task.Task) *Progress {
+	value, exist := task.ExtraAttrs[progressKey]
+	if !exist {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Errorf("failed to marshal the progress of gc task %d: %v", task.ID, err)
+		return nil
+	}
+	progress := &Progress{}
+	if err := json.Unmarshal(data, progress); err != nil {
+		log.Errorf("failed to unmarshal the progress of gc task %d: %v", task.ID, err)
+		return nil
+	}
+	return progress
+}
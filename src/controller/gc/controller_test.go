@@ -2,6 +2,7 @@ package gc
 
 import (
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/task"
@@ -32,6 +33,8 @@ func (g *gcCtrTestSuite) SetupTest() {
 }
 
 func (g *gcCtrTestSuite) TestStart() {
+	g.execMgr.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+	g.execMgr.On("Count", mock.Anything, mock.Anything).Return(int64(0), nil)
 	g.execMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
 	g.taskMgr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
 	g.taskMgr.On("Stop", mock.Anything, mock.Anything).Return(nil)
@@ -46,6 +49,115 @@ func (g *gcCtrTestSuite) TestStart() {
 	g.Equal(int64(1), id)
 }
 
+// startResume starts the system wide gc following the executions and returns the resume parameter of it
+func (g *gcCtrTestSuite) startResume(execs []*task.Execution, tasks []*task.Task) interface{} {
+	g.SetupTest()
+	g.execMgr.On("List", mock.Anything, mock.Anything).Return(execs, nil)
+	g.execMgr.On("Count", mock.Anything, mock.Anything).Return(int64(0), nil)
+	g.execMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(2), nil)
+	g.taskMgr.On("List", mock.Anything, mock.Anything).Return(tasks, nil)
+	g.taskMgr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+
+	id, err := g.ctl.Start(nil, Policy{ExtraAttrs: map[string]interface{}{}}, task.ExecutionTriggerManual)
+	g.Require().Nil(err)
+	g.Equal(int64(2), id)
+	for _, call := range g.execMgr.Calls {
+		if call.Method == "Create" {
+			return call.Arguments.Get(4).(map[string]interface{})["resume"]
+		}
+	}
+	return nil
+}
+
+func (g *gcCtrTestSuite) TestStartResume() {
+	failed := func(id int64, resume bool) *task.Execution {
+		return &task.Execution{
+			ID:         id,
+			Status:     job.ErrorStatus.String(),
+			ExtraAttrs: map[string]interface{}{"dry_run": false, "resume": resume},
+		}
+	}
+	swept := []*task.Task{
+		{
+			ID:          1,
+			ExecutionID: 1,
+			Status:      job.ErrorStatus.String(),
+			ExtraAttrs:  map[string]interface{}{progressKey: map[string]interface{}{"blobs_total": 10, "blobs_done": 5}},
+		},
+	}
+	notSwept := []*task.Task{
+		{
+			ID:          1,
+			ExecutionID: 1,
+			Status:      job.ErrorStatus.String(),
+			ExtraAttrs:  map[string]interface{}{},
+		},
+	}
+
+	// the latest execution failed in the sweep phase
+	g.Equal(true, g.startResume([]*task.Execution{failed(1, false)}, swept))
+	// the latest execution failed before the sweep phase
+	g.Equal(false, g.startResume([]*task.Execution{failed(1, false)}, notSwept))
+	// the latest execution succeeded
+	g.Equal(false, g.startResume([]*task.Execution{
+		{ID: 1, Status: job.SuccessStatus.String(), ExtraAttrs: map[string]interface{}{"dry_run": false}},
+	}, swept))
+	// the latest execution was a dry run
+	g.Equal(false, g.startResume([]*task.Execution{
+		{ID: 1, Status: job.StoppedStatus.String(), ExtraAttrs: map[string]interface{}{"dry_run": true}},
+	}, swept))
+	// the latest executions resumed less than the max times
+	g.Equal(true, g.startResume([]*task.Execution{failed(3, true), failed(2, true), failed(1, false)}, swept))
+	// the latest executions resumed the max times
+	g.Equal(false, g.startResume([]*task.Execution{failed(3, true), failed(2, true), failed(1, true)}, swept))
+}
+
+func (g *gcCtrTestSuite) TestStartRunning() {
+	g.execMgr.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
+
+	// neither the system wide gc nor the scoped one starts while any gc is running
+	_, err := g.ctl.Start(nil, Policy{ExtraAttrs: map[string]interface{}{}}, task.ExecutionTriggerManual)
+	g.True(errors.IsConflictErr(err))
+	_, err = g.ctl.Start(nil, Policy{
+		ExtraAttrs: map[string]interface{}{},
+		Scope:      &Scope{ProjectID: 2, ProjectName: "library"},
+	}, task.ExecutionTriggerManual)
+	g.True(errors.IsConflictErr(err))
+	g.execMgr.AssertNotCalled(g.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	for _, call := range g.execMgr.Calls {
+		if call.Method == "Count" {
+			query := call.Arguments.Get(1).(*q.Query)
+			g.Equal(GCVendorType, query.Keywords["VendorType"])
+			g.Equal(job.RunningStatus.String(), query.Keywords["Status"])
+			g.Nil(query.Keywords["VendorID"])
+		}
+	}
+}
+
+func (g *gcCtrTestSuite) TestStartScoped() {
+	g.execMgr.On("Count", mock.Anything, mock.Anything).Return(int64(0), nil)
+	g.execMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	g.taskMgr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+
+	p := Policy{
+		ExtraAttrs: map[string]interface{}{},
+		Scope:      &Scope{ProjectID: 2, ProjectName: "library", Repository: "library/hello-world"},
+	}
+	id, err := g.ctl.Start(nil, p, task.ExecutionTriggerManual)
+	g.Nil(err)
+	g.Equal(int64(1), id)
+	// the scoped gc never resumes
+	g.execMgr.AssertNotCalled(g.T(), "List", mock.Anything, mock.Anything)
+	for _, call := range g.execMgr.Calls {
+		if call.Method == "Create" {
+			g.Equal(int64(2), call.Arguments.Get(2))
+			para := call.Arguments.Get(4).(map[string]interface{})
+			g.Equal("library/hello-world", para["repository"])
+			g.Nil(para["resume"])
+		}
+	}
+}
+
 func (g *gcCtrTestSuite) TestStop() {
 	g.execMgr.On("Stop", mock.Anything, mock.Anything).Return(nil)
 	g.Nil(g.ctl.Stop(nil, 1))
@@ -83,10 +195,32 @@ func (g *gcCtrTestSuite) TestGetExecution() {
 		},
 	}, nil)
 
+	g.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{
+		{
+			ID:          1,
+			ExecutionID: 1,
+			ExtraAttrs: map[string]interface{}{
+				"progress": map[string]interface{}{
+					"blobs_total": 10,
+					"blobs_done":  5,
+					"bytes_freed": 1024,
+					"repositories": map[string]interface{}{
+						"library/hello-world": map[string]interface{}{"manifests_total": 2, "manifests_done": 1},
+					},
+				},
+			},
+		},
+	}, nil)
+
 	hs, err := g.ctl.GetExecution(nil, int64(1))
 	g.Nil(err)
 
 	g.Equal("Manual", hs.Trigger)
+	g.Require().NotNil(hs.Progress)
+	g.Equal(10, hs.Progress.BlobsTotal)
+	g.Equal(5, hs.Progress.BlobsDone)
+	g.Equal(int64(1024), hs.Progress.BytesFreed)
+	g.Equal(1, hs.Progress.Repositories["library/hello-world"].ManifestsDone)
 }
 
 func (g *gcCtrTestSuite) TestListExecutions() {
@@ -135,7 +269,7 @@ func (g *gcCtrTestSuite) TestGetSchedule() {
 		},
 	}, nil)
 
-	sche, err := g.ctl.GetSchedule(nil)
+	sche, err := g.ctl.GetSchedule(nil, -1)
 	g.Nil(err)
 	g.Equal("gc", sche.VendorType)
 }
@@ -154,9 +288,33 @@ func (g *gcCtrTestSuite) TestCreateSchedule() {
 	g.Equal(int64(1), id)
 }
 
+func (g *gcCtrTestSuite) TestCreateScheduleScoped() {
+	g.scheduler.On("Schedule", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+
+	p := Policy{
+		ExtraAttrs: map[string]interface{}{},
+		Scope:      &Scope{ProjectID: 2, ProjectName: "library"},
+	}
+	_, err := g.ctl.CreateSchedule(nil, "Daily", "* * * * * *", p)
+	g.Nil(err)
+	g.scheduler.AssertCalled(g.T(), "Schedule", mock.Anything, GCVendorType, int64(2), "Daily", "* * * * * *",
+		SchedulerCallback, p, mock.Anything)
+}
+
 func (g *gcCtrTestSuite) TestDeleteSchedule() {
-	g.scheduler.On("UnScheduleByVendor", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	g.Nil(g.ctl.DeleteSchedule(nil))
+	g.scheduler.On("ListSchedules", mock.Anything, mock.Anything).Return([]*scheduler.Schedule{
+		{
+			ID:         1,
+			VendorType: "gc",
+			VendorID:   -1,
+		},
+	}, nil)
+	g.scheduler.On("UnScheduleByID", mock.Anything, mock.Anything).Return(nil)
+	g.Nil(g.ctl.DeleteSchedule(nil, -1))
+	g.scheduler.AssertCalled(g.T(), "UnScheduleByID", mock.Anything, int64(1))
+	query := g.scheduler.Calls[0].Arguments.Get(1).(*q.Query)
+	g.Equal(int64(-1), query.Keywords["VendorID"])
 }
 
 func TestControllerTestSuite(t *testing.T) {
@@ -10,6 +10,25 @@ type Policy struct {
 	DeleteUntagged bool                   `json:"deleteuntagged"`
 	DryRun         bool                   `json:"dryrun"`
 	ExtraAttrs     map[string]interface{} `json:"extra_attrs"`
+	// Scope restricts the gc to the project or repository, it's a system wide gc if the scope is nil
+	Scope *Scope `json:"scope,omitempty"`
+}
+
+// Scope restricts the mark phase of the gc to the blobs only referenced by the project or repository
+type Scope struct {
+	ProjectID   int64  `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	// Repository is the name of the repository including the project name, the whole project is in scope if it's empty
+	Repository string `json:"repository,omitempty"`
+}
+
+// vendorID returns the vendor ID of the executions and schedule of the policy,
+// it's the project ID for the scoped gc, and -1 for the system wide one
+func (p *Policy) vendorID() int64 {
+	if p.Scope != nil {
+		return p.Scope.ProjectID
+	}
+	return SystemVendorID
 }
 
 // TriggerType represents the type of trigger.
@@ -35,6 +54,24 @@ type Execution struct {
 	ExtraAttrs    map[string]interface{}
 	StartTime     time.Time
 	EndTime       time.Time
+	// Progress of the sweep phase, it's nil before the sweep starts
+	Progress *Progress
+}
+
+// Progress is the progress of the sweep phase checked in by the gc job
+type Progress struct {
+	// Resumed is true when the execution resumes the sweep of the last failed or stopped one
+	Resumed      bool                           `json:"resumed"`
+	BlobsTotal   int                            `json:"blobs_total"`
+	BlobsDone    int                            `json:"blobs_done"`
+	BytesFreed   int64                          `json:"bytes_freed"`
+	Repositories map[string]*RepositoryProgress `json:"repositories,omitempty"`
+}
+
+// RepositoryProgress is the progress of deleting the manifests of the repository
+type RepositoryProgress struct {
+	ManifestsTotal int `json:"manifests_total"`
+	ManifestsDone  int `json:"manifests_done"`
 }
 
 // Task model for replication
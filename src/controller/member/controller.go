@@ -19,12 +19,15 @@ import (
 	"fmt"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/core/auth"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/member"
 	"github.com/goharbor/harbor/src/pkg/member/models"
 	"github.com/goharbor/harbor/src/pkg/project"
+	"github.com/goharbor/harbor/src/pkg/rbac"
 	"github.com/goharbor/harbor/src/pkg/user"
 	"github.com/goharbor/harbor/src/pkg/usergroup"
 )
@@ -67,21 +70,25 @@ type UserGroup struct {
 	LdapGroupDN string `json:"ldap_group_dn,omitempty"`
 }
 
+// refreshGroupQuotas is declared as a variable so that the tests can inspect the refreshed user groups
+var refreshGroupQuotas = quota.RefreshForGroups
+
 // ErrDuplicateProjectMember ...
 var ErrDuplicateProjectMember = errors.ConflictError(nil).WithMessage("The project member specified already exist")
 
 // ErrInvalidRole ...
-var ErrInvalidRole = errors.BadRequestError(nil).WithMessage("Failed to update project member, role is not in 1,2,3")
+var ErrInvalidRole = errors.BadRequestError(nil).WithMessage("Failed to update project member, role is neither a built-in role nor a custom role")
 
 type controller struct {
 	userManager user.Manager
 	mgr         member.Manager
 	projectMgr  project.Manager
+	roleMgr     rbac.Manager
 }
 
 // NewController ...
 func NewController() Controller {
-	return &controller{mgr: member.Mgr, projectMgr: project.Mgr, userManager: user.New()}
+	return &controller{mgr: member.Mgr, projectMgr: project.Mgr, userManager: user.New(), roleMgr: rbac.NewManager()}
 }
 
 func (c *controller) Count(ctx context.Context, projectNameOrID interface{}, query *q.Query) (int, error) {
@@ -100,7 +107,25 @@ func (c *controller) UpdateRole(ctx context.Context, projectNameOrID interface{}
 	if p == nil {
 		return errors.BadRequestError(nil).WithMessage("project is not found")
 	}
-	return c.mgr.UpdateRole(ctx, p.ProjectID, memberID, role)
+	valid, err := c.isValidRole(ctx, role)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ErrInvalidRole
+	}
+	// get the member before the change to know whether the user group gains or loses the project admin role
+	m, err := c.mgr.Get(ctx, p.ProjectID, memberID)
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return err
+	}
+	if err := c.mgr.UpdateRole(ctx, p.ProjectID, memberID, role); err != nil {
+		return err
+	}
+	if m != nil && m.Role != role {
+		c.refreshGroupQuota(ctx, m.EntityType, m.EntityID, m.Role, role)
+	}
+	return nil
 }
 
 func (c *controller) Get(ctx context.Context, projectNameOrID interface{}, memberID int) (*models.Member, error) {
@@ -202,24 +227,60 @@ func (c *controller) Create(ctx context.Context, projectNameOrID interface{}, re
 		return 0, ErrDuplicateProjectMember
 	}
 
-	if !isValidRole(member.Role) {
+	valid, err := c.isValidRole(ctx, member.Role)
+	if err != nil {
+		return 0, err
+	}
+	if !valid {
 		// Return invalid role error
 		return 0, ErrInvalidRole
 	}
-	return c.mgr.AddProjectMember(ctx, member)
+	id, err := c.mgr.AddProjectMember(ctx, member)
+	if err != nil {
+		return 0, err
+	}
+	c.refreshGroupQuota(ctx, member.EntityType, member.EntityID, member.Role)
+	return id, nil
 }
 
-func isValidRole(role int) bool {
+// refreshGroupQuota refreshes the quota of the user group when it gains or loses the project admin role,
+// as the usage of the user group aggregates the usage of the projects it's the project admin of
+func (c *controller) refreshGroupQuota(ctx context.Context, entityType string, entityID int, roles ...int) {
+	if entityType != common.GroupMember {
+		return
+	}
+	for _, role := range roles {
+		if role == common.RoleProjectAdmin {
+			// ignore the error, the quota usage will be correct when the usage of the projects is refreshed
+			if err := refreshGroupQuotas(ctx, quota.ReferenceID(entityID)); err != nil {
+				log.G(ctx).Warningf("refresh quota usage for user group %d failed, error: %v", entityID, err)
+			}
+			return
+		}
+	}
+}
+
+// isValidRole checks whether the role is a built-in role or an existing custom role
+func (c *controller) isValidRole(ctx context.Context, role int) (bool, error) {
 	switch role {
 	case common.RoleProjectAdmin,
 		common.RoleMaintainer,
 		common.RoleDeveloper,
 		common.RoleGuest,
 		common.RoleLimitedGuest:
-		return true
-	default:
-		return false
+		return true, nil
 	}
+	if role <= 0 {
+		return false, nil
+	}
+	_, err := c.roleMgr.GetRole(ctx, int64(role))
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
 }
 
 func (c *controller) List(ctx context.Context, projectNameOrID interface{}, entityName string, query *q.Query) ([]*models.Member, error) {
@@ -242,5 +303,16 @@ func (c *controller) Delete(ctx context.Context, projectNameOrID interface{}, me
 	if err != nil {
 		return err
 	}
-	return c.mgr.Delete(ctx, p.ProjectID, memberID)
+	// get the member before the change to know whether the user group gains or loses the project admin role
+	m, err := c.mgr.Get(ctx, p.ProjectID, memberID)
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return err
+	}
+	if err := c.mgr.Delete(ctx, p.ProjectID, memberID); err != nil {
+		return err
+	}
+	if m != nil {
+		c.refreshGroupQuota(ctx, m.EntityType, m.EntityID, m.Role)
+	}
+	return nil
 }
@@ -13,3 +13,160 @@
 //  limitations under the License.
 
 package member
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/member"
+	"github.com/goharbor/harbor/src/pkg/member/models"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/rbac/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	projecttesting "github.com/goharbor/harbor/src/testing/pkg/project"
+	"github.com/goharbor/harbor/src/testing/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidRole(t *testing.T) {
+	roleMgr := &rbac.Manager{}
+	c := &controller{roleMgr: roleMgr}
+	roleMgr.On("GetRole", mock.Anything, int64(6)).Return(&model.Role{ID: 6, Name: "puller"}, nil)
+	roleMgr.On("GetRole", mock.Anything, int64(7)).Return(nil, errors.NotFoundError(nil))
+
+	for role, expected := range map[int]bool{
+		common.RoleProjectAdmin: true,
+		common.RoleLimitedGuest: true,
+		6:                       true,
+		7:                       false,
+		0:                       false,
+	} {
+		valid, err := c.isValidRole(context.TODO(), role)
+		require.Nil(t, err)
+		assert.Equal(t, expected, valid, role)
+	}
+}
+
+type fakeMemberManager struct {
+	member.Manager
+	member *models.Member
+}
+
+func (f *fakeMemberManager) AddProjectMember(ctx context.Context, member models.Member) (int, error) {
+	return 1, nil
+}
+
+func (f *fakeMemberManager) Get(ctx context.Context, projectID int64, memberID int) (*models.Member, error) {
+	return f.member, nil
+}
+
+func (f *fakeMemberManager) List(ctx context.Context, queryMember models.Member, query *q.Query) ([]*models.Member, error) {
+	return nil, nil
+}
+
+func (f *fakeMemberManager) UpdateRole(ctx context.Context, projectID int64, pmID int, role int) error {
+	return nil
+}
+
+func (f *fakeMemberManager) Delete(ctx context.Context, projectID int64, memberID int) error {
+	return nil
+}
+
+func TestRefreshGroupQuota(t *testing.T) {
+	var refreshed []string
+	refreshGroupQuotas = func(ctx context.Context, referenceIDs ...string) error {
+		refreshed = append(refreshed, referenceIDs...)
+		return nil
+	}
+	defer func() {
+		refreshGroupQuotas = quota.RefreshForGroups
+	}()
+
+	projectMgr := &projecttesting.Manager{}
+	mock.OnAnything(projectMgr, "Get").Return(&proModels.Project{ProjectID: 1}, nil)
+	memberMgr := &fakeMemberManager{}
+	c := &controller{mgr: memberMgr, projectMgr: projectMgr}
+
+	groupAdmin := &models.Member{ID: 1, EntityID: 2, EntityType: common.GroupMember, Role: common.RoleProjectAdmin}
+	groupDeveloper := &models.Member{ID: 1, EntityID: 2, EntityType: common.GroupMember, Role: common.RoleDeveloper}
+	userAdmin := &models.Member{ID: 1, EntityID: 2, EntityType: common.UserMember, Role: common.RoleProjectAdmin}
+
+	cases := []struct {
+		name      string
+		member    *models.Member
+		f         func() error
+		refreshed bool
+	}{
+		{
+			name: "add group as project admin",
+			f: func() error {
+				_, err := c.Create(context.TODO(), int64(1), Request{Role: common.RoleProjectAdmin, MemberGroup: UserGroup{ID: 2}})
+				return err
+			},
+			refreshed: true,
+		},
+		{
+			name: "add group as developer",
+			f: func() error {
+				_, err := c.Create(context.TODO(), int64(1), Request{Role: common.RoleDeveloper, MemberGroup: UserGroup{ID: 2}})
+				return err
+			},
+		},
+		{
+			name: "add user as project admin",
+			f: func() error {
+				_, err := c.Create(context.TODO(), int64(1), Request{Role: common.RoleProjectAdmin, MemberUser: User{UserID: 2}})
+				return err
+			},
+		},
+		{
+			name:      "promote group to project admin",
+			member:    groupDeveloper,
+			f:         func() error { return c.UpdateRole(context.TODO(), int64(1), 1, common.RoleProjectAdmin) },
+			refreshed: true,
+		},
+		{
+			name:      "demote group from project admin",
+			member:    groupAdmin,
+			f:         func() error { return c.UpdateRole(context.TODO(), int64(1), 1, common.RoleMaintainer) },
+			refreshed: true,
+		},
+		{
+			name:   "change role of group other than project admin",
+			member: groupDeveloper,
+			f:      func() error { return c.UpdateRole(context.TODO(), int64(1), 1, common.RoleMaintainer) },
+		},
+		{
+			name:   "demote user from project admin",
+			member: userAdmin,
+			f:      func() error { return c.UpdateRole(context.TODO(), int64(1), 1, common.RoleMaintainer) },
+		},
+		{
+			name:      "remove group of project admin",
+			member:    groupAdmin,
+			f:         func() error { return c.Delete(context.TODO(), int64(1), 1) },
+			refreshed: true,
+		},
+		{
+			name:   "remove group of developer",
+			member: groupDeveloper,
+			f:      func() error { return c.Delete(context.TODO(), int64(1), 1) },
+		},
+	}
+
+	for _, tc := range cases {
+		refreshed = nil
+		memberMgr.member = tc.member
+		require.Nil(t, tc.f(), tc.name)
+		if tc.refreshed {
+			assert.Equal(t, []string{"2"}, refreshed, tc.name)
+		} else {
+			assert.Empty(t, refreshed, tc.name)
+		}
+	}
+}
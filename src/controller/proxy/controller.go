@@ -27,6 +27,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/blob"
 	"github.com/goharbor/harbor/src/controller/event/operator"
+	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/controller/tag"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/cache"
@@ -34,6 +35,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/task"
 	"github.com/opencontainers/go-digest"
 )
 
@@ -44,6 +46,8 @@ const (
 	sleepIntervalSec    = 20
 	// keep manifest list in cache for one week
 	manifestListCacheInterval = 7 * 24 * 60 * 60 * time.Second
+	// keep the time that the tag was confirmed with the remote registry in cache for 30 days
+	tagConfirmedCacheInterval = 30 * 24 * 60 * 60 * time.Second
 )
 
 var (
@@ -68,11 +72,27 @@ type Controller interface {
 	HeadManifest(ctx context.Context, art lib.ArtifactInfo, remote RemoteInterface) (bool, *distribution.Descriptor, error)
 	// EnsureTag ensure tag for digest
 	EnsureTag(ctx context.Context, art lib.ArtifactInfo, tagName string) error
+	// StaleManifest returns the local copy of the tag which can be served when the remote server is unavailable,
+	// nil is returned if serving the stale manifest isn't enabled in the proxy project p or the art isn't a tag,
+	// and a not found error is returned if the local copy is out of the stale window of the project
+	StaleManifest(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo) (*artifact.Artifact, error)
+	// FreshManifest returns the local copy of the tag if it was confirmed with the remote server within
+	// the tag TTL of the proxy project p, nil is returned if the art isn't a tag or the local copy isn't fresh
+	FreshManifest(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo) (*artifact.Artifact, error)
+	// EvictArtifacts deletes the least recently pulled artifacts of the proxy project p when the storage
+	// usage reaches the eviction threshold of the project
+	EvictArtifacts(ctx context.Context, p *proModels.Project) error
+	// Prewarm launches the jobs to pull the artifacts through the proxy project p ahead of time, the references
+	// are in the format of "repository:tag" or "repository@digest", it returns the ID of the execution
+	Prewarm(ctx context.Context, p *proModels.Project, references []string) (int64, error)
 }
 
 type controller struct {
 	blobCtl         blob.Controller
 	artifactCtl     artifact.Controller
+	quotaCtl        quota.Controller
+	execMgr         task.ExecutionManager
+	taskMgr         task.Manager
 	local           localInterface
 	cache           cache.Cache
 	handlerRegistry map[string]ManifestCacheHandler
@@ -87,6 +107,9 @@ func ControllerInstance() Controller {
 		ctl = &controller{
 			blobCtl:         blob.Ctl,
 			artifactCtl:     artifact.Ctl,
+			quotaCtl:        quota.Ctl,
+			execMgr:         task.ExecMgr,
+			taskMgr:         task.Mgr,
 			local:           newLocalHelper(),
 			cache:           cache.Default(),
 			handlerRegistry: NewCacheHandlerRegistry(l),
@@ -159,6 +182,7 @@ func (c *controller) UseLocalManifest(ctx context.Context, art lib.ArtifactInfo,
 		}()
 		return false, nil, errors.NotFoundError(fmt.Errorf("repo %v, tag %v not found", art.Repository, art.Tag))
 	}
+	c.confirmTag(art, string(desc.Digest))
 
 	var content []byte
 	if c.cache != nil {
@@ -181,6 +205,71 @@ func getManifestListKey(repo, dig string) string {
 	return "manifestlist:" + repo + ":" + dig
 }
 
+func getTagConfirmedKey(repo, tag, dig string) string {
+	// actual redis key format is cache:tagconfirmed:<repo name>:<tag>:sha256:xxxx
+	return "tagconfirmed:" + repo + ":" + tag + ":" + dig
+}
+
+// confirmTag records the time that the remote server confirmed the tag refers to the digest
+func (c *controller) confirmTag(art lib.ArtifactInfo, dig string) {
+	if c.cache == nil || len(art.Tag) == 0 || len(dig) == 0 {
+		return
+	}
+	if err := c.cache.Save(getTagConfirmedKey(art.Repository, art.Tag, dig), time.Now().Unix(), tagConfirmedCacheInterval); err != nil {
+		log.Errorf("failed to save the confirmed time of tag %v:%v, error: %v", art.Repository, art.Tag, err)
+	}
+}
+
+// tagConfirmedTime returns the last time that the remote server confirmed the tag refers to the local artifact,
+// the push time of the artifact is used if the tag is never confirmed
+func (c *controller) tagConfirmedTime(art lib.ArtifactInfo, a *artifact.Artifact) time.Time {
+	if c.cache != nil {
+		var confirmed int64
+		err := c.cache.Fetch(getTagConfirmedKey(art.Repository, art.Tag, a.Digest), &confirmed)
+		if err == nil {
+			return time.Unix(confirmed, 0)
+		}
+		if err != cache.ErrNotFound {
+			log.Errorf("failed to get the confirmed time of tag %v:%v from cache, error: %v", art.Repository, art.Tag, err)
+		}
+	}
+	return a.PushTime
+}
+
+func (c *controller) StaleManifest(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo) (*artifact.Artifact, error) {
+	// the manifest pulled by digest never gets stale
+	if !p.ProxyCacheServeStale() || len(art.Tag) == 0 {
+		return nil, nil
+	}
+	a, err := c.local.GetManifest(ctx, art)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	window := p.ProxyCacheStaleWindow()
+	if window > 0 {
+		if confirmed := c.tagConfirmedTime(art, a); time.Since(confirmed) > window {
+			return nil, errors.NotFoundError(nil).WithMessage("the cached tag %v:%v was confirmed at %v, which is out of the stale window %v of project %v",
+				art.Repository, art.Tag, confirmed.Format(time.RFC3339), window, p.Name)
+		}
+	}
+	return a, nil
+}
+
+func (c *controller) FreshManifest(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo) (*artifact.Artifact, error) {
+	ttl := p.ProxyCacheTagTTL()
+	if ttl == 0 || len(art.Tag) == 0 {
+		return nil, nil
+	}
+	a, err := c.local.GetManifest(ctx, art)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	if time.Since(c.tagConfirmedTime(art, a)) > ttl {
+		return nil, nil
+	}
+	return a, nil
+}
+
 func (c *controller) ProxyManifest(ctx context.Context, art lib.ArtifactInfo, remote RemoteInterface) (distribution.Manifest, error) {
 	var man distribution.Manifest
 	remoteRepo := getRemoteRepo(art)
@@ -198,6 +287,7 @@ func (c *controller) ProxyManifest(ctx context.Context, art lib.ArtifactInfo, re
 	if err != nil {
 		return man, err
 	}
+	c.confirmTag(art, dig)
 
 	// Push manifest in background
 	go func(operator string) {
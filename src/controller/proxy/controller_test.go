@@ -19,16 +19,26 @@ import (
 	"github.com/docker/distribution"
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/blob"
+	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/proxycache"
 	"github.com/goharbor/harbor/src/lib"
 	_ "github.com/goharbor/harbor/src/lib/cache"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	quotaModels "github.com/goharbor/harbor/src/pkg/quota/models"
+	"github.com/goharbor/harbor/src/pkg/task"
+	testartifact "github.com/goharbor/harbor/src/testing/controller/artifact"
 	testproxy "github.com/goharbor/harbor/src/testing/controller/proxy"
+	testquota "github.com/goharbor/harbor/src/testing/controller/quota"
+	testtask "github.com/goharbor/harbor/src/testing/pkg/task"
 	"github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"io"
 	"testing"
+	"time"
 )
 
 type localInterfaceMock struct {
@@ -80,19 +90,30 @@ func (l *localInterfaceMock) DeleteManifest(repo, ref string) {
 
 type proxyControllerTestSuite struct {
 	suite.Suite
-	local  *localInterfaceMock
-	remote *testproxy.RemoteInterface
-	ctr    Controller
-	proj   *proModels.Project
+	local    *localInterfaceMock
+	remote   *testproxy.RemoteInterface
+	artCtl   *testartifact.Controller
+	quotaCtl *testquota.Controller
+	execMgr  *testtask.ExecutionManager
+	taskMgr  *testtask.Manager
+	ctr      Controller
+	proj     *proModels.Project
 }
 
 func (p *proxyControllerTestSuite) SetupTest() {
 	p.local = &localInterfaceMock{}
 	p.remote = &testproxy.RemoteInterface{}
-	p.proj = &proModels.Project{RegistryID: 1}
+	p.artCtl = &testartifact.Controller{}
+	p.quotaCtl = &testquota.Controller{}
+	p.execMgr = &testtask.ExecutionManager{}
+	p.taskMgr = &testtask.Manager{}
+	p.proj = &proModels.Project{ProjectID: 1, Name: "proxy", RegistryID: 1}
 	p.ctr = &controller{
 		blobCtl:     blob.Ctl,
-		artifactCtl: artifact.Ctl,
+		artifactCtl: p.artCtl,
+		quotaCtl:    p.quotaCtl,
+		execMgr:     p.execMgr,
+		taskMgr:     p.taskMgr,
 		local:       p.local,
 	}
 }
@@ -149,6 +170,168 @@ func (p *proxyControllerTestSuite) TestUseLocalBlob_False() {
 	p.Assert().False(result)
 }
 
+func (p *proxyControllerTestSuite) TestStaleManifest() {
+	ctx := context.Background()
+	art := lib.ArtifactInfo{Repository: "library/hello-world", Tag: "latest"}
+	a := &artifact.Artifact{}
+	a.PushTime = time.Now().Add(-2 * time.Hour)
+	p.local.On("GetManifest", mock.Anything, mock.Anything).Return(a, nil)
+
+	// serving the stale manifest isn't enabled
+	result, err := p.ctr.StaleManifest(ctx, p.proj, art)
+	p.Require().Nil(err)
+	p.Nil(result)
+
+	// no stale window
+	p.proj.SetMetadata(proModels.ProMetaProxyCacheServeStale, "true")
+	result, err = p.ctr.StaleManifest(ctx, p.proj, art)
+	p.Require().Nil(err)
+	p.Equal(a, result)
+
+	// pulled by digest
+	result, err = p.ctr.StaleManifest(ctx, p.proj, lib.ArtifactInfo{Repository: "library/hello-world", Digest: "sha256:1a9ec845ee94c202b2d5da74a24f0ed2058318bfa9879fa541efaecba272e86b"})
+	p.Require().Nil(err)
+	p.Nil(result)
+
+	// within the stale window
+	p.proj.SetMetadata(proModels.ProMetaProxyCacheStaleWindow, "180")
+	result, err = p.ctr.StaleManifest(ctx, p.proj, art)
+	p.Require().Nil(err)
+	p.Equal(a, result)
+
+	// out of the stale window
+	p.proj.SetMetadata(proModels.ProMetaProxyCacheStaleWindow, "60")
+	_, err = p.ctr.StaleManifest(ctx, p.proj, art)
+	p.True(errors.IsNotFoundErr(err))
+}
+
+func (p *proxyControllerTestSuite) TestFreshManifest() {
+	ctx := context.Background()
+	art := lib.ArtifactInfo{Repository: "library/hello-world", Tag: "latest"}
+	a := &artifact.Artifact{}
+	a.PushTime = time.Now().Add(-2 * time.Hour)
+	p.local.On("GetManifest", mock.Anything, mock.Anything).Return(a, nil)
+
+	// no tag TTL
+	result, err := p.ctr.FreshManifest(ctx, p.proj, art)
+	p.Require().Nil(err)
+	p.Nil(result)
+
+	// within the tag TTL
+	p.proj.SetMetadata(proModels.ProMetaProxyCacheTagTTL, "180")
+	result, err = p.ctr.FreshManifest(ctx, p.proj, art)
+	p.Require().Nil(err)
+	p.Equal(a, result)
+
+	// out of the tag TTL
+	p.proj.SetMetadata(proModels.ProMetaProxyCacheTagTTL, "60")
+	result, err = p.ctr.FreshManifest(ctx, p.proj, art)
+	p.Require().Nil(err)
+	p.Nil(result)
+}
+
+func (p *proxyControllerTestSuite) TestEvictArtifacts() {
+	ctx := context.Background()
+
+	// the eviction isn't enabled
+	p.Require().Nil(p.ctr.EvictArtifacts(ctx, p.proj))
+
+	p.proj.SetMetadata(proModels.ProMetaProxyCacheEvictThreshold, "90")
+	p.quotaCtl.On("GetByRef", mock.Anything, quota.ProjectReference, "1").Return(&quotaModels.Quota{
+		Hard: `{"storage":100}`,
+		Used: `{"storage":95}`,
+	}, nil)
+	arts := []*artifact.Artifact{{}, {}, {}}
+	for i, art := range arts {
+		art.ID = int64(i + 1)
+		art.Size = 10
+	}
+	p.artCtl.On("List", mock.Anything, mock.Anything, mock.Anything).Return(arts, nil)
+	// the first one is referenced by others
+	p.artCtl.On("Delete", mock.Anything, int64(1)).Return(errors.PreconditionFailedError(nil))
+	p.artCtl.On("Delete", mock.Anything, int64(2)).Return(nil)
+	p.quotaCtl.On("Refresh", mock.Anything, quota.ProjectReference, "1", mock.Anything).Return(nil)
+
+	p.Require().Nil(p.ctr.EvictArtifacts(ctx, p.proj))
+	p.artCtl.AssertNumberOfCalls(p.T(), "Delete", 2)
+	p.quotaCtl.AssertNumberOfCalls(p.T(), "Refresh", 1)
+}
+
+func (p *proxyControllerTestSuite) TestEvictArtifactsInBatches() {
+	ctx := context.Background()
+	originalBatchSize := evictionBatchSize
+	evictionBatchSize = 2
+	defer func() { evictionBatchSize = originalBatchSize }()
+
+	p.proj.SetMetadata(proModels.ProMetaProxyCacheEvictThreshold, "50")
+	p.quotaCtl.On("GetByRef", mock.Anything, quota.ProjectReference, "1").Return(&quotaModels.Quota{
+		Hard: `{"storage":100}`,
+		Used: `{"storage":75}`,
+	}, nil)
+	// the least recently pulled artifacts, the first two are referenced by others
+	var arts []*artifact.Artifact
+	for i := 1; i <= 6; i++ {
+		art := &artifact.Artifact{}
+		art.ID = int64(i)
+		art.Size = 10
+		arts = append(arts, art)
+	}
+	deleted := map[int64]bool{}
+	p.artCtl.On("List", mock.Anything, mock.Anything, mock.Anything).Return(func(ctx context.Context, query *q.Query, option *artifact.Option) []*artifact.Artifact {
+		var remaining []*artifact.Artifact
+		for _, art := range arts {
+			if !deleted[art.ID] {
+				remaining = append(remaining, art)
+			}
+		}
+		start := (query.PageNumber - 1) * query.PageSize
+		if start >= int64(len(remaining)) {
+			return nil
+		}
+		end := start + query.PageSize
+		if end > int64(len(remaining)) {
+			end = int64(len(remaining))
+		}
+		return remaining[start:end]
+	}, nil)
+	p.artCtl.On("Delete", mock.Anything, mock.Anything).Return(func(ctx context.Context, id int64) error {
+		if id <= 2 {
+			return errors.PreconditionFailedError(nil)
+		}
+		deleted[id] = true
+		return nil
+	})
+	p.quotaCtl.On("Refresh", mock.Anything, quota.ProjectReference, "1", mock.Anything).Return(nil)
+
+	p.Require().Nil(p.ctr.EvictArtifacts(ctx, p.proj))
+	// the usage drops below the target after evicting 3 artifacts across the batches
+	p.Equal(map[int64]bool{3: true, 4: true, 5: true}, deleted)
+	p.quotaCtl.AssertNumberOfCalls(p.T(), "Refresh", 1)
+}
+
+func (p *proxyControllerTestSuite) TestPrewarm() {
+	ctx := context.Background()
+
+	// not a proxy cache project
+	_, err := p.ctr.Prewarm(ctx, &proModels.Project{Name: "library"}, []string{"library/hello-world:latest"})
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// invalid reference
+	_, err = p.ctr.Prewarm(ctx, p.proj, []string{"library/hello-world"})
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	p.execMgr.On("Create", mock.Anything, job.ProxyCachePrewarm, int64(1), task.ExecutionTriggerManual, mock.Anything).Return(int64(1), nil)
+	p.taskMgr.On("Create", mock.Anything, int64(1), mock.Anything, mock.Anything).Return(int64(1), nil)
+	id, err := p.ctr.Prewarm(ctx, p.proj, []string{"library/hello-world:latest",
+		"library/alpine@sha256:1a9ec845ee94c202b2d5da74a24f0ed2058318bfa9879fa541efaecba272e86b"})
+	p.Require().Nil(err)
+	p.Equal(int64(1), id)
+	p.taskMgr.AssertNumberOfCalls(p.T(), "Create", 2)
+	j := p.taskMgr.Calls[0].Arguments.Get(2).(*task.Job)
+	p.Equal("proxy/library/hello-world", j.Parameters[proxycache.ParamRepository])
+	p.Equal("latest", j.Parameters[proxycache.ParamReference])
+}
+
 func TestProxyControllerTestSuite(t *testing.T) {
 	suite.Run(t, &proxyControllerTestSuite{})
 }
@@ -200,3 +383,47 @@ func TestGetRef(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReference(t *testing.T) {
+	cases := []struct {
+		name       string
+		in         string
+		repository string
+		reference  string
+		wantErr    bool
+	}{
+		{
+			name:       `tag`,
+			in:         "library/hello-world:latest",
+			repository: "library/hello-world",
+			reference:  "latest",
+		},
+		{
+			name:       `digest`,
+			in:         "library/hello-world@sha256:1a9ec845ee94c202b2d5da74a24f0ed2058318bfa9879fa541efaecba272e86b",
+			repository: "library/hello-world",
+			reference:  "sha256:1a9ec845ee94c202b2d5da74a24f0ed2058318bfa9879fa541efaecba272e86b",
+		},
+		{
+			name:    `no_tag`,
+			in:      "library/hello-world",
+			wantErr: true,
+		},
+		{
+			name:    `invalid_digest`,
+			in:      "library/hello-world@sha256:aabbcc",
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			repository, reference, err := parseReference(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf(`(%v) error = %v; wantErr %v`, tt.in, err, tt.wantErr)
+			}
+			if repository != tt.repository || reference != tt.reference {
+				t.Errorf(`(%v) = %v, %v; want "%v", "%v"`, tt.in, repository, reference, tt.repository, tt.reference)
+			}
+		})
+	}
+}
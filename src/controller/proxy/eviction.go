@@ -0,0 +1,111 @@
+//  Copyright Project Harbor Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+)
+
+// the count of the least recently pulled artifacts listed in one batch of the eviction
+var evictionBatchSize int64 = 100
+
+func (c *controller) EvictArtifacts(ctx context.Context, p *proModels.Project) error {
+	threshold := p.ProxyCacheEvictThreshold()
+	if !p.IsProxy() || threshold == 0 {
+		return nil
+	}
+	// only one eviction runs for a project at the same time
+	key := fmt.Sprintf("eviction:%d", p.ProjectID)
+	if !inflightChecker.addRequest(key) {
+		return nil
+	}
+	defer inflightChecker.removeRequest(key)
+
+	referenceID := quota.ReferenceID(p.ProjectID)
+	qt, err := c.quotaCtl.GetByRef(ctx, quota.ProjectReference, referenceID)
+	if err != nil {
+		return err
+	}
+	hardLimits, err := qt.GetHard()
+	if err != nil {
+		return err
+	}
+	usage, err := qt.GetUsed()
+	if err != nil {
+		return err
+	}
+	limit, ok := hardLimits[types.ResourceStorage]
+	if !ok || limit == types.UNLIMITED {
+		return nil
+	}
+	target := limit * int64(threshold) / 100
+	used := usage[types.ResourceStorage]
+	if used < target {
+		return nil
+	}
+
+	log.Infof("the storage usage %d of proxy cache project %s reaches %d%% of the quota %d, evict the least recently pulled artifacts",
+		used, p.Name, threshold, limit)
+	evicted := 0
+	// the artifacts failed to evict stay at the head of the list, so the list is paged from the
+	// one containing the first artifact that hasn't been checked
+	skipped := map[int64]bool{}
+	for used >= target {
+		query := q.New(q.KeyWords{"ProjectID": p.ProjectID})
+		query.Sorts = []*q.Sort{q.NewSort("pull_time", false), q.NewSort("push_time", false), q.NewSort("id", false)}
+		query.PageNumber = int64(len(skipped))/evictionBatchSize + 1
+		query.PageSize = evictionBatchSize
+		arts, err := c.artifactCtl.List(ctx, query, nil)
+		if err != nil {
+			return err
+		}
+		checked := 0
+		for _, art := range arts {
+			if used < target {
+				break
+			}
+			if skipped[art.ID] {
+				continue
+			}
+			checked++
+			if err = c.artifactCtl.Delete(ctx, art.ID); err != nil {
+				// the artifacts referenced by others are skipped, they are deleted along with their parents
+				log.Debugf("failed to evict the artifact %s@%s: %v", art.RepositoryName, art.Digest, err)
+				skipped[art.ID] = true
+				continue
+			}
+			// the blobs may be shared by other artifacts, so the usage is just estimated here
+			// and the actual usage is calculated by the quota refreshing
+			used -= art.Size
+			evicted++
+			log.Infof("the artifact %s@%s is evicted from proxy cache project %s", art.RepositoryName, art.Digest, p.Name)
+		}
+		// all the artifacts have been checked
+		if checked == 0 {
+			break
+		}
+	}
+	if evicted == 0 {
+		return nil
+	}
+	return c.quotaCtl.Refresh(ctx, quota.ProjectReference, referenceID, quota.IgnoreLimitation(true))
+}
@@ -0,0 +1,99 @@
+//  Copyright Project Harbor Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/proxycache"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	extraAttrTotal     = "total"
+	extraAttrReference = "reference"
+)
+
+func init() {
+	task.SetExecutionSweeperCount(job.ProxyCachePrewarm, 50)
+}
+
+func (c *controller) Prewarm(ctx context.Context, p *proModels.Project, references []string) (int64, error) {
+	if !p.IsProxy() {
+		return 0, errors.BadRequestError(nil).WithMessage("the project %s isn't a proxy cache project", p.Name)
+	}
+	if len(references) == 0 {
+		return 0, errors.BadRequestError(nil).WithMessage("no artifact is specified to pre-warm")
+	}
+	for _, reference := range references {
+		if _, _, err := parseReference(reference); err != nil {
+			return 0, err
+		}
+	}
+
+	id, err := c.execMgr.Create(ctx, job.ProxyCachePrewarm, p.ProjectID, task.ExecutionTriggerManual,
+		map[string]interface{}{extraAttrTotal: len(references)})
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, reference := range references {
+		// ignore the error as it has been validated already
+		repository, ref, _ := parseReference(reference)
+		j := &task.Job{
+			Name: job.ProxyCachePrewarm,
+			Parameters: job.Parameters{
+				proxycache.ParamRepository: p.Name + "/" + repository,
+				proxycache.ParamReference:  ref,
+			},
+			Metadata: &job.Metadata{
+				JobKind: job.KindGeneric,
+			},
+		}
+		if _, err = c.taskMgr.Create(ctx, id, j, map[string]interface{}{extraAttrReference: reference}); err != nil {
+			log.Errorf("failed to create the task to pre-warm %s of project %s: %v", reference, p.Name, err)
+			continue
+		}
+		count++
+	}
+	if count != len(references) {
+		return id, errors.Errorf("failed to create the tasks to pre-warm some artifacts, check the execution %d for details", id)
+	}
+	return id, nil
+}
+
+// parseReference parses the "repository:tag" or "repository@digest" into the repository and the tag or digest
+func parseReference(reference string) (string, string, error) {
+	var repository, ref string
+	if i := strings.LastIndex(reference, "@"); i >= 0 {
+		repository, ref = reference[:i], reference[i+1:]
+		if _, err := digest.Parse(ref); err != nil {
+			return "", "", errors.BadRequestError(nil).WithMessage("invalid digest in the reference %s: %v", reference, err)
+		}
+	} else if i = strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		repository, ref = reference[:i], reference[i+1:]
+	}
+	if len(repository) == 0 || len(ref) == 0 {
+		return "", "", errors.BadRequestError(nil).
+			WithMessage("invalid reference %s, it should be in the format of \"repository:tag\" or \"repository@digest\"", reference)
+	}
+	return repository, ref, nil
+}
@@ -0,0 +1,197 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/purge"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+func init() {
+	// keep only the latest created 50 purge execution records
+	task.SetExecutionSweeperCount(VendorType, 50)
+}
+
+var (
+	// Ctl is a global audit log purge controller instance
+	Ctl = NewController()
+)
+
+const (
+	// SchedulerCallback ...
+	SchedulerCallback = "PURGE_AUDIT_LOG"
+	// VendorType ...
+	VendorType = "PURGE_AUDIT_LOG"
+)
+
+// Controller manages the audit log purge jobs
+type Controller interface {
+	// Start a purge job
+	Start(ctx context.Context, policy Policy, trigger string) (int64, error)
+	// Stop the purge job
+	Stop(ctx context.Context, id int64) error
+
+	// ExecutionCount returns the total count of executions according to the query
+	ExecutionCount(ctx context.Context, query *q.Query) (count int64, err error)
+	// ListExecutions lists the executions according to the query
+	ListExecutions(ctx context.Context, query *q.Query) (executions []*task.Execution, err error)
+	// GetExecution gets the specific execution
+	GetExecution(ctx context.Context, executionID int64) (execution *task.Execution, err error)
+
+	// ListTasks lists the tasks according to the query
+	ListTasks(ctx context.Context, query *q.Query) (tasks []*task.Task, err error)
+	// GetTaskLog gets log of the specific task
+	GetTaskLog(ctx context.Context, id int64) ([]byte, error)
+
+	// GetSchedule get the current purge schedule
+	GetSchedule(ctx context.Context) (*scheduler.Schedule, error)
+	// CreateSchedule create the purge schedule with cron type & string
+	CreateSchedule(ctx context.Context, cronType, cron string, policy Policy) (int64, error)
+	// DeleteSchedule remove the purge schedule
+	DeleteSchedule(ctx context.Context) error
+}
+
+// NewController creates an instance of the default audit log purge controller
+func NewController() Controller {
+	return &controller{
+		taskMgr:      task.NewManager(),
+		exeMgr:       task.NewExecutionManager(),
+		schedulerMgr: scheduler.New(),
+	}
+}
+
+type controller struct {
+	taskMgr      task.Manager
+	exeMgr       task.ExecutionManager
+	schedulerMgr scheduler.Scheduler
+}
+
+// Start the purge job
+func (c *controller) Start(ctx context.Context, policy Policy, trigger string) (int64, error) {
+	if policy.RetentionHour <= 0 {
+		return -1, errors.BadRequestError(nil).WithMessage("the audit log retention hour must be greater than 0")
+	}
+	para := make(map[string]interface{})
+	para[purge.ParamRetentionHour] = policy.RetentionHour
+	para[purge.ParamIncludeOperations] = policy.IncludeOperations
+	para[purge.ParamDryRun] = policy.DryRun
+
+	execID, err := c.exeMgr.Create(ctx, VendorType, -1, trigger, para)
+	if err != nil {
+		return -1, err
+	}
+	_, err = c.taskMgr.Create(ctx, execID, &task.Job{
+		Name: job.PurgeAudit,
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+		Parameters: para,
+	})
+	if err != nil {
+		return -1, err
+	}
+	return execID, nil
+}
+
+// Stop ...
+func (c *controller) Stop(ctx context.Context, id int64) error {
+	if _, err := c.GetExecution(ctx, id); err != nil {
+		return err
+	}
+	return c.exeMgr.Stop(ctx, id)
+}
+
+// ExecutionCount ...
+func (c *controller) ExecutionCount(ctx context.Context, query *q.Query) (int64, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = VendorType
+	return c.exeMgr.Count(ctx, query)
+}
+
+// ListExecutions ...
+func (c *controller) ListExecutions(ctx context.Context, query *q.Query) ([]*task.Execution, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = VendorType
+	return c.exeMgr.List(ctx, query)
+}
+
+// GetExecution ...
+func (c *controller) GetExecution(ctx context.Context, id int64) (*task.Execution, error) {
+	execs, err := c.exeMgr.List(ctx, q.New(q.KeyWords{
+		"ID":         id,
+		"VendorType": VendorType,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if len(execs) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessage("purge audit log execution %d not found", id)
+	}
+	return execs[0], nil
+}
+
+// ListTasks ...
+func (c *controller) ListTasks(ctx context.Context, query *q.Query) ([]*task.Task, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = VendorType
+	return c.taskMgr.List(ctx, query)
+}
+
+// GetTaskLog ...
+func (c *controller) GetTaskLog(ctx context.Context, id int64) ([]byte, error) {
+	tasks, err := c.ListTasks(ctx, q.New(q.KeyWords{"ID": id}))
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessage("purge audit log task %d not found", id)
+	}
+	return c.taskMgr.GetLog(ctx, id)
+}
+
+// GetSchedule ...
+func (c *controller) GetSchedule(ctx context.Context) (*scheduler.Schedule, error) {
+	sch, err := c.schedulerMgr.ListSchedules(ctx, q.New(q.KeyWords{"VendorType": VendorType}))
+	if err != nil {
+		return nil, err
+	}
+	if len(sch) == 0 || sch[0] == nil {
+		return nil, errors.NotFoundError(nil).WithMessage("no purge audit log schedule is found")
+	}
+	return sch[0], nil
+}
+
+// CreateSchedule ...
+func (c *controller) CreateSchedule(ctx context.Context, cronType, cron string, policy Policy) (int64, error) {
+	if policy.RetentionHour <= 0 {
+		return -1, errors.BadRequestError(nil).WithMessage("the audit log retention hour must be greater than 0")
+	}
+	extras := make(map[string]interface{})
+	extras[purge.ParamRetentionHour] = policy.RetentionHour
+	extras[purge.ParamIncludeOperations] = policy.IncludeOperations
+	extras[purge.ParamDryRun] = policy.DryRun
+	return c.schedulerMgr.Schedule(ctx, VendorType, -1, cronType, cron, SchedulerCallback, policy, extras)
+}
+
+// DeleteSchedule ...
+func (c *controller) DeleteSchedule(ctx context.Context) error {
+	return c.schedulerMgr.UnScheduleByVendor(ctx, VendorType, -1)
+}
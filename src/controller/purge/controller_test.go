@@ -0,0 +1,126 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/purge"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/testing/mock"
+	schedulertesting "github.com/goharbor/harbor/src/testing/pkg/scheduler"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type purgeCtrTestSuite struct {
+	suite.Suite
+	scheduler *schedulertesting.Scheduler
+	execMgr   *tasktesting.ExecutionManager
+	taskMgr   *tasktesting.Manager
+	ctl       *controller
+}
+
+func (p *purgeCtrTestSuite) SetupTest() {
+	p.execMgr = &tasktesting.ExecutionManager{}
+	p.taskMgr = &tasktesting.Manager{}
+	p.scheduler = &schedulertesting.Scheduler{}
+	p.ctl = &controller{
+		taskMgr:      p.taskMgr,
+		exeMgr:       p.execMgr,
+		schedulerMgr: p.scheduler,
+	}
+}
+
+func (p *purgeCtrTestSuite) TestStart() {
+	// invalid retention hour
+	_, err := p.ctl.Start(nil, Policy{}, task.ExecutionTriggerManual)
+	p.True(errors.IsErr(err, errors.BadRequestCode))
+
+	params := map[string]interface{}{
+		purge.ParamRetentionHour:     24,
+		purge.ParamIncludeOperations: "pull",
+		purge.ParamDryRun:            true,
+	}
+	p.execMgr.On("Create", mock.Anything, VendorType, int64(-1), task.ExecutionTriggerManual, params).Return(int64(1), nil)
+	p.taskMgr.On("Create", mock.Anything, int64(1), testifymock.MatchedBy(func(j *task.Job) bool {
+		return j.Name == job.PurgeAudit
+	})).Return(int64(1), nil)
+
+	id, err := p.ctl.Start(nil, Policy{
+		RetentionHour:     24,
+		IncludeOperations: "pull",
+		DryRun:            true,
+	}, task.ExecutionTriggerManual)
+	p.Require().Nil(err)
+	p.Equal(int64(1), id)
+	p.execMgr.AssertExpectations(p.T())
+	p.taskMgr.AssertExpectations(p.T())
+}
+
+func (p *purgeCtrTestSuite) TestGetExecution() {
+	p.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{
+		{
+			ID:         1,
+			Trigger:    "Manual",
+			VendorType: VendorType,
+		},
+	}, nil)
+	exec, err := p.ctl.GetExecution(nil, 1)
+	p.Require().Nil(err)
+	p.Equal("Manual", exec.Trigger)
+}
+
+func (p *purgeCtrTestSuite) TestGetTaskLog() {
+	p.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{
+		{
+			ID:          1,
+			ExecutionID: 1,
+			Status:      job.SuccessStatus.String(),
+		},
+	}, nil)
+	p.taskMgr.On("GetLog", mock.Anything, int64(1)).Return([]byte("hello world"), nil)
+
+	log, err := p.ctl.GetTaskLog(nil, 1)
+	p.Require().Nil(err)
+	p.Equal([]byte("hello world"), log)
+}
+
+func (p *purgeCtrTestSuite) TestGetSchedule() {
+	p.scheduler.On("ListSchedules", mock.Anything, mock.Anything).Return([]*scheduler.Schedule{}, nil)
+	_, err := p.ctl.GetSchedule(nil)
+	p.True(errors.IsNotFoundErr(err))
+}
+
+func (p *purgeCtrTestSuite) TestCreateSchedule() {
+	p.scheduler.On("Schedule", mock.Anything, VendorType, int64(-1), "Daily", "0 0 0 * * *",
+		SchedulerCallback, mock.Anything, mock.Anything).Return(int64(1), nil)
+	id, err := p.ctl.CreateSchedule(nil, "Daily", "0 0 0 * * *", Policy{RetentionHour: 24})
+	p.Require().Nil(err)
+	p.Equal(int64(1), id)
+}
+
+func (p *purgeCtrTestSuite) TestDeleteSchedule() {
+	p.scheduler.On("UnScheduleByVendor", mock.Anything, VendorType, int64(-1)).Return(nil)
+	p.Nil(p.ctl.DeleteSchedule(nil))
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &purgeCtrTestSuite{})
+}
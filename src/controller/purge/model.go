@@ -0,0 +1,25 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+// Policy of the audit log purge job
+type Policy struct {
+	// RetentionHour is the hours the audit logs are retained
+	RetentionHour int `json:"audit_retention_hour"`
+	// IncludeOperations is the comma separated operations whose audit logs are purged, all the operations if empty
+	IncludeOperations string                 `json:"include_operations"`
+	DryRun            bool                   `json:"dry_run"`
+	ExtraAttrs        map[string]interface{} `json:"extra_attrs"`
+}
@@ -224,7 +224,19 @@ func (c *controller) Refresh(ctx context.Context, reference, referenceID string,
 		return newUsed, err
 	}
 
-	return c.updateUsageWithRetry(ctx, reference, referenceID, refreshResources(calculateUsage, opts.IgnoreLimitation))
+	if err := c.updateUsageWithRetry(ctx, reference, referenceID, refreshResources(calculateUsage, opts.IgnoreLimitation)); err != nil {
+		return err
+	}
+
+	// the usage of the aggregators always follows the usage of the aggregated object,
+	// so the limitation is ignored when refreshing them
+	for _, ref := range c.aggregators(ctx, reference, referenceID) {
+		if err := c.Refresh(ctx, ref.reference, ref.referenceID, IgnoreLimitation(true)); err != nil {
+			log.G(ctx).Warningf("refresh quota usage for %s %s failed, error: %v", ref.reference, ref.referenceID, err)
+		}
+	}
+
+	return nil
 }
 
 func (c *controller) Request(ctx context.Context, reference, referenceID string, resources types.ResourceList, f func() error) error {
@@ -232,23 +244,69 @@ func (c *controller) Request(ctx context.Context, reference, referenceID string,
 		return f()
 	}
 
-	if err := c.updateUsageWithRetry(ctx, reference, referenceID, reserveResources(resources)); err != nil {
-		log.G(ctx).Errorf("reserve resources %s for %s %s failed, error: %v", resources.String(), reference, referenceID, err)
-		return err
+	// the resources are reserved in the quotas of the aggregators as well, e.g. the user groups own the project
+	refs := append([]ref{{reference: reference, referenceID: referenceID}}, c.aggregators(ctx, reference, referenceID)...)
+
+	rollback := func(reserved []ref) {
+		for _, r := range reserved {
+			if err := c.updateUsageWithRetry(ctx, r.reference, r.referenceID, rollbackResources(resources)); err != nil {
+				// ignore this error, the quota usage will be correct when users do operations which will call refresh quota
+				log.G(ctx).Warningf("rollback resources %s for %s %s failed, error: %v", resources.String(), r.reference, r.referenceID, err)
+			}
+		}
+	}
+
+	for i, r := range refs {
+		if err := c.updateUsageWithRetry(ctx, r.reference, r.referenceID, reserveResources(resources)); err != nil {
+			log.G(ctx).Errorf("reserve resources %s for %s %s failed, error: %v", resources.String(), r.reference, r.referenceID, err)
+			rollback(refs[:i])
+			return err
+		}
 	}
 
 	err := f()
 
 	if err != nil {
-		if er := c.updateUsageWithRetry(ctx, reference, referenceID, rollbackResources(resources)); er != nil {
-			// ignore this error, the quota usage will be correct when users do operations which will call refresh quota
-			log.G(ctx).Warningf("rollback resources %s for %s %s failed, error: %v", resources.String(), reference, referenceID, er)
-		}
+		rollback(refs)
 	}
 
 	return err
 }
 
+type ref struct {
+	reference   string
+	referenceID string
+}
+
+// aggregators returns the reference objects which aggregate the reference object and have quotas
+func (c *controller) aggregators(ctx context.Context, reference, referenceID string) []ref {
+	var refs []ref
+	for name, d := range driver.List() {
+		aggregator, ok := d.(driver.Aggregator)
+		if !ok || aggregator.AggregatedReference() != reference {
+			continue
+		}
+
+		keys, err := aggregator.ListAggregators(ctx, referenceID)
+		if err != nil {
+			log.G(ctx).Warningf("list the %s aggregating %s %s failed, error: %v", name, reference, referenceID, err)
+			continue
+		}
+
+		for _, key := range keys {
+			if _, err := c.quotaMgr.GetByRef(ctx, name, key); err != nil {
+				if !errors.IsNotFoundErr(err) {
+					log.G(ctx).Warningf("get quota for %s %s failed, error: %v", name, key, err)
+				}
+				continue
+			}
+			refs = append(refs, ref{reference: name, referenceID: key})
+		}
+	}
+
+	return refs
+}
+
 func (c *controller) Update(ctx context.Context, u *quota.Quota) error {
 	f := func() error {
 		q, err := c.quotaMgr.GetByRef(ctx, u.Reference, u.ReferenceID)
@@ -302,6 +360,10 @@ func Validate(ctx context.Context, reference string, hardLimits types.ResourceLi
 func reserveResources(resources types.ResourceList) func(hardLimits, used types.ResourceList) (types.ResourceList, error) {
 	return func(hardLimits, used types.ResourceList) (types.ResourceList, error) {
 		newUsed := types.Add(used, resources)
+		// the resources released may make the usage negative when it's out of sync
+		if negativeUsed := types.IsNegative(newUsed); len(negativeUsed) > 0 {
+			return nil, fmt.Errorf("quota usage is negative for resource(s): %s", quota.PrettyPrintResourceNames(negativeUsed))
+		}
 
 		if err := quota.IsSafe(hardLimits, used, newUsed, false); err != nil {
 			return nil, errors.DeniedError(err).WithMessage("Quota exceeded when processing the request of %v", err)
@@ -142,6 +142,53 @@ func (suite *ControllerTestSuite) TestRequestFunctionFailed() {
 	suite.Error(suite.ctl.Request(ctx, suite.reference, referenceID, resources, func() error { return fmt.Errorf("error") }))
 }
 
+type aggregatorDriver struct {
+	*drivertesting.Driver
+	aggregatedReference string
+	aggregators         []string
+}
+
+func (d *aggregatorDriver) AggregatedReference() string {
+	return d.aggregatedReference
+}
+
+func (d *aggregatorDriver) ListAggregated(ctx context.Context, key string) ([]string, error) {
+	return nil, nil
+}
+
+func (d *aggregatorDriver) ListAggregators(ctx context.Context, aggregatedKey string) ([]string, error) {
+	return d.aggregators, nil
+}
+
+func (suite *ControllerTestSuite) TestRequestAggregated() {
+	reference := "mock-aggregated"
+	driver.Register(reference, &drivertesting.Driver{})
+	driver.Register("mock-aggregator", &aggregatorDriver{
+		Driver:              &drivertesting.Driver{},
+		aggregatedReference: reference,
+		aggregators:         []string{"1"},
+	})
+
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	referenceID := uuid.New().String()
+
+	aggregatorQuota := &quota.Quota{
+		Hard: types.ResourceList{types.ResourceStorage: 50}.String(),
+		Used: types.ResourceList{types.ResourceStorage: 0}.String(),
+	}
+	suite.quotaMgr.On("GetByRef", mock.Anything, reference, referenceID).Return(suite.quota, nil)
+	suite.quotaMgr.On("GetByRef", mock.Anything, "mock-aggregator", "1").Return(aggregatorQuota, nil)
+	mock.OnAnything(suite.quotaMgr, "Update").Return(nil)
+
+	// the request exceeds the quota of the aggregator
+	resources := types.ResourceList{types.ResourceStorage: 60}
+	suite.Error(suite.ctl.Request(ctx, reference, referenceID, resources, func() error { return nil }))
+
+	resources = types.ResourceList{types.ResourceStorage: 40}
+	suite.Nil(suite.ctl.Request(ctx, reference, referenceID, resources, func() error { return nil }))
+	suite.quotaMgr.AssertCalled(suite.T(), "GetByRef", mock.Anything, "mock-aggregator", "1")
+}
+
 func TestControllerTestSuite(t *testing.T) {
 	suite.Run(t, &ControllerTestSuite{})
 }
@@ -15,6 +15,8 @@
 package driver
 
 import (
+	// user group quota driver
+	_ "github.com/goharbor/harbor/src/controller/quota/driver/group"
 	// project quota driver
 	_ "github.com/goharbor/harbor/src/controller/quota/driver/project"
 )
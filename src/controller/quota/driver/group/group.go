@@ -0,0 +1,197 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/config/db"
+	"github.com/goharbor/harbor/src/pkg/member"
+	"github.com/goharbor/harbor/src/pkg/member/models"
+	"github.com/goharbor/harbor/src/pkg/project"
+	dr "github.com/goharbor/harbor/src/pkg/quota/driver"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+	"github.com/goharbor/harbor/src/pkg/usergroup"
+)
+
+const (
+	// the reference of the projects aggregated by the user group
+	projectReference = "project"
+)
+
+func init() {
+	dr.Register("group", newDriver())
+}
+
+// driver is the quota driver of the user group, the usage of the user group aggregates the
+// usage of all the projects owned by it, which are the projects the group is the project admin of
+type driver struct {
+	cfg          config.Manager
+	projectMgr   project.Manager
+	memberMgr    member.Manager
+	userGroupMgr usergroup.Manager
+	// projectDriver returns the quota driver of the aggregated projects,
+	// it's looked up lazily as the drivers are registered in the init functions
+	projectDriver func() (dr.Driver, bool)
+}
+
+func (d *driver) Enabled(ctx context.Context, key string) (bool, error) {
+	// NOTE: every time load the new configurations from the db to get the latest configurations may have performance problem.
+	if err := d.cfg.Load(ctx); err != nil {
+		return false, err
+	}
+	return d.cfg.Get(ctx, common.QuotaPerProjectEnable).GetBool(), nil
+}
+
+// HardLimits returns the unlimited resources as the quota of the user group is set by the system admin explicitly
+func (d *driver) HardLimits(ctx context.Context) types.ResourceList {
+	return types.ResourceList{
+		types.ResourceStorage:    types.UNLIMITED,
+		types.ResourceCount:      types.UNLIMITED,
+		types.ResourceRepository: types.UNLIMITED,
+	}
+}
+
+func (d *driver) Load(ctx context.Context, key string) (dr.RefObject, error) {
+	id, err := strconv.Atoi(key)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := d.userGroupMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, errors.NotFoundError(nil).WithMessage("user group %d not found", id)
+	}
+
+	return dr.RefObject{
+		"id":   group.ID,
+		"name": group.GroupName,
+	}, nil
+}
+
+func (d *driver) Validate(hardLimits types.ResourceList) error {
+	resources := map[types.ResourceName]bool{
+		types.ResourceStorage:    true,
+		types.ResourceCount:      true,
+		types.ResourceRepository: true,
+	}
+
+	for resource, value := range hardLimits {
+		if !resources[resource] {
+			return fmt.Errorf("resource %s not support", resource)
+		}
+
+		if value <= 0 && value != types.UNLIMITED {
+			return fmt.Errorf("invalid value for resource %s", resource)
+		}
+	}
+
+	for resource := range resources {
+		if _, found := hardLimits[resource]; !found {
+			return fmt.Errorf("resource %s not found", resource)
+		}
+	}
+
+	return nil
+}
+
+// CalculateUsage sums the usage of the projects owned by the user group
+func (d *driver) CalculateUsage(ctx context.Context, key string) (types.ResourceList, error) {
+	projectDriver, ok := d.projectDriver()
+	if !ok {
+		return nil, fmt.Errorf("quota not support for %s", projectReference)
+	}
+
+	projectIDs, err := d.ListAggregated(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	used := types.Zero(d.HardLimits(ctx))
+	for _, projectID := range projectIDs {
+		usage, err := projectDriver.CalculateUsage(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		used = types.Add(used, usage)
+	}
+
+	return used, nil
+}
+
+func (d *driver) AggregatedReference() string {
+	return projectReference
+}
+
+// ListAggregated returns the IDs of the projects owned by the user group
+func (d *driver) ListAggregated(ctx context.Context, key string) ([]string, error) {
+	groupID, err := strconv.Atoi(key)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := d.projectMgr.List(ctx, q.New(q.KeyWords{"admin_group": groupID}))
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, p := range projects {
+		keys = append(keys, strconv.FormatInt(p.ProjectID, 10))
+	}
+	return keys, nil
+}
+
+// ListAggregators returns the IDs of the user groups which own the project
+func (d *driver) ListAggregators(ctx context.Context, aggregatedKey string) ([]string, error) {
+	projectID, err := strconv.ParseInt(aggregatedKey, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := d.memberMgr.List(ctx, models.Member{ProjectID: projectID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, m := range members {
+		if m.EntityType == common.GroupMember && m.Role == common.RoleProjectAdmin {
+			keys = append(keys, strconv.Itoa(m.EntityID))
+		}
+	}
+	return keys, nil
+}
+
+func newDriver() dr.Driver {
+	return &driver{
+		cfg:          db.NewDBCfgManager(),
+		projectMgr:   project.Mgr,
+		memberMgr:    member.Mgr,
+		userGroupMgr: usergroup.Mgr,
+		projectDriver: func() (dr.Driver, bool) {
+			return dr.Get(projectReference)
+		},
+	}
+}
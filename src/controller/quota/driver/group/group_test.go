@@ -0,0 +1,122 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/member"
+	"github.com/goharbor/harbor/src/pkg/member/models"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	dr "github.com/goharbor/harbor/src/pkg/quota/driver"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+	"github.com/goharbor/harbor/src/testing/mock"
+	projecttesting "github.com/goharbor/harbor/src/testing/pkg/project"
+	drivertesting "github.com/goharbor/harbor/src/testing/pkg/quota/driver"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeMemberManager struct {
+	member.Manager
+	members []*models.Member
+}
+
+func (f *fakeMemberManager) List(ctx context.Context, queryMember models.Member, query *q.Query) ([]*models.Member, error) {
+	return f.members, nil
+}
+
+type DriverTestSuite struct {
+	suite.Suite
+
+	projectMgr    *projecttesting.Manager
+	memberMgr     *fakeMemberManager
+	projectDriver *drivertesting.Driver
+
+	d *driver
+}
+
+func (suite *DriverTestSuite) SetupTest() {
+	suite.projectMgr = &projecttesting.Manager{}
+	suite.memberMgr = &fakeMemberManager{}
+	suite.projectDriver = &drivertesting.Driver{}
+
+	suite.d = &driver{
+		projectMgr: suite.projectMgr,
+		memberMgr:  suite.memberMgr,
+		projectDriver: func() (dr.Driver, bool) {
+			return suite.projectDriver, true
+		},
+	}
+}
+
+func (suite *DriverTestSuite) TestCalculateUsage() {
+	mock.OnAnything(suite.projectMgr, "List").Return([]*proModels.Project{{ProjectID: 1}, {ProjectID: 2}}, nil).Once()
+	suite.projectDriver.On("CalculateUsage", mock.Anything, "1").Return(types.ResourceList{
+		types.ResourceStorage:    1000,
+		types.ResourceCount:      10,
+		types.ResourceRepository: 2,
+	}, nil).Once()
+	suite.projectDriver.On("CalculateUsage", mock.Anything, "2").Return(types.ResourceList{
+		types.ResourceStorage:    500,
+		types.ResourceCount:      5,
+		types.ResourceRepository: 1,
+	}, nil).Once()
+
+	resources, err := suite.d.CalculateUsage(context.TODO(), "3")
+	if suite.Nil(err) {
+		suite.Len(resources, 3)
+		suite.Equal(int64(1500), resources[types.ResourceStorage])
+		suite.Equal(int64(15), resources[types.ResourceCount])
+		suite.Equal(int64(3), resources[types.ResourceRepository])
+	}
+	suite.projectDriver.AssertExpectations(suite.T())
+}
+
+func (suite *DriverTestSuite) TestListAggregators() {
+	suite.memberMgr.members = []*models.Member{
+		{EntityID: 1, EntityType: common.GroupMember, Role: common.RoleProjectAdmin},
+		{EntityID: 2, EntityType: common.GroupMember, Role: common.RoleDeveloper},
+		{EntityID: 3, EntityType: common.UserMember, Role: common.RoleProjectAdmin},
+	}
+
+	keys, err := suite.d.ListAggregators(context.TODO(), "1")
+	if suite.Nil(err) {
+		suite.Equal([]string{"1"}, keys)
+	}
+
+	_, err = suite.d.ListAggregators(context.TODO(), "library")
+	suite.NotNil(err)
+}
+
+func (suite *DriverTestSuite) TestValidate() {
+	suite.Nil(suite.d.Validate(types.ResourceList{
+		types.ResourceStorage:    types.UNLIMITED,
+		types.ResourceCount:      100,
+		types.ResourceRepository: 10,
+	}))
+	suite.NotNil(suite.d.Validate(types.ResourceList{types.ResourceStorage: types.UNLIMITED}))
+	suite.NotNil(suite.d.Validate(types.ResourceList{
+		types.ResourceStorage:    types.UNLIMITED,
+		types.ResourceCount:      0,
+		types.ResourceRepository: 10,
+	}))
+}
+
+func TestDriverTestSuite(t *testing.T) {
+	suite.Run(t, &DriverTestSuite{})
+}
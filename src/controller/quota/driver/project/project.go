@@ -23,8 +23,11 @@ import (
 	"strconv"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/blob"
+	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
 	dr "github.com/goharbor/harbor/src/pkg/quota/driver"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 	"github.com/graph-gophers/dataloader"
@@ -38,7 +41,9 @@ type driver struct {
 	cfg    config.Manager
 	loader *dataloader.Loader
 
-	blobCtl blob.Controller
+	artifactCtl   artifact.Controller
+	blobCtl       blob.Controller
+	repositoryCtl repository.Controller
 }
 
 func (d *driver) Enabled(ctx context.Context, key string) (bool, error) {
@@ -56,7 +61,9 @@ func (d *driver) HardLimits(ctx context.Context) types.ResourceList {
 	}
 
 	return types.ResourceList{
-		types.ResourceStorage: d.cfg.Get(ctx, common.StoragePerProject).GetInt64(),
+		types.ResourceStorage:    d.cfg.Get(ctx, common.StoragePerProject).GetInt64(),
+		types.ResourceCount:      d.cfg.Get(ctx, common.CountPerProject).GetInt64(),
+		types.ResourceRepository: d.cfg.Get(ctx, common.RepositoryPerProject).GetInt64(),
 	}
 }
 
@@ -82,7 +89,9 @@ func (d *driver) Load(ctx context.Context, key string) (dr.RefObject, error) {
 
 func (d *driver) Validate(hardLimits types.ResourceList) error {
 	resources := map[types.ResourceName]bool{
-		types.ResourceStorage: true,
+		types.ResourceStorage:    true,
+		types.ResourceCount:      true,
+		types.ResourceRepository: true,
 	}
 
 	for resource, value := range hardLimits {
@@ -115,7 +124,23 @@ func (d *driver) CalculateUsage(ctx context.Context, key string) (types.Resource
 		return nil, err
 	}
 
-	return types.ResourceList{types.ResourceStorage: size}, nil
+	// all the artifacts are counted, including the untagged children of the indexes and the accessories,
+	// as every manifest pushed is counted when requesting the quota
+	count, err := d.artifactCtl.Count(ctx, q.New(q.KeyWords{"ProjectID": projectID, "base": "*"}))
+	if err != nil {
+		return nil, err
+	}
+
+	repositories, err := d.repositoryCtl.Count(ctx, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return nil, err
+	}
+
+	return types.ResourceList{
+		types.ResourceStorage:    size,
+		types.ResourceCount:      count,
+		types.ResourceRepository: repositories,
+	}, nil
 }
 
 func newDriver() dr.Driver {
@@ -124,8 +149,10 @@ func newDriver() dr.Driver {
 	loader := dataloader.NewBatchedLoader(getProjectsBatchFn, dataloader.WithClearCacheOnBatch())
 
 	return &driver{
-		cfg:     cfg,
-		loader:  loader,
-		blobCtl: blob.Ctl,
+		cfg:           cfg,
+		loader:        loader,
+		artifactCtl:   artifact.Ctl,
+		blobCtl:       blob.Ctl,
+		repositoryCtl: repository.Ctl,
 	}
 }
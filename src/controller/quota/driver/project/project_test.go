@@ -18,9 +18,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
 	blobtesting "github.com/goharbor/harbor/src/testing/controller/blob"
+	repositorytesting "github.com/goharbor/harbor/src/testing/controller/repository"
 	"github.com/goharbor/harbor/src/testing/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -28,8 +30,9 @@ import (
 type DriverTestSuite struct {
 	suite.Suite
 
-	artifactCtl *artifacttesting.Controller
-	blobCtl     *blobtesting.Controller
+	artifactCtl   *artifacttesting.Controller
+	blobCtl       *blobtesting.Controller
+	repositoryCtl *repositorytesting.Controller
 
 	d *driver
 }
@@ -37,9 +40,12 @@ type DriverTestSuite struct {
 func (suite *DriverTestSuite) SetupTest() {
 	suite.artifactCtl = &artifacttesting.Controller{}
 	suite.blobCtl = &blobtesting.Controller{}
+	suite.repositoryCtl = &repositorytesting.Controller{}
 
 	suite.d = &driver{
-		blobCtl: suite.blobCtl,
+		artifactCtl:   suite.artifactCtl,
+		blobCtl:       suite.blobCtl,
+		repositoryCtl: suite.repositoryCtl,
 	}
 }
 
@@ -47,15 +53,38 @@ func (suite *DriverTestSuite) TestCalculateUsage() {
 
 	{
 		mock.OnAnything(suite.blobCtl, "CalculateTotalSizeByProject").Return(int64(1000), nil).Once()
+		mock.OnAnything(suite.artifactCtl, "Count").Return(int64(10), nil).Once()
+		mock.OnAnything(suite.repositoryCtl, "Count").Return(int64(2), nil).Once()
 
 		resources, err := suite.d.CalculateUsage(context.TODO(), "1")
 		if suite.Nil(err) {
-			suite.Len(resources, 1)
+			suite.Len(resources, 3)
 			suite.Equal(resources[types.ResourceStorage], int64(1000))
+			suite.Equal(resources[types.ResourceCount], int64(10))
+			suite.Equal(resources[types.ResourceRepository], int64(2))
 		}
+		// the untagged children of the indexes and the accessories are counted
+		query := suite.artifactCtl.Calls[0].Arguments.Get(1).(*q.Query)
+		suite.Equal("*", query.Keywords["base"])
 	}
 }
 
+func (suite *DriverTestSuite) TestValidate() {
+	suite.Nil(suite.d.Validate(types.ResourceList{
+		types.ResourceStorage:    types.UNLIMITED,
+		types.ResourceCount:      100,
+		types.ResourceRepository: 10,
+	}))
+	// the resource is missing
+	suite.NotNil(suite.d.Validate(types.ResourceList{types.ResourceStorage: types.UNLIMITED}))
+	// the value is invalid
+	suite.NotNil(suite.d.Validate(types.ResourceList{
+		types.ResourceStorage:    types.UNLIMITED,
+		types.ResourceCount:      0,
+		types.ResourceRepository: 10,
+	}))
+}
+
 func TestDriverTestSuite(t *testing.T) {
 	suite.Run(t, &DriverTestSuite{})
 }
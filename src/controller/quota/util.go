@@ -22,11 +22,15 @@ import (
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/quota/driver"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
 )
 
 const (
 	// ProjectReference reference type for project
 	ProjectReference = "project"
+	// GroupReference reference type for user group
+	GroupReference = "group"
 )
 
 // ReferenceID returns reference id for the interface
@@ -82,3 +86,91 @@ func RefreshForProjects(ctx context.Context) error {
 
 	return nil
 }
+
+// UsageBreakdown returns the usages of the objects aggregated by the reference object, keyed by the reference ids of them,
+// the objects without quota are skipped
+func UsageBreakdown(ctx context.Context, reference, referenceID string) (map[string]types.ResourceList, error) {
+	d, err := Driver(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregator, ok := d.(driver.Aggregator)
+	if !ok {
+		return nil, errors.BadRequestError(nil).WithMessage("quota of %s doesn't aggregate others", reference)
+	}
+
+	keys, err := aggregator.ListAggregated(ctx, referenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := map[string]types.ResourceList{}
+	for _, key := range keys {
+		q, err := Ctl.GetByRef(ctx, aggregator.AggregatedReference(), key)
+		if errors.IsNotFoundErr(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		used, err := q.GetUsed()
+		if err != nil {
+			return nil, err
+		}
+		usages[key] = used
+	}
+
+	return usages, nil
+}
+
+// GroupsOfProject returns the reference ids of the user groups which own the project and have quotas
+func GroupsOfProject(ctx context.Context, projectID int64) ([]string, error) {
+	d, err := Driver(ctx, GroupReference)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregator, ok := d.(driver.Aggregator)
+	if !ok {
+		return nil, fmt.Errorf("quota of %s doesn't aggregate others", GroupReference)
+	}
+
+	keys, err := aggregator.ListAggregators(ctx, ReferenceID(projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []string
+	for _, key := range keys {
+		_, err := Ctl.GetByRef(ctx, GroupReference, key)
+		if errors.IsNotFoundErr(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		groups = append(groups, key)
+	}
+
+	return groups, nil
+}
+
+// RefreshForGroups refresh quotas of the user groups, the user groups without quota are skipped
+func RefreshForGroups(ctx context.Context, referenceIDs ...string) error {
+	for _, referenceID := range referenceIDs {
+		_, err := Ctl.GetByRef(ctx, GroupReference, referenceID)
+		if errors.IsNotFoundErr(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		// the usage of the user group always follows the usage of the projects owned by it,
+		// so the limitation is ignored when refreshing it
+		if err := Ctl.Refresh(ctx, GroupReference, referenceID, IgnoreLimitation(true)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
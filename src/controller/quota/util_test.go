@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/project/models"
@@ -44,14 +45,18 @@ type RefreshForProjectsTestSuite struct {
 	originalQuotaCtl Controller
 	quotaMgr         quota.Manager
 
-	originalDriver driver.Driver
-	driver         *drivertesting.Driver
+	originalDriver      driver.Driver
+	driver              *drivertesting.Driver
+	originalGroupDriver driver.Driver
 }
 
 func (suite *RefreshForProjectsTestSuite) SetupTest() {
 	suite.originalDriver, _ = Driver(context.TODO(), ProjectReference)
 	suite.driver = &drivertesting.Driver{}
 	driver.Register(ProjectReference, suite.driver)
+	// the user groups aggregating the projects are out of the scope
+	suite.originalGroupDriver, _ = Driver(context.TODO(), GroupReference)
+	driver.Register(GroupReference, &drivertesting.Driver{})
 
 	suite.originalProjectCtl = project.Ctl
 	suite.projectCtl = &projecttesting.Controller{}
@@ -70,6 +75,7 @@ func (suite *RefreshForProjectsTestSuite) TearDownTest() {
 	Ctl = suite.originalQuotaCtl
 
 	driver.Register(ProjectReference, suite.originalDriver)
+	driver.Register(GroupReference, suite.originalGroupDriver)
 }
 
 func (suite *RefreshForProjectsTestSuite) TestRefreshForProjects() {
@@ -120,3 +126,63 @@ func (suite *RefreshForProjectsTestSuite) TestRefreshForProjects() {
 func TestRefreshForProjectsTestSuite(t *testing.T) {
 	suite.Run(t, &RefreshForProjectsTestSuite{})
 }
+
+type RefreshForGroupsTestSuite struct {
+	suite.Suite
+
+	originalQuotaCtl Controller
+	quotaMgr         *quotatesting.Manager
+
+	originalGroupDriver driver.Driver
+	groupDriver         *drivertesting.Driver
+}
+
+func (suite *RefreshForGroupsTestSuite) SetupTest() {
+	suite.originalGroupDriver, _ = Driver(context.TODO(), GroupReference)
+	suite.groupDriver = &drivertesting.Driver{}
+	driver.Register(GroupReference, &aggregatorDriver{
+		Driver:              suite.groupDriver,
+		aggregatedReference: ProjectReference,
+		aggregators:         []string{"1", "2"},
+	})
+
+	suite.originalQuotaCtl = Ctl
+	suite.quotaMgr = &quotatesting.Manager{}
+	Ctl = &controller{
+		quotaMgr: suite.quotaMgr,
+	}
+
+	// only the user group 1 has quota
+	q := &quota.Quota{}
+	q.SetHard(types.ResourceList{types.ResourceStorage: 10})
+	q.SetUsed(types.ResourceList{types.ResourceStorage: 0})
+	suite.quotaMgr.On("GetByRef", mock.Anything, GroupReference, "1").Return(q, nil)
+	suite.quotaMgr.On("GetByRef", mock.Anything, GroupReference, "2").Return(nil, errors.NotFoundError(nil))
+}
+
+func (suite *RefreshForGroupsTestSuite) TearDownTest() {
+	Ctl = suite.originalQuotaCtl
+	driver.Register(GroupReference, suite.originalGroupDriver)
+}
+
+func (suite *RefreshForGroupsTestSuite) TestGroupsOfProject() {
+	groups, err := GroupsOfProject(context.TODO(), 1)
+	suite.Nil(err)
+	suite.Equal([]string{"1"}, groups)
+}
+
+func (suite *RefreshForGroupsTestSuite) TestRefreshForGroups() {
+	mock.OnAnything(suite.quotaMgr, "Update").Return(nil)
+	mock.OnAnything(suite.groupDriver, "CalculateUsage").Return(types.ResourceList{types.ResourceStorage: 20}, nil)
+
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	suite.Nil(RefreshForGroups(ctx, "1", "2"))
+	// the usage is refreshed even it exceeds the limitation
+	suite.groupDriver.AssertCalled(suite.T(), "CalculateUsage", mock.Anything, "1")
+	suite.groupDriver.AssertNotCalled(suite.T(), "CalculateUsage", mock.Anything, "2")
+	suite.quotaMgr.AssertNumberOfCalls(suite.T(), "Update", 1)
+}
+
+func TestRefreshForGroupsTestSuite(t *testing.T) {
+	suite.Run(t, &RefreshForGroupsTestSuite{})
+}
@@ -125,6 +125,64 @@ func TestValidate(t *testing.T) {
 	err = policy.Validate()
 	assert.True(errors.IsErr(err, errors.BadRequestCode))
 
+	// invalid annotation filter
+	policy = &Policy{
+		Name: "policy01",
+		SrcRegistry: &model.Registry{
+			ID: 0,
+		},
+		DestRegistry: &model.Registry{
+			ID: 1,
+		},
+		Filters: []*model.Filter{
+			{
+				Type:  model.FilterTypeAnnotation,
+				Value: "=value",
+			},
+		},
+	}
+	err = policy.Validate()
+	assert.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// invalid severity filter
+	policy = &Policy{
+		Name: "policy01",
+		SrcRegistry: &model.Registry{
+			ID: 0,
+		},
+		DestRegistry: &model.Registry{
+			ID: 1,
+		},
+		Filters: []*model.Filter{
+			{
+				Type:  model.FilterTypeSeverity,
+				Value: "Severe",
+			},
+		},
+	}
+	err = policy.Validate()
+	assert.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// severity filter doesn't support decoration
+	policy = &Policy{
+		Name: "policy01",
+		SrcRegistry: &model.Registry{
+			ID: 0,
+		},
+		DestRegistry: &model.Registry{
+			ID: 1,
+		},
+		Filters: []*model.Filter{
+			{
+				Type:       model.FilterTypeSeverity,
+				Value:      "High",
+				Decoration: model.Excludes,
+			},
+		},
+	}
+	err = policy.Validate()
+	assert.True(errors.IsErr(err, errors.BadRequestCode))
+
 	// invalid trigger
 	policy = &Policy{
 		Name: "policy01",
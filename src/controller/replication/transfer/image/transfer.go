@@ -31,6 +31,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/reg/adapter"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/registry"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -249,7 +250,8 @@ func (t *transfer) copyContent(content distribution.Descriptor, srcRepo, dstRepo
 	// the contents it contains are a few manifests/indexes
 	case v1.MediaTypeImageIndex, manifestlist.MediaTypeManifestList,
 		v1.MediaTypeImageManifest, schema2.MediaTypeManifest,
-		schema1.MediaTypeSignedManifest, schema1.MediaTypeManifest:
+		schema1.MediaTypeSignedManifest, schema1.MediaTypeManifest,
+		registry.MediaTypeArtifactManifest:
 		// as using digest as the reference, so set the override to true directly
 		return t.copyArtifact(srcRepo, digest, dstRepo, digest, true, speed)
 	// handle foreign layer
@@ -0,0 +1,233 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/pkg/rbac"
+	"github.com/goharbor/harbor/src/pkg/rbac/model"
+)
+
+var (
+	// Ctl is a global role controller instance
+	Ctl = NewController()
+)
+
+// Controller manages the project roles, the custom roles are built from the
+// resources and actions of the built-in project roles
+type Controller interface {
+	// Create the custom role with its permissions
+	Create(ctx context.Context, role *Role) (int64, error)
+	// Update the name, description and permissions of the custom role
+	Update(ctx context.Context, role *Role) error
+	// Delete the custom role, the role assigned to the project members can't be deleted
+	Delete(ctx context.Context, id int64) error
+	// Get the role specified by ID with its permissions
+	Get(ctx context.Context, id int64) (*Role, error)
+	// Count returns the total count of roles according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the roles with their permissions according to the query
+	List(ctx context.Context, query *q.Query) ([]*Role, error)
+}
+
+// NewController creates an instance of the default role controller
+func NewController() Controller {
+	return &controller{
+		rbacMgr: rbac.NewManager(),
+	}
+}
+
+type controller struct {
+	rbacMgr rbac.Manager
+}
+
+func (c *controller) Create(ctx context.Context, role *Role) (int64, error) {
+	if err := validate(role); err != nil {
+		return 0, err
+	}
+	id, err := c.rbacMgr.CreateRole(ctx, &model.Role{
+		Name:        role.Name,
+		Description: role.Description,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err = c.createPermissions(ctx, id, role.Permissions); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (c *controller) Update(ctx context.Context, role *Role) error {
+	if err := validate(role); err != nil {
+		return err
+	}
+	r, err := c.rbacMgr.GetRole(ctx, role.ID)
+	if err != nil {
+		return err
+	}
+	if r.IsBuiltIn() {
+		return errors.BadRequestError(nil).WithMessage("the built-in role %s can't be updated", r.Name)
+	}
+	r.Name = role.Name
+	r.Description = role.Description
+	if err = c.rbacMgr.UpdateRole(ctx, r, "Name", "Description", "UpdateTime"); err != nil {
+		return err
+	}
+	if err = c.deletePermissions(ctx, r.ID); err != nil {
+		return err
+	}
+	return c.createPermissions(ctx, r.ID, role.Permissions)
+}
+
+func (c *controller) Delete(ctx context.Context, id int64) error {
+	r, err := c.rbacMgr.GetRole(ctx, id)
+	if err != nil {
+		return err
+	}
+	if r.IsBuiltIn() {
+		return errors.BadRequestError(nil).WithMessage("the built-in role %s can't be deleted", r.Name)
+	}
+	count, err := c.rbacMgr.CountRoleMembers(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.ConflictError(nil).WithMessage("the role %s is assigned to %d project members", r.Name, count)
+	}
+	if err = c.deletePermissions(ctx, id); err != nil {
+		return err
+	}
+	return c.rbacMgr.DeleteRole(ctx, id)
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*Role, error) {
+	r, err := c.rbacMgr.GetRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.populate(ctx, r)
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return c.rbacMgr.CountRoles(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*Role, error) {
+	rs, err := c.rbacMgr.ListRoles(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var roles []*Role
+	for _, r := range rs {
+		role, err := c.populate(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func (c *controller) populate(ctx context.Context, r *model.Role) (*Role, error) {
+	role := &Role{
+		Role:    *r,
+		BuiltIn: r.IsBuiltIn(),
+	}
+	if role.BuiltIn {
+		role.Permissions = project.GetPoliciesOfRole(int(r.ID))
+		return role, nil
+	}
+	permissions, err := c.rbacMgr.GetPermissionsByRole(ctx, model.ProjectRoleType, r.ID)
+	if err != nil {
+		log.Errorf("failed to get permissions of role %d: %v", r.ID, err)
+		return nil, err
+	}
+	for _, permission := range permissions {
+		role.Permissions = append(role.Permissions, &types.Policy{
+			Resource: types.Resource(permission.Resource),
+			Action:   types.Action(permission.Action),
+			Effect:   types.Effect(permission.Effect),
+		})
+	}
+	return role, nil
+}
+
+func (c *controller) createPermissions(ctx context.Context, roleID int64, policies []*types.Policy) error {
+	for _, policy := range policies {
+		policyID, err := c.rbacMgr.CreateRbacPolicy(ctx, &model.PermissionPolicy{
+			Scope:    model.ProjectRoleScope,
+			Resource: policy.Resource.String(),
+			Action:   policy.Action.String(),
+			Effect:   policy.GetEffect(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = c.rbacMgr.CreatePermission(ctx, &model.RolePermission{
+			RoleType:           model.ProjectRoleType,
+			RoleID:             roleID,
+			PermissionPolicyID: policyID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) deletePermissions(ctx context.Context, roleID int64) error {
+	err := c.rbacMgr.DeletePermissionsByRole(ctx, model.ProjectRoleType, roleID)
+	// the role without any permission
+	if errors.IsNotFoundErr(err) {
+		return nil
+	}
+	return err
+}
+
+// validate the name and the permissions of the custom role, the permissions must be
+// in the resource and action matrix of the built-in project roles
+func validate(role *Role) error {
+	role.Name = strings.TrimSpace(role.Name)
+	if len(role.Name) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the name of the role is required")
+	}
+	if len(role.Name) > 255 {
+		return errors.BadRequestError(nil).WithMessage("the name of the role must be no more than 255 characters")
+	}
+	if len(role.Permissions) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the permissions of the role are required")
+	}
+	allowed := map[string]bool{}
+	for _, policy := range project.GetAllPolicies() {
+		allowed[policy.Resource.String()+":"+policy.Action.String()] = true
+	}
+	for _, policy := range role.Permissions {
+		if !allowed[policy.Resource.String()+":"+policy.Action.String()] {
+			return errors.BadRequestError(nil).WithMessage("the permission %s:%s isn't supported by the project roles",
+				policy.Resource, policy.Action)
+		}
+		if policy.Effect != "" && policy.Effect != types.EffectAllow {
+			return errors.BadRequestError(nil).WithMessage("only the allow effect is supported by the project roles")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/pkg/rbac/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	rbactesting "github.com/goharbor/harbor/src/testing/pkg/rbac"
+	"github.com/stretchr/testify/suite"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	rbacMgr *rbactesting.Manager
+	ctl     *controller
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.rbacMgr = &rbactesting.Manager{}
+	c.ctl = &controller{
+		rbacMgr: c.rbacMgr,
+	}
+}
+
+func (c *controllerTestSuite) TestCreate() {
+	// invalid name
+	_, err := c.ctl.Create(context.TODO(), &Role{
+		Permissions: []*types.Policy{{Resource: rbac.ResourceRepository, Action: rbac.ActionPull}},
+	})
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// unsupported permission
+	_, err = c.ctl.Create(context.TODO(), &Role{
+		Role:        model.Role{Name: "puller"},
+		Permissions: []*types.Policy{{Resource: rbac.ResourcePurgeAuditLog, Action: rbac.ActionCreate}},
+	})
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	c.rbacMgr.On("CreateRole", mock.Anything, mock.Anything).Return(int64(6), nil)
+	c.rbacMgr.On("CreateRbacPolicy", mock.Anything, mock.Anything).Return(int64(1), nil)
+	c.rbacMgr.On("CreatePermission", mock.Anything, mock.Anything).Return(int64(1), nil)
+	id, err := c.ctl.Create(context.TODO(), &Role{
+		Role: model.Role{Name: " puller "},
+		Permissions: []*types.Policy{
+			{Resource: rbac.ResourceRepository, Action: rbac.ActionPull},
+			{Resource: rbac.ResourceArtifact, Action: rbac.ActionRead},
+		},
+	})
+	c.Require().Nil(err)
+	c.Equal(int64(6), id)
+	c.rbacMgr.AssertCalled(c.T(), "CreateRole", mock.Anything, &model.Role{Name: "puller"})
+	c.rbacMgr.AssertNumberOfCalls(c.T(), "CreatePermission", 2)
+}
+
+func (c *controllerTestSuite) TestUpdate() {
+	role := &Role{
+		Role:        model.Role{ID: 1, Name: "admin"},
+		Permissions: []*types.Policy{{Resource: rbac.ResourceRepository, Action: rbac.ActionPull}},
+	}
+	// built-in role
+	c.rbacMgr.On("GetRole", mock.Anything, int64(1)).Return(&model.Role{ID: 1, Name: "projectAdmin"}, nil)
+	err := c.ctl.Update(context.TODO(), role)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	c.rbacMgr.On("GetRole", mock.Anything, int64(6)).Return(&model.Role{ID: 6, Name: "puller"}, nil)
+	c.rbacMgr.On("UpdateRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.rbacMgr.On("DeletePermissionsByRole", mock.Anything, model.ProjectRoleType, int64(6)).Return(errors.NotFoundError(nil))
+	c.rbacMgr.On("CreateRbacPolicy", mock.Anything, mock.Anything).Return(int64(1), nil)
+	c.rbacMgr.On("CreatePermission", mock.Anything, mock.Anything).Return(int64(1), nil)
+	role.ID = 6
+	role.Name = "reader"
+	c.Require().Nil(c.ctl.Update(context.TODO(), role))
+	c.rbacMgr.AssertCalled(c.T(), "UpdateRole", mock.Anything, &model.Role{ID: 6, Name: "reader"},
+		"Name", "Description", "UpdateTime")
+	c.rbacMgr.AssertNumberOfCalls(c.T(), "CreatePermission", 1)
+}
+
+func (c *controllerTestSuite) TestDelete() {
+	c.rbacMgr.On("GetRole", mock.Anything, int64(1)).Return(&model.Role{ID: 1, Name: "projectAdmin"}, nil)
+	err := c.ctl.Delete(context.TODO(), 1)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// the role is assigned to members
+	c.rbacMgr.On("GetRole", mock.Anything, int64(6)).Return(&model.Role{ID: 6, Name: "puller"}, nil)
+	c.rbacMgr.On("CountRoleMembers", mock.Anything, int64(6)).Return(int64(1), nil)
+	err = c.ctl.Delete(context.TODO(), 6)
+	c.True(errors.IsErr(err, errors.ConflictCode))
+
+	c.rbacMgr.On("GetRole", mock.Anything, int64(7)).Return(&model.Role{ID: 7, Name: "reader"}, nil)
+	c.rbacMgr.On("CountRoleMembers", mock.Anything, int64(7)).Return(int64(0), nil)
+	c.rbacMgr.On("DeletePermissionsByRole", mock.Anything, model.ProjectRoleType, int64(7)).Return(nil)
+	c.rbacMgr.On("DeleteRole", mock.Anything, int64(7)).Return(nil)
+	c.Nil(c.ctl.Delete(context.TODO(), 7))
+	c.rbacMgr.AssertCalled(c.T(), "DeleteRole", mock.Anything, int64(7))
+}
+
+func (c *controllerTestSuite) TestList() {
+	c.rbacMgr.On("ListRoles", mock.Anything, mock.Anything).Return([]*model.Role{
+		{ID: 1, Name: "projectAdmin"},
+		{ID: 6, Name: "puller"},
+	}, nil)
+	c.rbacMgr.On("GetPermissionsByRole", mock.Anything, model.ProjectRoleType, int64(6)).Return([]*model.UniversalRolePermission{
+		{Resource: "repository", Action: "pull", Effect: "allow"},
+	}, nil)
+	roles, err := c.ctl.List(context.TODO(), nil)
+	c.Require().Nil(err)
+	c.Require().Len(roles, 2)
+	c.True(roles[0].BuiltIn)
+	c.NotEmpty(roles[0].Permissions)
+	c.False(roles[1].BuiltIn)
+	c.Require().Len(roles[1].Permissions, 1)
+	c.Equal(rbac.ActionPull, roles[1].Permissions[0].Action)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
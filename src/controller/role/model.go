@@ -12,12 +12,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package models
+package role
 
-// Role holds the details of a role.
+import (
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/pkg/rbac/model"
+)
+
+// Role is the project role with its permissions
 type Role struct {
-	RoleID   int    `orm:"pk;auto;column(role_id)" json:"role_id"`
-	RoleCode string `orm:"column(role_code)" json:"role_code"`
-	Name     string `orm:"column(name)" json:"role_name"`
-	RoleMask int    `orm:"column(role_mask)" json:"role_mask"`
+	model.Role
+	// BuiltIn is true for the built-in roles which can't be updated or deleted
+	BuiltIn bool `json:"builtin"`
+	// Permissions are the policies of the project resources without the namespace
+	Permissions []*types.Policy `json:"permissions"`
 }
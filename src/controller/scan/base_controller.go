@@ -783,6 +783,8 @@ func (bc *basicController) GetVulnerable(ctx context.Context, artifact *ar.Artif
 				continue
 			}
 
+			vulnerable.Vulnerabilities = append(vulnerable.Vulnerabilities, v)
+
 			if severity == "" || v.Severity.Code() > severity.Code() {
 				severity = v.Severity
 			}
@@ -30,6 +30,8 @@ type Vulnerable struct {
 	ScanStatus           string
 	Severity             *vuln.Severity
 	CVEBypassed          []string
+	// the vulnerabilities which aren't bypassed by the allowlist
+	Vulnerabilities []*vuln.VulnerabilityItem
 }
 
 // IsScanSuccess returns true when the artifact scanned success
@@ -89,6 +89,15 @@ func (h *Handler) Prepare() {
 // HandleNotificationJob handles the hook of notification job
 func (h *Handler) HandleNotificationJob() {
 	log.Debugf("received notification job status update event: job-%d, status-%s", h.id, h.status)
+	// the check in message carries the delivery attempt of the job, record it without touching the status
+	// as the check in may arrive after the final status
+	if len(h.checkIn) > 0 {
+		if err := h.recordDelivery(); err != nil {
+			log.Errorf("Failed to record the delivery of notification job %d: %v", h.id, err)
+			h.SendInternalServerError(err)
+		}
+		return
+	}
 	if err := notification.JobMgr.Update(orm.Context(), &model.Job{
 		ID:         h.id,
 		Status:     h.status,
@@ -99,3 +108,23 @@ func (h *Handler) HandleNotificationJob() {
 		return
 	}
 }
+
+func (h *Handler) recordDelivery() error {
+	delivery := &model.Delivery{}
+	if err := json.Unmarshal([]byte(h.checkIn), delivery); err != nil {
+		// not a delivery record, ignore it
+		log.Debugf("drop the check in message of notification job %d: %v", h.id, err)
+		return nil
+	}
+	ctx := orm.Context()
+	j, err := notification.JobMgr.Get(ctx, h.id)
+	if err != nil {
+		return err
+	}
+	delivery.ID = 0
+	delivery.JobID = j.ID
+	delivery.PolicyID = j.PolicyID
+	delivery.CreationTime = time.Now()
+	_, err = notification.JobMgr.CreateDelivery(ctx, delivery)
+	return err
+}
@@ -16,6 +16,7 @@ package gc
 
 import (
 	"os"
+	"sync"
 	"time"
 
 	"github.com/goharbor/harbor/src/common/registryctl"
@@ -44,6 +45,9 @@ const (
 	dialWriteTimeout      = 10 * time.Second
 	blobPrefix            = "blobs::*"
 	repoPrefix            = "repository::*"
+	// the default and max count of the workers to delete the blobs and manifests concurrently in the sweep phase
+	defaultSweepWorkers = 4
+	maxSweepWorkers     = 50
 )
 
 // GarbageCollector is the struct to run registry's garbage collection
@@ -63,6 +67,13 @@ type GarbageCollector struct {
 	// hold all of GC candidates(non-referenced blobs), it's captured by mark and consumed by sweep.
 	deleteSet       []*blobModels.Blob
 	timeWindowHours int64
+	// the count of the workers in the sweep phase
+	workers int
+	// resume the sweep of the candidates left by the last failed or stopped execution instead of marking again
+	resume   bool
+	progress *sweepProgress
+	// restrict the mark phase to the project or repository, nil for the system wide gc
+	scope *scope
 }
 
 // MaxFails implements the interface in job/Interface
@@ -142,8 +153,35 @@ func (gc *GarbageCollector) parseParams(params job.Parameters) {
 		}
 	}
 
-	gc.logger.Infof("Garbage Collection parameters: [delete_untagged: %t, dry_run: %t, time_window: %d]",
-		gc.deleteUntagged, gc.dryRun, gc.timeWindowHours)
+	// workers: default is 4, and it's limited by the max count
+	gc.workers = defaultSweepWorkers
+	workers, exist := params["workers"]
+	if exist {
+		if workers, ok := workers.(float64); ok && workers > 0 {
+			gc.workers = int(workers)
+		}
+	}
+	if gc.workers > maxSweepWorkers {
+		gc.workers = maxSweepWorkers
+	}
+
+	// scope: default is the system wide gc
+	gc.scope = parseScope(params)
+
+	// resume: set by the core when the last execution failed or was stopped in the sweep phase, the scoped gc never resumes
+	gc.resume = false
+	resume, exist := params["resume"]
+	if exist {
+		if resume, ok := resume.(bool); ok && resume && !gc.dryRun && gc.scope == nil {
+			gc.resume = resume
+		}
+	}
+
+	gc.logger.Infof("Garbage Collection parameters: [delete_untagged: %t, dry_run: %t, time_window: %d, workers: %d, resume: %t]",
+		gc.deleteUntagged, gc.dryRun, gc.timeWindowHours, gc.workers, gc.resume)
+	if gc.scope != nil {
+		gc.logger.Infof("Garbage Collection is restricted to the %s", gc.scope)
+	}
 }
 
 // Run implements the interface in job/Interface
@@ -154,8 +192,13 @@ func (gc *GarbageCollector) Run(ctx job.Context, params job.Parameters) error {
 
 	gc.logger.Infof("start to run gc in job.")
 
-	// mark
-	if err := gc.mark(ctx); err != nil {
+	// mark, the candidates marked by the last execution are used directly when resuming
+	if gc.resume {
+		if err := gc.markResumed(ctx); err != nil {
+			gc.logger.Errorf("failed to execute GC job at resuming mark phase, error: %v", err)
+			return err
+		}
+	} else if err := gc.mark(ctx); err != nil {
 		gc.logger.Errorf("failed to execute GC job at mark phase, error: %v", err)
 		return err
 	}
@@ -188,6 +231,15 @@ func (gc *GarbageCollector) mark(ctx job.Context) error {
 	}
 	gc.trashedArts = arts
 
+	// collect the blobs referenced by the artifacts removed from the scope. All the trashed artifacts are
+	// kept in trashedArts as a manifest shared by the repositories out of the scope has to be deleted from all of them.
+	if gc.scope != nil {
+		if err := gc.collectScopedBlobs(ctx); err != nil {
+			gc.logger.Errorf("failed to get the blobs referenced by the %s: %v", gc.scope, err)
+			return err
+		}
+	}
+
 	// get gc candidates, and set the repositories.
 	// AS the reference count is calculated by joining table project_blob and blob, here needs to call removeUntaggedBlobs to remove these non-used blobs from table project_blob firstly.
 	orphanBlobs := gc.markOrSweepUntaggedBlobs(ctx)
@@ -238,97 +290,201 @@ func (gc *GarbageCollector) mark(ctx job.Context) error {
 	return nil
 }
 
+// markResumed loads the candidates marked by the last failed or stopped execution, the blobs deleted
+// by it have been removed from the database, so the sweep continues with the remaining ones.
+func (gc *GarbageCollector) markResumed(ctx job.Context) error {
+	arts, err := gc.artrashMgr.Filter(ctx.SystemContext(), 0)
+	if err != nil {
+		gc.logger.Errorf("failed to get deleted Artifacts in gc job, with error: %v", err)
+		return err
+	}
+	for _, art := range arts {
+		gc.trashedArts[art.Digest] = append(gc.trashedArts[art.Digest], art)
+	}
+
+	blobs, err := gc.blobMgr.List(ctx.SystemContext(), q.New(q.KeyWords{
+		"status": q.NewOrList([]interface{}{blobModels.StatusDelete, blobModels.StatusDeleting, blobModels.StatusDeleteFailed}),
+	}))
+	if err != nil {
+		gc.logger.Errorf("failed to get the gc candidates to resume: %v", err)
+		return err
+	}
+	for _, blob := range blobs {
+		// the blobs failed to delete should be in the candidate again
+		if blob.Status == blobModels.StatusDeleteFailed {
+			blob.Status = blobModels.StatusDelete
+			count, err := gc.blobMgr.UpdateBlobStatus(ctx.SystemContext(), blob)
+			if err != nil {
+				gc.logger.Warningf("failed to mark gc candidate, skip it.: %s, error: %v", blob.Digest, err)
+				continue
+			}
+			if count == 0 {
+				gc.logger.Warningf("no blob found to mark gc candidate, skip it. ID:%d, digest:%s", blob.ID, blob.Digest)
+				continue
+			}
+		}
+		gc.deleteSet = append(gc.deleteSet, blob)
+	}
+	gc.logger.Infof("resume the sweep of %d blobs left by the last execution", len(gc.deleteSet))
+	return nil
+}
+
+// sweep deletes the candidates by the workers concurrently, it stops dispatching the candidates once a worker fails
+// or the job is stopped. The candidates not deleted keep their status in the database and can be resumed by the next execution.
 func (gc *GarbageCollector) sweep(ctx job.Context) error {
 	gc.logger = ctx.GetLogger()
-	sweepSize := int64(0)
+	gc.progress = newSweepProgress(gc.deleteSet, gc.trashedArts, gc.resume)
+	// check in the progress once the sweep starts, the core resumes the next execution only if the sweep has started
+	if err := gc.progress.checkin(ctx, true); err != nil {
+		gc.logger.Warningf("failed to check in the progress of gc: %v", err)
+	}
+	workers := gc.workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		sweepErr error
+	)
+	candidates := make(chan *blobModels.Blob)
+	failed := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for blob := range candidates {
+				if err := gc.sweepBlob(ctx, blob); err != nil {
+					once.Do(func() {
+						sweepErr = err
+						close(failed)
+					})
+					return
+				}
+				if err := gc.progress.checkin(ctx, false); err != nil {
+					gc.logger.Warningf("failed to check in the progress of gc: %v", err)
+				}
+			}
+		}()
+	}
+
+dispatch:
 	for _, blob := range gc.deleteSet {
-		// set the status firstly, if the blob is updated by any HEAD/PUT request, it should be fail and skip.
+		if opCmd, flag := ctx.OPCommand(); flag && opCmd.IsStop() {
+			gc.logger.Info("received the stop signal, stop the sweep, the remaining candidates can be resumed by the next execution.")
+			break
+		}
+		select {
+		case candidates <- blob:
+		case <-failed:
+			break dispatch
+		}
+	}
+	close(candidates)
+	wg.Wait()
+
+	if err := gc.progress.checkin(ctx, true); err != nil {
+		gc.logger.Warningf("failed to check in the progress of gc: %v", err)
+	}
+	gc.logger.Infof("%d of %d blobs are deleted, the GC job actual frees up %d MB space.",
+		gc.progress.BlobsDone, gc.progress.BlobsTotal, gc.progress.BytesFreed/1024/1024)
+	return sweepErr
+}
+
+// sweepBlob deletes the blob from the storage and database, the blob which is in use again is skipped
+func (gc *GarbageCollector) sweepBlob(ctx job.Context, blob *blobModels.Blob) error {
+	// set the status firstly, if the blob is updated by any HEAD/PUT request, it should be fail and skip.
+	// the blob left in deleting status by the last execution is claimed already when resuming.
+	if blob.Status != blobModels.StatusDeleting {
 		blob.Status = blobModels.StatusDeleting
 		count, err := gc.blobMgr.UpdateBlobStatus(ctx.SystemContext(), blob)
 		if err != nil {
 			gc.logger.Errorf("failed to mark gc candidate deleting, skip: %s, %s", blob.Digest, blob.Status)
-			continue
+			return nil
 		}
 		if count == 0 {
 			gc.logger.Warningf("no blob found to mark gc candidate deleting, ID:%d, digest:%s", blob.ID, blob.Digest)
-			continue
+			return nil
 		}
+	}
 
-		// remove tags and revisions of a manifest
-		if _, exist := gc.trashedArts[blob.Digest]; exist && blob.IsManifest() {
-			for _, art := range gc.trashedArts[blob.Digest] {
-				// Harbor cannot know the existing tags in the backend from its database, so let the v2 DELETE manifest to remove all of them.
-				gc.logger.Infof("delete the manifest with registry v2 API: %s, %s, %s",
-					art.RepositoryName, blob.ContentType, blob.Digest)
-				if err := v2DeleteManifest(gc.logger, art.RepositoryName, blob.Digest); err != nil {
-					gc.logger.Errorf("failed to delete manifest with v2 API, %s, %s, %v", art.RepositoryName, blob.Digest, err)
-					if err := ignoreNotFound(func() error {
-						return gc.markDeleteFailed(ctx, blob)
-					}); err != nil {
-						return err
-					}
-					return errors.Wrapf(err, "failed to delete manifest with v2 API: %s, %s", art.RepositoryName, blob.Digest)
-				}
-				// for manifest, it has to delete the revisions folder of each repository
-				gc.logger.Infof("delete manifest from storage: %s", blob.Digest)
-				if err := retry.Retry(func() error {
-					return ignoreNotFound(func() error {
-						return gc.registryCtlClient.DeleteManifest(art.RepositoryName, blob.Digest)
-					})
-				}, retry.Callback(func(err error, sleep time.Duration) {
-					gc.logger.Infof("failed to exec DeleteManifest, error: %v, will retry again after: %s", err, sleep)
-				})); err != nil {
-					if err := ignoreNotFound(func() error {
-						return gc.markDeleteFailed(ctx, blob)
-					}); err != nil {
-						return err
-					}
-					return errors.Wrapf(err, "failed to remove manifest from storage: %s, %s", art.RepositoryName, blob.Digest)
-				}
-
-				gc.logger.Infof("delete artifact trash record from database: %d, %s, %s", art.ID, art.RepositoryName, art.Digest)
+	// remove tags and revisions of a manifest
+	if _, exist := gc.trashedArts[blob.Digest]; exist && blob.IsManifest() {
+		for _, art := range gc.trashedArts[blob.Digest] {
+			// Harbor cannot know the existing tags in the backend from its database, so let the v2 DELETE manifest to remove all of them.
+			gc.logger.Infof("delete the manifest with registry v2 API: %s, %s, %s",
+				art.RepositoryName, blob.ContentType, blob.Digest)
+			if err := v2DeleteManifest(gc.logger, art.RepositoryName, blob.Digest); err != nil {
+				gc.logger.Errorf("failed to delete manifest with v2 API, %s, %s, %v", art.RepositoryName, blob.Digest, err)
 				if err := ignoreNotFound(func() error {
-					return gc.artrashMgr.Delete(ctx.SystemContext(), art.ID)
+					return gc.markDeleteFailed(ctx, blob)
 				}); err != nil {
 					return err
 				}
+				return errors.Wrapf(err, "failed to delete manifest with v2 API: %s, %s", art.RepositoryName, blob.Digest)
 			}
-		}
-
-		// delete all of blobs, which include config, layer and manifest
-		// for the foreign layer, as it's not stored in the storage, no need to call the delete api and count size, but still have to delete the DB record.
-		if !blob.IsForeignLayer() {
-			gc.logger.Infof("delete blob from storage: %s", blob.Digest)
+			// for manifest, it has to delete the revisions folder of each repository
+			gc.logger.Infof("delete manifest from storage: %s", blob.Digest)
 			if err := retry.Retry(func() error {
 				return ignoreNotFound(func() error {
-					return gc.registryCtlClient.DeleteBlob(blob.Digest)
+					return gc.registryCtlClient.DeleteManifest(art.RepositoryName, blob.Digest)
 				})
 			}, retry.Callback(func(err error, sleep time.Duration) {
-				gc.logger.Infof("failed to exec DeleteBlob, error: %v, will retry again after: %s", err, sleep)
+				gc.logger.Infof("failed to exec DeleteManifest, error: %v, will retry again after: %s", err, sleep)
 			})); err != nil {
 				if err := ignoreNotFound(func() error {
 					return gc.markDeleteFailed(ctx, blob)
 				}); err != nil {
 					return err
 				}
-				return errors.Wrapf(err, "failed to delete blob from storage: %s, %s", blob.Digest, blob.Status)
+				return errors.Wrapf(err, "failed to remove manifest from storage: %s, %s", art.RepositoryName, blob.Digest)
+			}
+
+			gc.logger.Infof("delete artifact trash record from database: %d, %s, %s", art.ID, art.RepositoryName, art.Digest)
+			if err := ignoreNotFound(func() error {
+				return gc.artrashMgr.Delete(ctx.SystemContext(), art.ID)
+			}); err != nil {
+				return err
 			}
-			sweepSize = sweepSize + blob.Size
+			gc.progress.manifestDone(art.RepositoryName)
 		}
+	}
 
-		gc.logger.Infof("delete blob record from database: %d, %s", blob.ID, blob.Digest)
-		if err := ignoreNotFound(func() error {
-			return gc.blobMgr.Delete(ctx.SystemContext(), blob.ID)
-		}); err != nil {
+	// delete all of blobs, which include config, layer and manifest
+	// for the foreign layer, as it's not stored in the storage, no need to call the delete api and count size, but still have to delete the DB record.
+	freed := int64(0)
+	if !blob.IsForeignLayer() {
+		gc.logger.Infof("delete blob from storage: %s", blob.Digest)
+		if err := retry.Retry(func() error {
+			return ignoreNotFound(func() error {
+				return gc.registryCtlClient.DeleteBlob(blob.Digest)
+			})
+		}, retry.Callback(func(err error, sleep time.Duration) {
+			gc.logger.Infof("failed to exec DeleteBlob, error: %v, will retry again after: %s", err, sleep)
+		})); err != nil {
 			if err := ignoreNotFound(func() error {
 				return gc.markDeleteFailed(ctx, blob)
 			}); err != nil {
 				return err
 			}
-			return errors.Wrapf(err, "failed to delete blob from database: %s, %s", blob.Digest, blob.Status)
+			return errors.Wrapf(err, "failed to delete blob from storage: %s, %s", blob.Digest, blob.Status)
+		}
+		freed = blob.Size
+	}
+
+	gc.logger.Infof("delete blob record from database: %d, %s", blob.ID, blob.Digest)
+	if err := ignoreNotFound(func() error {
+		return gc.blobMgr.Delete(ctx.SystemContext(), blob.ID)
+	}); err != nil {
+		if err := ignoreNotFound(func() error {
+			return gc.markDeleteFailed(ctx, blob)
+		}); err != nil {
+			return err
 		}
+		return errors.Wrapf(err, "failed to delete blob from database: %s, %s", blob.Digest, blob.Status)
 	}
-	gc.logger.Infof("The GC job actual frees up %d MB space.", sweepSize/1024/1024)
+	gc.progress.blobDone(freed)
 	return nil
 }
 
@@ -382,11 +538,18 @@ func (gc *GarbageCollector) deletedArt(ctx job.Context) (map[string][]model.Arti
 	// artMap : map[digest : []ArtifactTrash list]
 	artMap := make(map[string][]model.ArtifactTrash)
 	// handle the optional ones, and the artifact controller will move them into trash.
-	if gc.deleteUntagged {
+	// the deleted project in the scope has no artifact left, and the query without the project ID matches all the projects
+	if gc.deleteUntagged && (gc.scope == nil || gc.scope.projectID > 0) {
+		keywords := map[string]interface{}{
+			"Tags": "nil",
+		}
+		if gc.scope != nil {
+			for k, v := range gc.scope.keywords() {
+				keywords[k] = v
+			}
+		}
 		untaggedArts, err = gc.artCtl.List(ctx.SystemContext(), &q.Query{
-			Keywords: map[string]interface{}{
-				"Tags": "nil",
-			},
+			Keywords: keywords,
 		}, nil)
 		if err != nil {
 			return artMap, err
@@ -449,7 +612,15 @@ func (gc *GarbageCollector) deletedArt(ctx job.Context) (map[string][]model.Arti
 // * non dry-run, remove the reference of the untagged blobs
 func (gc *GarbageCollector) markOrSweepUntaggedBlobs(ctx job.Context) []*blobModels.Blob {
 	var orphanBlobs []*blobModels.Blob
-	for result := range project.ListAll(ctx.SystemContext(), 50, nil, project.Metadata(false)) {
+	var query *q.Query
+	if gc.scope != nil {
+		// the project is deleted, there is no blob associated with it
+		if gc.scope.projectID <= 0 {
+			return orphanBlobs
+		}
+		query = q.New(q.KeyWords{"project_id": gc.scope.projectID})
+	}
+	for result := range project.ListAll(ctx.SystemContext(), 50, query, project.Metadata(false)) {
 		if result.Error != nil {
 			gc.logger.Errorf("remove untagged blobs for all projects got error: %v", result.Error)
 			continue
@@ -483,14 +654,23 @@ func (gc *GarbageCollector) markOrSweepUntaggedBlobs(ctx job.Context) []*blobMod
 				gc.logger.Errorf("failed to get blobs of project: %d, %v", p.ProjectID, err)
 				break
 			}
-			if gc.dryRun {
+			if gc.dryRun || gc.scope != nil {
 				unassociated, err := gc.blobMgr.FindBlobsShouldUnassociatedWithProject(ctx.SystemContext(), p.ProjectID, blobs)
 				if err != nil {
 					gc.logger.Errorf("failed to find untagged blobs of project: %d, %v", p.ProjectID, err)
 					break
 				}
-				orphanBlobs = append(orphanBlobs, unassociated...)
-			} else {
+				// the untagged blobs of the project are out of the repository scope
+				if gc.scope != nil && len(gc.scope.repository) == 0 {
+					for _, blob := range unassociated {
+						gc.scope.digests[blob.Digest] = true
+					}
+				}
+				if gc.dryRun {
+					orphanBlobs = append(orphanBlobs, unassociated...)
+				}
+			}
+			if !gc.dryRun {
 				if err := gc.blobMgr.CleanupAssociationsForProject(ctx.SystemContext(), p.ProjectID, blobs); err != nil {
 					gc.logger.Errorf("failed to clean untagged blobs of project: %d, %v", p.ProjectID, err)
 					break
@@ -529,9 +709,38 @@ func (gc *GarbageCollector) uselessBlobs(ctx job.Context) ([]*blobModels.Blob, e
 		}
 	}
 
+	if gc.scope != nil {
+		blobs = gc.scope.filter(blobs)
+	}
+
 	return blobs, err
 }
 
+// collectScopedBlobs collects the blobs referenced by the trashed artifacts in the scope
+func (gc *GarbageCollector) collectScopedBlobs(ctx job.Context) error {
+	for digest, arts := range gc.trashedArts {
+		inScope := false
+		for _, art := range arts {
+			if gc.scope.contains(art.RepositoryName) {
+				inScope = true
+				break
+			}
+		}
+		if !inScope {
+			continue
+		}
+		gc.scope.digests[digest] = true
+		artBlobs, err := gc.blobMgr.GetByArt(ctx.SystemContext(), digest)
+		if err != nil {
+			return err
+		}
+		for _, blob := range artBlobs {
+			gc.scope.digests[blob.Digest] = true
+		}
+	}
+	return nil
+}
+
 // markDeleteFailed set the blob status to StatusDeleteFailed
 func (gc *GarbageCollector) markDeleteFailed(ctx job.Context, blob *blobModels.Blob) error {
 	blob.Status = blobModels.StatusDeleteFailed
@@ -180,6 +180,31 @@ func (suite *gcTestSuite) TestInit() {
 	}
 	suite.Nil(gc.init(ctx, params))
 	suite.True(gc.deleteUntagged)
+	suite.Equal(defaultSweepWorkers, gc.workers)
+	suite.False(gc.resume)
+
+	params = map[string]interface{}{
+		"redis_url_reg": "redis url",
+		"workers":       float64(100),
+		"resume":        true,
+	}
+	suite.Nil(gc.init(ctx, params))
+	suite.Equal(maxSweepWorkers, gc.workers)
+	suite.True(gc.resume)
+
+	params = map[string]interface{}{
+		"redis_url_reg": "redis url",
+		"resume":        true,
+		"project_id":    float64(1),
+		"repository":    "library/hello-world",
+	}
+	suite.Nil(gc.init(ctx, params))
+	suite.False(gc.resume)
+	suite.Require().NotNil(gc.scope)
+	suite.Equal(int64(1), gc.scope.projectID)
+	suite.Equal("library", gc.scope.projectName)
+	suite.True(gc.scope.contains("library/hello-world"))
+	suite.False(gc.scope.contains("library/hello-world2"))
 }
 
 func (suite *gcTestSuite) TestStop() {
@@ -215,6 +240,7 @@ func (suite *gcTestSuite) TestRun() {
 	logger := &mockjobservice.MockJobLogger{}
 	ctx.On("GetLogger").Return(logger)
 	ctx.On("OPCommand").Return(job.NilCommand, true)
+	ctx.On("Checkin", mock.Anything).Return(nil)
 	mock.OnAnything(ctx, "Get").Return("core url", true)
 
 	suite.artifactCtl.On("List").Return([]*artifact.Artifact{
@@ -347,10 +373,132 @@ func (suite *gcTestSuite) TestMark() {
 	suite.Nil(gc.mark(ctx))
 }
 
+func (suite *gcTestSuite) TestMarkScoped() {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+
+	manifest := suite.DigestString()
+	layer := suite.DigestString()
+	suite.artrashMgr.On("Filter").Return([]model.ArtifactTrash{
+		{
+			ID:             1,
+			Digest:         manifest,
+			RepositoryName: "library/hello-world",
+		},
+		{
+			ID:             2,
+			Digest:         suite.DigestString(),
+			RepositoryName: "other/hello-world",
+		},
+	}, nil)
+	mock.OnAnything(suite.blobMgr, "GetByArt").Return([]*pkg_blob.Blob{
+		{
+			ID:     2,
+			Digest: layer,
+		},
+	}, nil)
+	mock.OnAnything(suite.projectCtl, "List").Return([]*proModels.Project{
+		{
+			ProjectID: 1,
+			Name:      "library",
+		},
+	}, nil)
+	mock.OnAnything(suite.blobMgr, "List").Return([]*pkg_blob.Blob{}, nil)
+	mock.OnAnything(suite.blobMgr, "FindBlobsShouldUnassociatedWithProject").Return([]*pkg_blob.Blob{}, nil)
+	mock.OnAnything(suite.blobMgr, "CleanupAssociationsForProject").Return(nil)
+	mock.OnAnything(suite.blobMgr, "UselessBlobs").Return([]*pkg_blob.Blob{
+		{
+			ID:          1,
+			Digest:      manifest,
+			ContentType: schema2.MediaTypeManifest,
+		},
+		{
+			ID:          2,
+			Digest:      layer,
+			ContentType: schema2.MediaTypeLayer,
+		},
+		{
+			ID:          3,
+			Digest:      suite.DigestString(),
+			ContentType: schema2.MediaTypeLayer,
+		},
+	}, nil)
+	mock.OnAnything(suite.blobMgr, "UpdateBlobStatus").Return(int64(1), nil)
+
+	gc := &GarbageCollector{
+		artCtl:     suite.artifactCtl,
+		artrashMgr: suite.artrashMgr,
+		blobMgr:    suite.blobMgr,
+		logger:     logger,
+		scope: &scope{
+			projectID:   1,
+			projectName: "library",
+			digests:     map[string]bool{},
+		},
+	}
+
+	suite.Nil(gc.mark(ctx))
+	suite.Len(gc.deleteSet, 2)
+	// the trashed artifacts out of the scope are kept to clean up the shared manifests
+	suite.Len(gc.trashedArts, 2)
+	suite.blobMgr.AssertNumberOfCalls(suite.T(), "GetByArt", 1)
+}
+
+func (suite *gcTestSuite) TestMarkScopedDeletedProject() {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+
+	manifest := suite.DigestString()
+	suite.artrashMgr.On("Filter").Return([]model.ArtifactTrash{
+		{
+			ID:             1,
+			Digest:         manifest,
+			RepositoryName: "library/hello-world",
+		},
+	}, nil)
+	mock.OnAnything(suite.blobMgr, "GetByArt").Return([]*pkg_blob.Blob{}, nil)
+	mock.OnAnything(suite.blobMgr, "UselessBlobs").Return([]*pkg_blob.Blob{
+		{
+			ID:          1,
+			Digest:      manifest,
+			ContentType: schema2.MediaTypeManifest,
+		},
+		{
+			ID:          2,
+			Digest:      suite.DigestString(),
+			ContentType: schema2.MediaTypeLayer,
+		},
+	}, nil)
+	mock.OnAnything(suite.blobMgr, "UpdateBlobStatus").Return(int64(1), nil)
+
+	gc := &GarbageCollector{
+		artCtl:         suite.artifactCtl,
+		artrashMgr:     suite.artrashMgr,
+		blobMgr:        suite.blobMgr,
+		logger:         logger,
+		deleteUntagged: true,
+		scope: &scope{
+			projectName: "library",
+			digests:     map[string]bool{},
+		},
+	}
+
+	suite.Nil(gc.mark(ctx))
+	suite.Len(gc.deleteSet, 1)
+	// the untagged artifacts of the other projects are untouched
+	suite.artifactCtl.AssertNotCalled(suite.T(), "List", mock.Anything, mock.Anything, mock.Anything)
+	suite.artifactCtl.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+	suite.projectCtl.AssertNotCalled(suite.T(), "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *gcTestSuite) TestSweep() {
 	ctx := &mockjobservice.MockJobContext{}
 	logger := &mockjobservice.MockJobLogger{}
 	ctx.On("GetLogger").Return(logger)
+	ctx.On("OPCommand").Return(job.NilCommand, true)
+	ctx.On("Checkin", mock.Anything).Return(nil)
 
 	mock.OnAnything(suite.blobMgr, "UpdateBlobStatus").Return(int64(1), nil)
 	mock.OnAnything(suite.blobMgr, "Delete").Return(nil)
@@ -358,6 +506,42 @@ func (suite *gcTestSuite) TestSweep() {
 	gc := &GarbageCollector{
 		artCtl:            suite.artifactCtl,
 		artrashMgr:        suite.artrashMgr,
+		blobMgr:           suite.blobMgr,
+		registryCtlClient: suite.registryCtlClient,
+		workers:           2,
+		deleteSet: []*pkg_blob.Blob{
+			{
+				ID:          1,
+				Digest:      suite.DigestString(),
+				ContentType: schema2.MediaTypeLayer,
+				Size:        100,
+			},
+			{
+				ID:          2,
+				Digest:      suite.DigestString(),
+				ContentType: schema2.MediaTypeLayer,
+				Size:        200,
+				// left by the last execution
+				Status: pkg_blob.StatusDeleting,
+			},
+		},
+	}
+
+	suite.Nil(gc.sweep(ctx))
+	suite.Equal(2, gc.progress.BlobsTotal)
+	suite.Equal(2, gc.progress.BlobsDone)
+	suite.Equal(int64(300), gc.progress.BytesFreed)
+	suite.blobMgr.AssertNumberOfCalls(suite.T(), "UpdateBlobStatus", 1)
+}
+
+func (suite *gcTestSuite) TestSweepStopped() {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("OPCommand").Return(job.StopCommand, true)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	gc := &GarbageCollector{
 		blobMgr:           suite.blobMgr,
 		registryCtlClient: suite.registryCtlClient,
 		deleteSet: []*pkg_blob.Blob{
@@ -370,6 +554,53 @@ func (suite *gcTestSuite) TestSweep() {
 	}
 
 	suite.Nil(gc.sweep(ctx))
+	suite.Equal(0, gc.progress.BlobsDone)
+	suite.blobMgr.AssertNotCalled(suite.T(), "Delete")
+	// the progress is checked in when the sweep starts and ends, even no blob is deleted
+	ctx.AssertNumberOfCalls(suite.T(), "Checkin", 2)
+}
+
+func (suite *gcTestSuite) TestMarkResumed() {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+
+	digest := suite.DigestString()
+	suite.artrashMgr.On("Filter").Return([]model.ArtifactTrash{
+		{
+			ID:             1,
+			Digest:         digest,
+			RepositoryName: "library/hello-world",
+		},
+	}, nil)
+	mock.OnAnything(suite.blobMgr, "List").Return([]*pkg_blob.Blob{
+		{
+			ID:          1,
+			Digest:      digest,
+			ContentType: schema2.MediaTypeManifest,
+			Status:      pkg_blob.StatusDelete,
+		},
+		{
+			ID:          2,
+			Digest:      suite.DigestString(),
+			ContentType: schema2.MediaTypeLayer,
+			Status:      pkg_blob.StatusDeleteFailed,
+		},
+	}, nil)
+	mock.OnAnything(suite.blobMgr, "UpdateBlobStatus").Return(int64(1), nil).Once()
+
+	gc := &GarbageCollector{
+		artrashMgr:  suite.artrashMgr,
+		blobMgr:     suite.blobMgr,
+		logger:      logger,
+		trashedArts: map[string][]model.ArtifactTrash{},
+		resume:      true,
+	}
+
+	suite.Nil(gc.markResumed(ctx))
+	suite.Len(gc.deleteSet, 2)
+	suite.Equal(pkg_blob.StatusDelete, gc.deleteSet[1].Status)
+	suite.Len(gc.trashedArts[digest], 1)
 }
 
 func TestGCTestSuite(t *testing.T) {
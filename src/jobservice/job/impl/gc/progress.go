@@ -0,0 +1,103 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/artifactrash/model"
+	blobModels "github.com/goharbor/harbor/src/pkg/blob/models"
+)
+
+var (
+	// the min interval between two check-ins of the sweep progress
+	checkinInterval = 10 * time.Second
+)
+
+// sweepProgress is the progress of the sweep phase, it's checked in periodically and persisted
+// in the extra attributes of the GC task by the core, see controller/gc
+type sweepProgress struct {
+	Resumed      bool                           `json:"resumed"`
+	BlobsTotal   int                            `json:"blobs_total"`
+	BlobsDone    int                            `json:"blobs_done"`
+	BytesFreed   int64                          `json:"bytes_freed"`
+	Repositories map[string]*repositoryProgress `json:"repositories,omitempty"`
+
+	lock        sync.Mutex
+	lastCheckin time.Time
+}
+
+// repositoryProgress is the progress of deleting the manifests of the repository
+type repositoryProgress struct {
+	ManifestsTotal int `json:"manifests_total"`
+	ManifestsDone  int `json:"manifests_done"`
+}
+
+func newSweepProgress(deleteSet []*blobModels.Blob, trashedArts map[string][]model.ArtifactTrash, resumed bool) *sweepProgress {
+	p := &sweepProgress{
+		Resumed:      resumed,
+		BlobsTotal:   len(deleteSet),
+		Repositories: map[string]*repositoryProgress{},
+	}
+	for _, blob := range deleteSet {
+		if !blob.IsManifest() {
+			continue
+		}
+		for _, art := range trashedArts[blob.Digest] {
+			repo, exist := p.Repositories[art.RepositoryName]
+			if !exist {
+				repo = &repositoryProgress{}
+				p.Repositories[art.RepositoryName] = repo
+			}
+			repo.ManifestsTotal++
+		}
+	}
+	return p
+}
+
+// manifestDone records the manifest deleted from the repository
+func (p *sweepProgress) manifestDone(repository string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if repo, exist := p.Repositories[repository]; exist {
+		repo.ManifestsDone++
+	}
+}
+
+// blobDone records the blob deleted and the size freed up
+func (p *sweepProgress) blobDone(freed int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.BlobsDone++
+	p.BytesFreed += freed
+}
+
+// checkin reports the progress, it's skipped if the last check-in is within the interval unless forced
+func (p *sweepProgress) checkin(ctx job.Context, force bool) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if !force && time.Since(p.lastCheckin) < checkinInterval {
+		return nil
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	p.lastCheckin = time.Now()
+	return ctx.Checkin(string(data))
+}
@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/q"
+	blobModels "github.com/goharbor/harbor/src/pkg/blob/models"
+)
+
+// scope restricts the mark phase to the blobs only referenced by the project or repository,
+// the project may have been deleted, so the artifacts in the trash are matched by the repository name
+type scope struct {
+	projectID   int64
+	projectName string
+	repository  string
+	// the digests of the blobs referenced by the scope, collected in the mark phase
+	digests map[string]bool
+}
+
+// parseScope returns the scope in the parameters, nil is returned for the system wide gc
+func parseScope(params job.Parameters) *scope {
+	projectName, _ := params["project_name"].(string)
+	repository, _ := params["repository"].(string)
+	if len(projectName) == 0 && len(repository) == 0 {
+		return nil
+	}
+	s := &scope{
+		projectName: projectName,
+		repository:  repository,
+		digests:     map[string]bool{},
+	}
+	if len(s.projectName) == 0 {
+		s.projectName = strings.SplitN(repository, "/", 2)[0]
+	}
+	// the numbers in the parameters are decoded as float64
+	switch projectID := params["project_id"].(type) {
+	case float64:
+		s.projectID = int64(projectID)
+	case int64:
+		s.projectID = projectID
+	}
+	return s
+}
+
+func (s *scope) String() string {
+	if len(s.repository) > 0 {
+		return fmt.Sprintf("repository %s", s.repository)
+	}
+	return fmt.Sprintf("project %s", s.projectName)
+}
+
+// contains returns true when the repository is in the scope
+func (s *scope) contains(repository string) bool {
+	if len(s.repository) > 0 {
+		return repository == s.repository
+	}
+	return strings.HasPrefix(repository, s.projectName+"/")
+}
+
+// keywords returns the keywords to query the artifacts in the scope
+func (s *scope) keywords() q.KeyWords {
+	kw := q.KeyWords{}
+	if s.projectID > 0 {
+		kw["ProjectID"] = s.projectID
+	}
+	if len(s.repository) > 0 {
+		kw["RepositoryName"] = s.repository
+	}
+	return kw
+}
+
+// filter returns the blobs referenced by the scope
+func (s *scope) filter(blobs []*blobModels.Blob) []*blobModels.Blob {
+	var result []*blobModels.Blob
+	for _, blob := range blobs {
+		if s.digests[blob.Digest] {
+			result = append(result, blob)
+		}
+	}
+	return result
+}
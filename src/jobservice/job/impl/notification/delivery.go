@@ -0,0 +1,73 @@
+package notification
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/notification/job/model"
+)
+
+// the max length of the response body read from the receivers
+const maxResponseBodyLength = 4096
+
+// the headers whose values are never recorded in the deliveries
+var sensitiveHeaders = map[string]struct{}{
+	"Authorization": {},
+}
+
+// deliver sends the request and checks in the delivery attempt, so that core can record the request
+// and the response of every attempt. The status code of the response is returned, and the error is
+// only returned when the request cannot be sent
+func deliver(ctx job.Context, client *http.Client, req *http.Request, payload string) (int, error) {
+	delivery := &model.Delivery{
+		RequestURL:  req.URL.String(),
+		RequestBody: payload,
+	}
+	headers := map[string]string{}
+	for k := range req.Header {
+		if _, ok := sensitiveHeaders[k]; ok {
+			headers[k] = "******"
+			continue
+		}
+		headers[k] = req.Header.Get(k)
+	}
+	if data, err := json.Marshal(headers); err == nil {
+		delivery.RequestHeaders = string(data)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	delivery.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+		checkInDelivery(ctx, delivery)
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBodyLength))
+	if err != nil {
+		ctx.GetLogger().Warningf("failed to read the response body: %v", err)
+	}
+	delivery.StatusCode = resp.StatusCode
+	delivery.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	delivery.SetResponseBody(body)
+	checkInDelivery(ctx, delivery)
+	return resp.StatusCode, nil
+}
+
+// checkInDelivery checks in the delivery, the failure doesn't fail the job as the delivery is done
+func checkInDelivery(ctx job.Context, delivery *model.Delivery) {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		ctx.GetLogger().Errorf("failed to marshal the delivery: %v", err)
+		return
+	}
+	if err := ctx.Checkin(string(data)); err != nil {
+		ctx.GetLogger().Errorf("failed to check in the delivery: %v", err)
+	}
+}
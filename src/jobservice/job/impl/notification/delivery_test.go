@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goharbor/harbor/src/pkg/notification/job/model"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliver(t *testing.T) {
+	var deliveries []*model.Delivery
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("Checkin", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		delivery := &model.Delivery{}
+		require.Nil(t, json.Unmarshal([]byte(args.String(0)), delivery))
+		deliveries = append(deliveries, delivery)
+	})
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(strings.Repeat("a", maxResponseBodyLength+1)))
+		}))
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(`{"key": "value"}`))
+	require.Nil(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Content-Type", "application/json")
+	code, err := deliver(ctx, http.DefaultClient, req, `{"key": "value"}`)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusBadGateway, code)
+
+	// the request cannot be sent
+	ts.Close()
+	req, err = http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(`{"key": "value"}`))
+	require.Nil(t, err)
+	_, err = deliver(ctx, http.DefaultClient, req, `{"key": "value"}`)
+	require.NotNil(t, err)
+
+	require.Len(t, deliveries, 2)
+	assert.False(t, deliveries[0].Success)
+	assert.Equal(t, ts.URL, deliveries[0].RequestURL)
+	assert.Equal(t, `{"key": "value"}`, deliveries[0].RequestBody)
+	assert.Equal(t, `{"Authorization":"******","Content-Type":"application/json"}`, deliveries[0].RequestHeaders)
+	assert.Equal(t, http.StatusBadGateway, deliveries[0].StatusCode)
+	assert.Len(t, deliveries[0].ResponseBody, maxResponseBodyLength)
+	assert.Empty(t, deliveries[0].Error)
+
+	assert.False(t, deliveries[1].Success)
+	assert.Equal(t, 0, deliveries[1].StatusCode)
+	assert.NotEmpty(t, deliveries[1].Error)
+}
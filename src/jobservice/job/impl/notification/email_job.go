@@ -0,0 +1,185 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/utils/email"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/notification/job/model"
+)
+
+// the timeout in seconds to connect to the email server
+const emailTimeout = 60
+
+var sendEmail = email.Send
+
+// EmailJob implements the job interface, which send notification by email with the SMTP settings of Harbor.
+// The SMTP settings are read from the configurations that the job context loads from core with the secret
+// of jobservice, so that the password of the email server isn't exposed in the job parameters.
+type EmailJob struct {
+	logger logger.Interface
+}
+
+// MaxFails returns that how many times this job can fail.
+func (ej *EmailJob) MaxFails() (result uint) {
+	// Default max fails count is 10, and its max retry interval is around 3h
+	// Large enough to ensure most situations can notify successfully
+	result = 10
+	if maxFails, exist := os.LookupEnv(maxFails); exist {
+		mf, err := strconv.ParseUint(maxFails, 10, 32)
+		if err != nil {
+			logger.Warningf("Fetch email job maxFails error: %s", err.Error())
+			return result
+		}
+		result = uint(mf)
+	}
+	return result
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (ej *EmailJob) MaxCurrency() uint {
+	return 1
+}
+
+// ShouldRetry ...
+func (ej *EmailJob) ShouldRetry() bool {
+	return true
+}
+
+// Validate implements the interface in job/Interface
+func (ej *EmailJob) Validate(params job.Parameters) error {
+	if params == nil {
+		// Params are required
+		return errors.New("missing parameter of email job")
+	}
+
+	for _, key := range []string{"subject", "message"} {
+		v, ok := params[key]
+		if !ok {
+			return errors.Errorf("missing job parameter '%s'", key)
+		}
+		if _, ok = v.(string); !ok {
+			return errors.Errorf("malformed job parameter '%s', expecting string but got %s", key, reflect.TypeOf(v).String())
+		}
+	}
+	if len(recipients(params)) == 0 {
+		return errors.Errorf("missing job parameter 'recipients'")
+	}
+	return nil
+}
+
+// Run implements the interface in job/Interface
+func (ej *EmailJob) Run(ctx job.Context, params job.Parameters) error {
+	ej.logger = ctx.GetLogger()
+
+	err := ej.execute(ctx, params)
+	if err != nil {
+		ej.logger.Error(err)
+	}
+	return err
+}
+
+// execute email job
+func (ej *EmailJob) execute(ctx job.Context, params map[string]interface{}) error {
+	settings := emailSettings(ctx)
+	host := stringParam(settings, common.EmailHost)
+	if len(host) == 0 {
+		return errors.New("the email server isn't configured")
+	}
+	port, err := emailPort(settings)
+	if err != nil {
+		return err
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	to := recipients(params)
+	message := params["message"].(string)
+
+	delivery := &model.Delivery{
+		RequestURL:  fmt.Sprintf("smtp://%s", addr),
+		RequestBody: message,
+	}
+	if data, err := json.Marshal(map[string]string{"To": strings.Join(to, ","), "Subject": params["subject"].(string)}); err == nil {
+		delivery.RequestHeaders = string(data)
+	}
+	start := time.Now()
+	err = sendEmail(addr, stringParam(settings, common.EmailIdentity), stringParam(settings, common.EmailUsername),
+		stringParam(settings, common.EmailPassword), emailTimeout, boolParam(settings, common.EmailSSL),
+		boolParam(settings, common.EmailInsecure), stringParam(settings, common.EmailFrom), to, params["subject"].(string), message)
+	delivery.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	delivery.Success = err == nil
+	checkInDelivery(ctx, delivery)
+	if err != nil {
+		return fmt.Errorf("email job(server: %s) failed to send email to %v: %v", addr, to, err)
+	}
+	return nil
+}
+
+// emailSettings returns the SMTP settings in the configurations of the job context
+func emailSettings(ctx job.Context) map[string]interface{} {
+	settings := map[string]interface{}{}
+	for _, key := range []string{common.EmailHost, common.EmailPort, common.EmailUsername, common.EmailPassword,
+		common.EmailFrom, common.EmailIdentity, common.EmailSSL, common.EmailInsecure} {
+		if v, ok := ctx.Get(key); ok {
+			settings[key] = v
+		}
+	}
+	return settings
+}
+
+// emailPort returns the port of the email server, the numbers are decoded as float64 from JSON
+func emailPort(settings map[string]interface{}) (int, error) {
+	v, ok := settings[common.EmailPort]
+	if !ok {
+		return 0, errors.Errorf("missing the setting '%s'", common.EmailPort)
+	}
+	switch port := v.(type) {
+	case int:
+		return port, nil
+	case int64:
+		return int(port), nil
+	case float64:
+		return int(port), nil
+	default:
+		return 0, errors.Errorf("malformed setting '%s', expecting number but got %s", common.EmailPort, reflect.TypeOf(v).String())
+	}
+}
+
+// recipients returns the addresses which the email is sent to
+func recipients(params map[string]interface{}) []string {
+	var result []string
+	switch rs := params["recipients"].(type) {
+	case []string:
+		result = append(result, rs...)
+	case []interface{}:
+		// the job parameters are decoded from JSON
+		for _, r := range rs {
+			if recipient, ok := r.(string); ok && len(recipient) > 0 {
+				result = append(result, recipient)
+			}
+		}
+	}
+	return result
+}
+
+func stringParam(params map[string]interface{}, key string) string {
+	s, _ := params[key].(string)
+	return s
+}
+
+func boolParam(params map[string]interface{}, key string) bool {
+	b, _ := params[key].(bool)
+	return b
+}
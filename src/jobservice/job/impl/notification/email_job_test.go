@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func emailParams() job.Parameters {
+	return job.Parameters{
+		"recipients": []interface{}{"dev@example.com", "ops@example.com"},
+		"subject":    "subject",
+		"message":    "<p>message</p>",
+	}
+}
+
+// mockEmailSettings mocks the SMTP settings in the configurations of the job context
+func mockEmailSettings(ctx *mockjobservice.MockJobContext, host string) {
+	ctx.On("Get", common.EmailHost).Return(host, true)
+	// the numbers are decoded as float64 from JSON
+	ctx.On("Get", common.EmailPort).Return(float64(465), true)
+	ctx.On("Get", common.EmailUsername).Return("harbor", true)
+	ctx.On("Get", common.EmailPassword).Return("password", true)
+	ctx.On("Get", common.EmailFrom).Return("harbor <harbor@example.com>", true)
+	ctx.On("Get", common.EmailIdentity).Return(nil, false)
+	ctx.On("Get", common.EmailSSL).Return(true, true)
+	ctx.On("Get", common.EmailInsecure).Return(false, true)
+}
+
+func TestEmailJobValidate(t *testing.T) {
+	rep := &EmailJob{}
+	assert.NotNil(t, rep.Validate(nil))
+	assert.Nil(t, rep.Validate(emailParams()))
+
+	params := emailParams()
+	delete(params, "recipients")
+	assert.NotNil(t, rep.Validate(params))
+
+	params = emailParams()
+	delete(params, "subject")
+	assert.NotNil(t, rep.Validate(params))
+}
+
+func TestEmailJobRun(t *testing.T) {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+	mockEmailSettings(ctx, "smtp.example.com")
+
+	send := sendEmail
+	defer func() {
+		sendEmail = send
+	}()
+
+	sendEmail = func(addr, identity, username, password string, timeout int, tls, insecure bool,
+		from string, to []string, subject, message string) error {
+		assert.Equal(t, "smtp.example.com:465", addr)
+		assert.Equal(t, "harbor", username)
+		assert.Equal(t, "password", password)
+		assert.True(t, tls)
+		assert.False(t, insecure)
+		assert.Equal(t, "harbor <harbor@example.com>", from)
+		assert.Equal(t, []string{"dev@example.com", "ops@example.com"}, to)
+		assert.Equal(t, "subject", subject)
+		assert.Equal(t, "<p>message</p>", message)
+		return nil
+	}
+	rep := &EmailJob{}
+	assert.Nil(t, rep.Run(ctx, emailParams()))
+
+	sendEmail = func(addr, identity, username, password string, timeout int, tls, insecure bool,
+		from string, to []string, subject, message string) error {
+		return errors.New("connection refused")
+	}
+	assert.NotNil(t, rep.Run(ctx, emailParams()))
+
+	// the email server isn't configured
+	ctx = &mockjobservice.MockJobContext{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+	mockEmailSettings(ctx, "")
+	assert.NotNil(t, rep.Run(ctx, emailParams()))
+}
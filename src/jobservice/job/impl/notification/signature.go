@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The payloads of the webhooks are signed when the signing secrets are configured for the targets,
+// the receivers can verify that the payloads are sent by Harbor and are not replayed:
+//
+//  1. Read the unix timestamp in seconds from the "X-Harbor-Webhook-Timestamp" header, and reject the
+//     request if the timestamp isn't within the tolerance(e.g. 5 minutes) of the current time.
+//  2. Compute the HMAC-SHA256 of "<timestamp>.<raw request body>" with the signing secret, and encode
+//     it as the lower case hex string.
+//  3. The "X-Harbor-Webhook-Signature" header contains the comma separated signatures in the format of
+//     "v1=<signature>", accept the request if any of them equals to the computed one.
+//
+// The header contains two signatures during the rotation window of the signing secret, one is signed
+// by the current secret and the other is signed by the previous one, so the receivers can switch to
+// the new secret at any time in the window. The timestamp and the signatures are regenerated when
+// the webhook is retried.
+const (
+	// HeaderTimestamp is the header of the unix timestamp when the webhook is sent
+	HeaderTimestamp = "X-Harbor-Webhook-Timestamp"
+	// HeaderSignature is the header of the signatures of the payload
+	HeaderSignature = "X-Harbor-Webhook-Signature"
+
+	signatureVersion = "v1"
+)
+
+// Sign returns the value of the signature header for the payload sent at the timestamp
+func Sign(timestamp int64, payload []byte, secrets ...string) string {
+	var signatures []string
+	for _, secret := range secrets {
+		if len(secret) == 0 {
+			continue
+		}
+		signatures = append(signatures, fmt.Sprintf("%s=%s", signatureVersion, compute(secret, timestamp, payload)))
+	}
+	return strings.Join(signatures, ",")
+}
+
+// Verify verifies the timestamp and the signature header of the payload with the secret,
+// the timestamp should be within the tolerance of the current time
+func Verify(secret, timestamp, signature string, payload []byte, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %s: %v", timestamp, err)
+	}
+	if math.Abs(float64(time.Now().Unix()-ts)) > tolerance.Seconds() {
+		return fmt.Errorf("timestamp %s is out of the tolerance %s", timestamp, tolerance)
+	}
+
+	expected := compute(secret, ts, payload)
+	for _, s := range strings.Split(signature, ",") {
+		parts := strings.SplitN(strings.TrimSpace(s), "=", 2)
+		if len(parts) != 2 || parts[0] != signatureVersion {
+			continue
+		}
+		if hmac.Equal([]byte(parts[1]), []byte(expected)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no matched signature")
+}
+
+func compute(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"key": "value"}`)
+
+	assert.Empty(t, Sign(1, payload))
+	assert.Empty(t, Sign(1, payload, ""))
+	assert.Equal(t, "v1="+compute("secret", 1, payload), Sign(1, payload, "secret"))
+	assert.Equal(t, "v1="+compute("secret", 1, payload)+",v1="+compute("previous", 1, payload),
+		Sign(1, payload, "secret", "previous"))
+	// the signature differs with the timestamp
+	assert.NotEqual(t, Sign(1, payload, "secret"), Sign(2, payload, "secret"))
+}
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"key": "value"}`)
+	now := time.Now().Unix()
+	timestamp := strconv.FormatInt(now, 10)
+	signature := Sign(now, payload, "secret", "previous")
+
+	assert.Nil(t, Verify("secret", timestamp, signature, payload, time.Minute))
+	assert.Nil(t, Verify("previous", timestamp, signature, payload, time.Minute))
+	// wrong secret
+	assert.NotNil(t, Verify("other", timestamp, signature, payload, time.Minute))
+	// tampered payload
+	assert.NotNil(t, Verify("secret", timestamp, signature, []byte(`{"key": "other"}`), time.Minute))
+	// invalid timestamp
+	assert.NotNil(t, Verify("secret", "invalid", signature, payload, time.Minute))
+	// replayed with the original timestamp
+	old := now - 600
+	assert.NotNil(t, Verify("secret", strconv.FormatInt(old, 10), Sign(old, payload, "secret"), payload, time.Minute))
+	// replayed with a new timestamp
+	assert.NotNil(t, Verify("secret", timestamp, Sign(old, payload, "secret"), payload, time.Minute))
+}
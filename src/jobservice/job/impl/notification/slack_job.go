@@ -79,7 +79,7 @@ func (sj *SlackJob) Run(ctx job.Context, params job.Parameters) error {
 		return err
 	}
 
-	err := sj.execute(params)
+	err := sj.execute(ctx, params)
 	if err != nil {
 		sj.logger.Error(err)
 	}
@@ -105,7 +105,7 @@ func (sj *SlackJob) init(ctx job.Context, params map[string]interface{}) error {
 }
 
 // execute slack job
-func (sj *SlackJob) execute(params map[string]interface{}) error {
+func (sj *SlackJob) execute(ctx job.Context, params map[string]interface{}) error {
 	payload := params["payload"].(string)
 	address := params["address"].(string)
 
@@ -115,13 +115,12 @@ func (sj *SlackJob) execute(params map[string]interface{}) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := sj.client.Do(req)
+	code, err := deliver(ctx, sj.client, req, payload)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("slack job(target: %s) response code is %d", address, resp.StatusCode)
+	if code < 200 || code >= 300 {
+		return fmt.Errorf("slack job(target: %s) response code is %d", address, code)
 	}
 	return nil
 }
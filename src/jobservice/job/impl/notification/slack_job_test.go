@@ -3,6 +3,7 @@ package notification
 import (
 	"github.com/goharbor/harbor/src/jobservice/job"
 	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"net/http"
@@ -46,6 +47,7 @@ func TestSlackJobRun(t *testing.T) {
 	logger := &mockjobservice.MockJobLogger{}
 
 	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
 
 	rep := &SlackJob{}
 
@@ -0,0 +1,122 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// TeamsJob implements the job interface, which send notification to Microsoft Teams by the incoming webhooks.
+type TeamsJob struct {
+	client *http.Client
+	logger logger.Interface
+}
+
+// MaxFails returns that how many times this job can fail.
+func (tj *TeamsJob) MaxFails() (result uint) {
+	// Default max fails count is 10, and its max retry interval is around 3h
+	// Large enough to ensure most situations can notify successfully
+	result = 10
+	if maxFails, exist := os.LookupEnv(maxFails); exist {
+		mf, err := strconv.ParseUint(maxFails, 10, 32)
+		if err != nil {
+			logger.Warningf("Fetch teams job maxFails error: %s", err.Error())
+			return result
+		}
+		result = uint(mf)
+	}
+	return result
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (tj *TeamsJob) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry ...
+func (tj *TeamsJob) ShouldRetry() bool {
+	return true
+}
+
+// Validate implements the interface in job/Interface
+func (tj *TeamsJob) Validate(params job.Parameters) error {
+	if params == nil {
+		// Params are required
+		return errors.New("missing parameter of teams job")
+	}
+
+	payload, ok := params["payload"]
+	if !ok {
+		return errors.Errorf("missing job parameter 'payload'")
+	}
+	_, ok = payload.(string)
+	if !ok {
+		return errors.Errorf("malformed job parameter 'payload', expecting string but got %s", reflect.TypeOf(payload).String())
+	}
+
+	address, ok := params["address"]
+	if !ok {
+		return errors.Errorf("missing job parameter 'address'")
+	}
+	_, ok = address.(string)
+	if !ok {
+		return errors.Errorf("malformed job parameter 'address', expecting string but got %s", reflect.TypeOf(address).String())
+	}
+	return nil
+}
+
+// Run implements the interface in job/Interface
+func (tj *TeamsJob) Run(ctx job.Context, params job.Parameters) error {
+	if err := tj.init(ctx, params); err != nil {
+		return err
+	}
+
+	err := tj.execute(ctx, params)
+	if err != nil {
+		tj.logger.Error(err)
+	}
+	return err
+}
+
+// init teams job
+func (tj *TeamsJob) init(ctx job.Context, params map[string]interface{}) error {
+	tj.logger = ctx.GetLogger()
+
+	// default use secure transport
+	tj.client = httpHelper.clients[secure]
+	if v, ok := params["skip_cert_verify"]; ok {
+		if skipCertVerify, ok := v.(bool); ok && skipCertVerify {
+			// if skip cert verify is true, it means not verify remote cert, use insecure client
+			tj.client = httpHelper.clients[insecure]
+		}
+	}
+	return nil
+}
+
+// execute teams job
+func (tj *TeamsJob) execute(ctx job.Context, params map[string]interface{}) error {
+	payload := params["payload"].(string)
+	address := params["address"].(string)
+
+	req, err := http.NewRequest(http.MethodPost, address, bytes.NewReader([]byte(payload)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	code, err := deliver(ctx, tj.client, req, payload)
+	if err != nil {
+		return err
+	}
+	if code < 200 || code >= 300 {
+		return fmt.Errorf("teams job(target: %s) response code is %d", address, code)
+	}
+	return nil
+}
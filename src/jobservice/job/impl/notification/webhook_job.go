@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
@@ -95,15 +96,67 @@ func (wj *WebhookJob) execute(ctx job.Context, params map[string]interface{}) er
 		req.Header.Set("Authorization", v.(string))
 	}
 	req.Header.Set("Content-Type", "application/json")
+	// the headers required by the payload format, e.g. the CloudEvents attributes
+	for k, v := range headers(params) {
+		req.Header.Set(k, v)
+	}
+	if secrets := signingSecrets(params); len(secrets) > 0 {
+		timestamp := time.Now().Unix()
+		req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(HeaderSignature, Sign(timestamp, []byte(payload), secrets...))
+	}
 
-	resp, err := wj.client.Do(req)
+	code, err := deliver(ctx, wj.client, req, payload)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("webhook job(target: %s) response code is %d", address, resp.StatusCode)
+	if code < 200 || code >= 300 {
+		return fmt.Errorf("webhook job(target: %s) response code is %d", address, code)
 	}
 
 	return nil
 }
+
+// signingSecrets returns the active signing secrets, the current secret goes first
+func signingSecrets(params map[string]interface{}) []string {
+	var secrets []string
+	v, ok := params["signing_secrets"]
+	if !ok {
+		return secrets
+	}
+	switch ss := v.(type) {
+	case []string:
+		secrets = append(secrets, ss...)
+	case []interface{}:
+		// the job parameters are decoded from JSON
+		for _, s := range ss {
+			if secret, ok := s.(string); ok && len(secret) > 0 {
+				secrets = append(secrets, secret)
+			}
+		}
+	}
+	return secrets
+}
+
+// headers returns the extra headers of the request
+func headers(params map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	v, ok := params["headers"]
+	if !ok {
+		return result
+	}
+	switch hs := v.(type) {
+	case map[string]string:
+		for k, h := range hs {
+			result[k] = h
+		}
+	case map[string]interface{}:
+		// the job parameters are decoded from JSON
+		for k, h := range hs {
+			if header, ok := h.(string); ok {
+				result[k] = header
+			}
+		}
+	}
+	return result
+}
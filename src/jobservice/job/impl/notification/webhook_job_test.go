@@ -2,12 +2,14 @@ package notification
 
 import (
 	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestMaxFails(t *testing.T) {
@@ -39,6 +41,7 @@ func TestRun(t *testing.T) {
 	logger := &mockjobservice.MockJobLogger{}
 
 	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
 
 	rep := &WebhookJob{}
 
@@ -78,3 +81,60 @@ func TestRun(t *testing.T) {
 	// test incorrect webhook response
 	assert.NotNil(t, rep.Run(ctx, paramsWrong))
 }
+
+func TestRunWithSignature(t *testing.T) {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	rep := &WebhookJob{}
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			timestamp := r.Header.Get(HeaderTimestamp)
+			signature := r.Header.Get(HeaderSignature)
+
+			// both the current and the previous secrets can verify the payload
+			assert.Nil(t, Verify("current", timestamp, signature, body, 5*time.Minute))
+			assert.Nil(t, Verify("previous", timestamp, signature, body, 5*time.Minute))
+			assert.NotNil(t, Verify("other", timestamp, signature, body, 5*time.Minute))
+		}))
+	defer ts.Close()
+	params := map[string]interface{}{
+		"skip_cert_verify": true,
+		"payload":          `{"key": "value"}`,
+		"address":          ts.URL,
+		"signing_secrets":  []interface{}{"current", "previous"},
+	}
+	assert.Nil(t, rep.Run(ctx, params))
+}
+
+func TestRunWithHeaders(t *testing.T) {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	rep := &WebhookJob{}
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/cloudevents+json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "1.0", r.Header.Get("ce-specversion"))
+		}))
+	defer ts.Close()
+	params := map[string]interface{}{
+		"skip_cert_verify": true,
+		"payload":          `{"key": "value"}`,
+		"address":          ts.URL,
+		"headers": map[string]interface{}{
+			"Content-Type":   "application/cloudevents+json",
+			"ce-specversion": "1.0",
+		},
+	}
+	assert.Nil(t, rep.Run(ctx, params))
+}
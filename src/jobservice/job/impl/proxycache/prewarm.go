@@ -0,0 +1,168 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxycache
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/goharbor/harbor/src/common/http/modifier/auth"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// ParamRepository is the parameter keeping the repository to pre-warm, including the project name
+	ParamRepository = "repository"
+	// ParamReference is the parameter keeping the tag or digest to pre-warm
+	ParamReference = "reference"
+)
+
+// the media types of the manifests accepted when pulling, the OCI artifact manifest is included
+var accepts = []string{
+	v1.MediaTypeImageIndex,
+	manifestlist.MediaTypeManifestList,
+	v1.MediaTypeImageManifest,
+	schema2.MediaTypeManifest,
+	registry.MediaTypeArtifactManifest,
+	schema1.MediaTypeSignedManifest,
+	schema1.MediaTypeManifest,
+}
+
+// Prewarm pulls the artifact through the proxy cache project ahead of time, so that
+// the manifests and blobs of the artifact are cached before the clients pull it
+type Prewarm struct {
+	logger     logger.Interface
+	client     registry.Client
+	shouldStop func() bool
+}
+
+// MaxFails of the pre-warm job, it is retried as the upstream registry may be unavailable temporarily
+func (p *Prewarm) MaxFails() uint {
+	return 3
+}
+
+// MaxCurrency indicates no limitation to the concurrency of the pre-warm job
+func (p *Prewarm) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry returns true as the pulled content is cached and won't be pulled again when retrying
+func (p *Prewarm) ShouldRetry() bool {
+	return true
+}
+
+// Validate the parameters of the pre-warm job
+func (p *Prewarm) Validate(params job.Parameters) error {
+	if _, err := parseStrValue(params, ParamRepository); err != nil {
+		return err
+	}
+	_, err := parseStrValue(params, ParamReference)
+	return err
+}
+
+// Run pulls the manifest and the blobs of the artifact through the proxy cache project
+func (p *Prewarm) Run(ctx job.Context, params job.Parameters) error {
+	p.logger = ctx.GetLogger()
+	p.shouldStop = func() bool {
+		cmd, exist := ctx.OPCommand()
+		return exist && cmd == job.StopCommand
+	}
+	// the requests are authorized by the secret of jobservice and sent to core, so
+	// that they are handled by the proxy cache as the normal pull requests
+	p.client = registry.NewClientWithAuthorizer(config.GetCoreURL(), auth.NewSecretAuthorizer(config.GetAuthSecret()), false)
+
+	// ignore errors as they have been validated already
+	repository, _ := parseStrValue(params, ParamRepository)
+	reference, _ := parseStrValue(params, ParamReference)
+
+	p.logger.Infof("start to pre-warm the artifact %s:%s", repository, reference)
+	if err := p.pull(repository, reference); err != nil {
+		p.logger.Errorf("failed to pre-warm the artifact %s:%s: %v", repository, reference, err)
+		return err
+	}
+	p.logger.Infof("the artifact %s:%s pre-warmed", repository, reference)
+	return nil
+}
+
+// pull the manifest and its references recursively, the content of the blobs is discarded
+func (p *Prewarm) pull(repository, reference string) error {
+	if p.shouldStop() {
+		return nil
+	}
+	manifest, dgt, err := p.client.PullManifest(repository, reference, accepts...)
+	if err != nil {
+		return err
+	}
+	p.logger.Infof("the manifest %s of %s:%s pulled", dgt, repository, reference)
+
+	for _, desc := range manifest.References() {
+		if p.shouldStop() {
+			return nil
+		}
+		switch desc.MediaType {
+		case v1.MediaTypeImageIndex, manifestlist.MediaTypeManifestList,
+			v1.MediaTypeImageManifest, schema2.MediaTypeManifest, registry.MediaTypeArtifactManifest,
+			schema1.MediaTypeSignedManifest, schema1.MediaTypeManifest:
+			if err = p.pull(repository, desc.Digest.String()); err != nil {
+				return err
+			}
+		case schema2.MediaTypeForeignLayer:
+			// the foreign layers aren't hosted by the registry
+			p.logger.Infof("the foreign layer %s skipped", desc.Digest)
+		default:
+			if err = p.pullBlob(repository, desc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Prewarm) pullBlob(repository string, desc distribution.Descriptor) error {
+	_, blob, err := p.client.PullBlob(repository, desc.Digest.String())
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+	if _, err = io.Copy(ioutil.Discard, blob); err != nil {
+		return err
+	}
+	p.logger.Debugf("the blob %s of %s pulled", desc.Digest, repository)
+	return nil
+}
+
+// parseStrValue parses the string data of the given parameter key from the job parameters
+func parseStrValue(params job.Parameters, key string) (string, error) {
+	param, ok := params[key]
+	if !ok || param == nil {
+		return "", errors.Errorf("missing job parameter '%s'", key)
+	}
+
+	data, ok := param.(string)
+	if !ok || len(data) == 0 {
+		return "", errors.Errorf("bad job parameter '%s'", key)
+	}
+
+	return data, nil
+}
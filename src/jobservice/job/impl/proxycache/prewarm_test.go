@@ -0,0 +1,111 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxycache
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	p := &Prewarm{}
+	assert.NotNil(t, p.Validate(job.Parameters{}))
+	assert.NotNil(t, p.Validate(job.Parameters{ParamRepository: "proxy/library/hello-world"}))
+	assert.Nil(t, p.Validate(job.Parameters{ParamRepository: "proxy/library/hello-world", ParamReference: "latest"}))
+}
+
+func TestRun(t *testing.T) {
+	config := "{}"
+	layer := "layer"
+	manifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","config":{"mediaType":"%s","digest":"%s","size":%d},"layers":[{"mediaType":"%s","digest":"%s","size":%d}]}`,
+		v1.MediaTypeImageManifest, v1.MediaTypeImageConfig, digest.FromString(config), len(config),
+		v1.MediaTypeImageLayerGzip, digest.FromString(layer), len(layer))
+	sbom := "sbom"
+	artManifest := fmt.Sprintf(`{"mediaType":"%s","artifactType":"application/spdx+json","blobs":[{"mediaType":"application/spdx+json","digest":"%s","size":%d}]}`,
+		registry.MediaTypeArtifactManifest, digest.FromString(sbom), len(sbom))
+	index := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","manifests":[{"mediaType":"%s","digest":"%s","size":%d},{"mediaType":"%s","digest":"%s","size":%d}]}`,
+		v1.MediaTypeImageIndex, v1.MediaTypeImageManifest, digest.FromString(manifest), len(manifest),
+		registry.MediaTypeArtifactManifest, digest.FromString(artManifest), len(artManifest))
+
+	contents := map[string]struct {
+		mediaType string
+		content   string
+	}{
+		"/v2/proxy/library/hello-world/manifests/latest":                                     {v1.MediaTypeImageIndex, index},
+		"/v2/proxy/library/hello-world/manifests/" + digest.FromString(manifest).String():    {v1.MediaTypeImageManifest, manifest},
+		"/v2/proxy/library/hello-world/blobs/" + digest.FromString(config).String():          {"application/octet-stream", config},
+		"/v2/proxy/library/hello-world/blobs/" + digest.FromString(layer).String():           {"application/octet-stream", layer},
+		"/v2/proxy/library/hello-world/manifests/" + digest.FromString(artManifest).String(): {registry.MediaTypeArtifactManifest, artManifest},
+		"/v2/proxy/library/hello-world/blobs/" + digest.FromString(sbom).String():            {"application/octet-stream", sbom},
+	}
+
+	lock := sync.Mutex{}
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Harbor-Secret ") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		c, exist := contents[r.URL.Path]
+		if !exist {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		lock.Lock()
+		requested = append(requested, r.URL.Path)
+		lock.Unlock()
+		w.Header().Set("Content-Type", c.mediaType)
+		w.Write([]byte(c.content))
+	}))
+	defer server.Close()
+
+	require.Nil(t, os.Setenv("CORE_URL", server.URL))
+	require.Nil(t, os.Setenv("JOBSERVICE_SECRET", "secret"))
+	defer os.Unsetenv("CORE_URL")
+	defer os.Unsetenv("JOBSERVICE_SECRET")
+
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.OPCommand(""), false)
+
+	p := &Prewarm{}
+	err := p.Run(ctx, job.Parameters{ParamRepository: "proxy/library/hello-world", ParamReference: "latest"})
+	require.Nil(t, err)
+	// the index, the manifests and the blobs referenced by the manifests are all pulled
+	assert.ElementsMatch(t, []string{
+		"/v2/proxy/library/hello-world/manifests/latest",
+		"/v2/proxy/library/hello-world/manifests/" + digest.FromString(manifest).String(),
+		"/v2/proxy/library/hello-world/blobs/" + digest.FromString(config).String(),
+		"/v2/proxy/library/hello-world/blobs/" + digest.FromString(layer).String(),
+		"/v2/proxy/library/hello-world/manifests/" + digest.FromString(artManifest).String(),
+		"/v2/proxy/library/hello-world/blobs/" + digest.FromString(sbom).String(),
+	}, requested)
+
+	// not found
+	err = p.Run(ctx, job.Parameters{ParamRepository: "proxy/library/hello-world", ParamReference: "not-exist"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,115 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"strings"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/audit"
+)
+
+const (
+	// ParamRetentionHour is the parameter keeping the hours the audit logs are retained
+	ParamRetentionHour = "audit_retention_hour"
+	// ParamIncludeOperations is the parameter keeping the comma separated operations to purge,
+	// the audit logs of all the operations are purged if it's empty
+	ParamIncludeOperations = "include_operations"
+	// ParamDryRun is the parameter indicating whether to only count the audit logs to purge
+	ParamDryRun = "dry_run"
+)
+
+// Job purges the audit logs older than the retention hours
+type Job struct {
+	auditMgr audit.Manager
+}
+
+// MaxFails of the purge job, it's triggered again in the next schedule if failed
+func (j *Job) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency limits only one purge job runs at the same time
+func (j *Job) MaxCurrency() uint {
+	return 1
+}
+
+// ShouldRetry returns false as the purge job is triggered periodically
+func (j *Job) ShouldRetry() bool {
+	return false
+}
+
+// Validate the parameters of the purge job
+func (j *Job) Validate(params job.Parameters) error {
+	_, err := parseRetentionHour(params)
+	return err
+}
+
+// Run deletes the audit logs according to the parameters
+func (j *Job) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+	if cmd, ok := ctx.OPCommand(); ok && cmd.IsStop() {
+		logger.Info("received the stop signal, quit the purge job")
+		return nil
+	}
+	if j.auditMgr == nil {
+		j.auditMgr = audit.Mgr
+	}
+
+	// ignore the error as it has been validated already
+	retentionHour, _ := parseRetentionHour(params)
+	var operations []string
+	if ops, ok := params[ParamIncludeOperations].(string); ok {
+		for _, op := range strings.Split(ops, ",") {
+			if op = strings.TrimSpace(op); len(op) > 0 {
+				operations = append(operations, op)
+			}
+		}
+	}
+	dryRun, _ := params[ParamDryRun].(bool)
+
+	logger.Infof("start to purge the audit logs older than %d hours, operations: %v, dry run: %t", retentionHour, operations, dryRun)
+	n, err := j.auditMgr.Purge(ctx.SystemContext(), retentionHour, operations, dryRun)
+	if err != nil {
+		logger.Errorf("failed to purge the audit logs: %v", err)
+		return err
+	}
+	if dryRun {
+		logger.Infof("%d audit logs would be purged", n)
+		return nil
+	}
+	logger.Infof("%d audit logs purged", n)
+	return nil
+}
+
+// parseRetentionHour parses the retention hours, the numbers are float64 after the parameters are decoded from JSON
+func parseRetentionHour(params job.Parameters) (int, error) {
+	var hour int
+	switch v := params[ParamRetentionHour].(type) {
+	case int:
+		hour = v
+	case int64:
+		hour = int(v)
+	case float64:
+		hour = int(v)
+	default:
+		return 0, errors.Errorf("missing or bad job parameter '%s'", ParamRetentionHour)
+	}
+	if hour <= 0 {
+		return 0, errors.Errorf("the job parameter '%s' must be greater than 0", ParamRetentionHour)
+	}
+	return hour, nil
+}
@@ -0,0 +1,54 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purge
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	j := &Job{}
+	assert.NotNil(t, j.Validate(job.Parameters{}))
+	assert.NotNil(t, j.Validate(job.Parameters{ParamRetentionHour: "24"}))
+	assert.NotNil(t, j.Validate(job.Parameters{ParamRetentionHour: float64(0)}))
+	assert.Nil(t, j.Validate(job.Parameters{ParamRetentionHour: float64(24)}))
+	assert.Nil(t, j.Validate(job.Parameters{ParamRetentionHour: 24}))
+}
+
+func TestRun(t *testing.T) {
+	mgr := &audit.Manager{}
+	mgr.On("Purge", mock.Anything, 24, []string{"pull", "create"}, true).Return(int64(10), nil)
+	mgr.On("Purge", mock.Anything, 48, []string(nil), false).Return(int64(20), nil)
+
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.OPCommand(""), false)
+
+	j := &Job{auditMgr: mgr}
+	assert.Nil(t, j.Run(ctx, job.Parameters{
+		ParamRetentionHour:     float64(24),
+		ParamIncludeOperations: "pull, create",
+		ParamDryRun:            true,
+	}))
+	assert.Nil(t, j.Run(ctx, job.Parameters{
+		ParamRetentionHour: float64(48),
+	}))
+	mgr.AssertExpectations(t)
+}
@@ -30,8 +30,16 @@ const (
 	WebhookJob = "WEBHOOK"
 	// SlackJob : the name of the slack job in job service
 	SlackJob = "SLACK"
+	// TeamsJob : the name of the Microsoft Teams job in job service
+	TeamsJob = "TEAMS"
+	// EmailJob : the name of the email notification job in job service
+	EmailJob = "EMAIL"
 	// Retention : the name of the retention job
 	Retention = "RETENTION"
 	// P2PPreheat : the name of the P2P preheat job
 	P2PPreheat = "P2P_PREHEAT"
+	// ProxyCachePrewarm : the name of the job pulling the artifacts through the proxy cache project ahead of time
+	ProxyCachePrewarm = "PROXY_CACHE_PREWARM"
+	// PurgeAudit : the name of the job purging the audit logs
+	PurgeAudit = "PURGE_AUDIT"
 )
@@ -157,12 +157,19 @@ var (
 		{Name: common.RobotNamePrefix, Scope: UserScope, Group: BasicGroup, EnvKey: "ROBOT_NAME_PREFIX", DefaultValue: "robot$", ItemType: &StringType{}, Editable: true, Description: `The rebot account name prefix`},
 		{Name: common.NotificationEnable, Scope: UserScope, Group: BasicGroup, EnvKey: "NOTIFICATION_ENABLE", DefaultValue: "true", ItemType: &BoolType{}, Editable: true, Description: `Enable notification`},
 
+		{Name: common.AuditLogForwardEndpoint, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_ENDPOINT", DefaultValue: "", ItemType: &StringType{}, Editable: true, Description: `The comma separated endpoints the audit logs are forwarded to, "tcp://", "udp://" and "tls://" for syslog servers, "http://" and "https://" for HTTP JSON batch endpoints`},
+		{Name: common.AuditLogForwardInsecure, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_INSECURE", DefaultValue: "false", ItemType: &BoolType{}, Editable: true, Description: `Skip the certificate verification when forwarding the audit logs over TLS`},
+		{Name: common.AuditLogForwardAuthHeader, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_AUTH_HEADER", DefaultValue: "", ItemType: &PasswordType{}, Editable: true, Description: `The authorization header sent to the HTTP endpoints the audit logs are forwarded to`},
+		{Name: common.SkipAuditLogDatabase, Scope: UserScope, Group: BasicGroup, EnvKey: "SKIP_AUDIT_LOG_DATABASE", DefaultValue: "false", ItemType: &BoolType{}, Editable: true, Description: `Skip writing the audit logs into the database when the audit log forwarding is enabled`},
+
 		{Name: common.MetricEnable, Scope: SystemScope, Group: BasicGroup, EnvKey: "METRIC_ENABLE", DefaultValue: "false", ItemType: &BoolType{}, Editable: true},
 		{Name: common.MetricPort, Scope: SystemScope, Group: BasicGroup, EnvKey: "METRIC_PORT", DefaultValue: "9090", ItemType: &PortType{}, Editable: true},
 		{Name: common.MetricPath, Scope: SystemScope, Group: BasicGroup, EnvKey: "METRIC_PATH", DefaultValue: "/metrics", ItemType: &StringType{}, Editable: true},
 
 		{Name: common.QuotaPerProjectEnable, Scope: UserScope, Group: QuotaGroup, EnvKey: "QUOTA_PER_PROJECT_ENABLE", DefaultValue: "true", ItemType: &BoolType{}, Editable: true, Description: `Enable quota per project`},
 		{Name: common.StoragePerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "STORAGE_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The storage quota per project`},
+		{Name: common.CountPerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "COUNT_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The artifact count quota per project`},
+		{Name: common.RepositoryPerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "REPOSITORY_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The repository count quota per project`},
 
 		{Name: common.TraceEnabled, Scope: SystemScope, Group: BasicGroup, EnvKey: "TRACE_ENABLED", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `Enable trace`},
 		{Name: common.TraceServiceName, Scope: SystemScope, Group: BasicGroup, EnvKey: "TRACE_SERVICE_NAME", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The service name of the trace`},
@@ -59,7 +59,18 @@ type OIDCSetting struct {
 
 // QuotaSetting wraps the settings for Quota
 type QuotaSetting struct {
-	StoragePerProject int64 `json:"storage_per_project"`
+	StoragePerProject    int64 `json:"storage_per_project"`
+	CountPerProject      int64 `json:"count_per_project"`
+	RepositoryPerProject int64 `json:"repository_per_project"`
+}
+
+// AuditLogForward wraps the settings of the audit log forwarding
+type AuditLogForward struct {
+	Endpoints  []string `json:"endpoints"`
+	Insecure   bool     `json:"insecure"`
+	AuthHeader string   `json:"auth_header"`
+	// SkipDatabase is only effective when the endpoints are configured
+	SkipDatabase bool `json:"skip_database"`
 }
 
 func init() {
@@ -196,6 +196,17 @@ func NotificationEnable(ctx context.Context) bool {
 	return defaultMgr().Get(ctx, common.NotificationEnable).GetBool()
 }
 
+// AuditLogForwardSetting returns the setting of the audit log forwarding
+func AuditLogForwardSetting(ctx context.Context) *cfgModels.AuditLogForward {
+	mgr := defaultMgr()
+	return &cfgModels.AuditLogForward{
+		Endpoints:    SplitAndTrim(mgr.Get(ctx, common.AuditLogForwardEndpoint).GetString(), ","),
+		Insecure:     mgr.Get(ctx, common.AuditLogForwardInsecure).GetBool(),
+		AuthHeader:   mgr.Get(ctx, common.AuditLogForwardAuthHeader).GetString(),
+		SkipDatabase: mgr.Get(ctx, common.SkipAuditLogDatabase).GetBool(),
+	}
+}
+
 // QuotaPerProjectEnable returns a bool to indicates if quota per project enabled in harbor
 func QuotaPerProjectEnable(ctx context.Context) bool {
 	return defaultMgr().Get(ctx, common.QuotaPerProjectEnable).GetBool()
@@ -207,7 +218,9 @@ func QuotaSetting(ctx context.Context) (*cfgModels.QuotaSetting, error) {
 		return nil, err
 	}
 	return &cfgModels.QuotaSetting{
-		StoragePerProject: defaultMgr().Get(ctx, common.StoragePerProject).GetInt64(),
+		StoragePerProject:    defaultMgr().Get(ctx, common.StoragePerProject).GetInt64(),
+		CountPerProject:      defaultMgr().Get(ctx, common.CountPerProject).GetInt64(),
+		RepositoryPerProject: defaultMgr().Get(ctx, common.RepositoryPerProject).GetInt64(),
 	}, nil
 }
 
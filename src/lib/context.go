@@ -26,6 +26,7 @@ const (
 	contextKeyArtifactInfo contextKey = "artifactInfo"
 	contextKeyAuthMode     contextKey = "authMode"
 	contextKeyCarrySession contextKey = "carrySession"
+	contextKeyClientInfo   contextKey = "clientInfo"
 )
 
 // ArtifactInfo wraps the artifact info extracted from the request to "/v2/"
@@ -40,6 +41,13 @@ type ArtifactInfo struct {
 	BlobMountDigest      string
 }
 
+// ClientInfo wraps the details of the client which sends the request
+type ClientInfo struct {
+	IP         string
+	UserAgent  string
+	AuthMethod string
+}
+
 func setToContext(ctx context.Context, key contextKey, value interface{}) context.Context {
 	if ctx == nil {
 		ctx = context.Background()
@@ -112,3 +120,17 @@ func GetCarrySession(ctx context.Context) bool {
 	}
 	return carrySession
 }
+
+// WithClientInfo returns a context with the client info set
+func WithClientInfo(ctx context.Context, client ClientInfo) context.Context {
+	return setToContext(ctx, contextKeyClientInfo, client)
+}
+
+// GetClientInfo gets the client info from the context
+func GetClientInfo(ctx context.Context) (client ClientInfo) {
+	value := getFromContext(ctx, contextKeyClientInfo)
+	if value != nil {
+		client, _ = value.(ClientInfo)
+	}
+	return
+}
@@ -39,3 +39,16 @@ func TestGetAPIVersion(t *testing.T) {
 	version = GetAPIVersion(ctx)
 	assert.Equal(t, "1.0", version)
 }
+
+func TestGetClientInfo(t *testing.T) {
+	// no client info set in context
+	client := GetClientInfo(context.Background())
+	assert.Empty(t, client.IP)
+
+	// client info set in context
+	ctx := WithClientInfo(context.Background(), ClientInfo{IP: "10.0.0.1", UserAgent: "docker", AuthMethod: "basic"})
+	client = GetClientInfo(ctx)
+	assert.Equal(t, "10.0.0.1", client.IP)
+	assert.Equal(t, "docker", client.UserAgent)
+	assert.Equal(t, "basic", client.AuthMethod)
+}
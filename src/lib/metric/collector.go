@@ -12,6 +12,7 @@ func RegisterCollectors() {
 		TotalInFlightGauge,
 		TotalReqCnt,
 		TotalReqDurSummary,
+		ProxyCacheStaleServeCnt,
 	}...)
 }
 
@@ -47,4 +48,15 @@ var (
 			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 		},
 		[]string{"method", "operation"})
+
+	// ProxyCacheStaleServeCnt used to collect the number of the stale manifests served by the proxy cache projects
+	ProxyCacheStaleServeCnt = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: os.Getenv(NamespaceEnvKey),
+			Subsystem: os.Getenv(SubsystemEnvKey),
+			Name:      "proxy_cache_stale_serve_total",
+			Help:      "The total number of the stale manifests served when the upstream registry is unavailable",
+		},
+		[]string{"project"},
+	)
 )
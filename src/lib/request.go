@@ -17,7 +17,19 @@ package lib
 import (
 	"bytes"
 	"io"
+	"net"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+var (
+	trustedProxiesOnce sync.Once
+	// the networks of the proxies in front of Harbor, only the headers set by them are trusted
+	trustedProxies []*net.IPNet
 )
 
 // nopCloser is just like ioutil's, but here to let us re-read the same
@@ -64,3 +76,73 @@ func NopCloseRequest(r *http.Request) *http.Request {
 
 	return r
 }
+
+// ClientIP returns the IP of the client which sends the request. The headers set by the proxies
+// are only respected when the request comes from the trusted proxies configured by the
+// environment variable "TRUSTED_PROXIES", otherwise the remote address of the connection is used
+func ClientIP(r *http.Request) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !isTrustedProxy(remote) {
+		return remote
+	}
+	// the trusted proxy sets it with the remote address of the connection
+	if realIP := r.Header.Get("X-Real-Ip"); len(realIP) > 0 {
+		return strings.TrimSpace(realIP)
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); len(forwarded) > 0 {
+		// the proxies append the address they receive the request from, the leading entries are sent
+		// by the client and may be forged, so the last hop which isn't a trusted proxy is the client
+		hops := strings.Split(forwarded, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if i == 0 || !isTrustedProxy(hop) {
+				return hop
+			}
+		}
+	}
+	return remote
+}
+
+func isTrustedProxy(ip string) bool {
+	trustedProxiesOnce.Do(func() {
+		trustedProxies = parseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	})
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies parses the comma separated IPs or CIDRs of the trusted proxies
+func parseTrustedProxies(proxies string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, proxy := range strings.Split(proxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if len(proxy) == 0 {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			log.Warningf("invalid trusted proxy %s: %v", proxy, err)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -50,6 +51,48 @@ func (suite *NopCloseRequestTestSuite) TestReusableBody() {
 	suite.Equal([]byte("body"), body)
 }
 
+func setTrustedProxies(proxies string) {
+	trustedProxiesOnce.Do(func() {})
+	trustedProxies = parseTrustedProxies(proxies)
+}
+
+func TestClientIP(t *testing.T) {
+	setTrustedProxies("10.0.0.1, 172.18.0.0/16")
+	defer setTrustedProxies("")
+
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:12345"
+	assert.Equal(t, "10.0.0.1", ClientIP(r))
+
+	r.Header.Set("X-Forwarded-For", "10.0.0.3, 10.0.0.4")
+	assert.Equal(t, "10.0.0.4", ClientIP(r))
+
+	r.Header.Set("X-Real-Ip", "10.0.0.2")
+	assert.Equal(t, "10.0.0.2", ClientIP(r))
+}
+
+func TestClientIPForgedForwardedFor(t *testing.T) {
+	setTrustedProxies("172.18.0.0/16")
+	defer setTrustedProxies("")
+
+	// the client forges the header, and the proxies append the real address of the client
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "172.18.0.2:12345"
+	r.Header.Set("X-Forwarded-For", "1.2.3.4, 10.0.0.5, 172.18.0.3")
+	assert.Equal(t, "10.0.0.5", ClientIP(r))
+}
+
+func TestClientIPUntrustedProxy(t *testing.T) {
+	setTrustedProxies("")
+
+	// the headers sent by the client directly are ignored
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:12345"
+	r.Header.Set("X-Real-Ip", "1.2.3.4")
+	r.Header.Set("X-Forwarded-For", "1.2.3.4")
+	assert.Equal(t, "10.0.0.1", ClientIP(r))
+}
+
 func TestNopCloseRequestTestSuite(t *testing.T) {
 	suite.Run(t, &NopCloseRequestTestSuite{})
 }
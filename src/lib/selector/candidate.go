@@ -85,6 +85,11 @@ type Candidate struct {
 	CreationTime int64 `json:"create_time_second"`
 	// Labels attached with the candidate
 	Labels []string `json:"labels"`
+	// Type of the artifact
+	// "IMAGE", "CHART", "CNAB", etc.
+	ArtifactType string `json:"artifact_type"`
+	// Size of the artifact in bytes
+	Size int64 `json:"size"`
 	// Overall severity of the candidate
 	// Use severity code value here to avoid pkg dependency issue.
 	VulnerabilitySeverity uint `json:"vulnerability_severity"`
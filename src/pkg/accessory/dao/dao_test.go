@@ -0,0 +1,181 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"testing"
+
+	beegoorm "github.com/astaxie/beego/orm"
+	common_dao "github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	artdao "github.com/goharbor/harbor/src/pkg/artifact/dao"
+	"github.com/stretchr/testify/suite"
+)
+
+type daoTestSuite struct {
+	suite.Suite
+	dao         DAO
+	artDAO      artdao.DAO
+	accID       int64
+	subArtID    int64
+	signatureID int64
+	ctx         context.Context
+}
+
+func (d *daoTestSuite) SetupSuite() {
+	d.dao = New()
+	common_dao.PrepareTestForPostgresSQL()
+	d.ctx = orm.NewContext(nil, beegoorm.NewOrm())
+	d.artDAO = artdao.New()
+	subArtID, err := d.artDAO.Create(d.ctx, &artdao.Artifact{
+		Type:              "IMAGE",
+		MediaType:         "application/vnd.oci.image.config.v1+json",
+		ManifestMediaType: "application/vnd.oci.image.manifest.v1+json",
+		ProjectID:         1,
+		RepositoryID:      1000,
+		Digest:            "sha256:subject",
+	})
+	d.Require().Nil(err)
+	d.subArtID = subArtID
+	signatureID, err := d.artDAO.Create(d.ctx, &artdao.Artifact{
+		Type:              "IMAGE",
+		MediaType:         "application/vnd.oci.image.config.v1+json",
+		ManifestMediaType: "application/vnd.oci.image.manifest.v1+json",
+		ProjectID:         1,
+		RepositoryID:      1000,
+		Digest:            "sha256:signature",
+	})
+	d.Require().Nil(err)
+	d.signatureID = signatureID
+}
+
+func (d *daoTestSuite) TearDownSuite() {
+	err := d.artDAO.Delete(d.ctx, d.signatureID)
+	d.Require().Nil(err)
+	err = d.artDAO.Delete(d.ctx, d.subArtID)
+	d.Require().Nil(err)
+}
+
+func (d *daoTestSuite) SetupTest() {
+	id, err := d.dao.Create(d.ctx, &Accessory{
+		ArtifactID:        d.signatureID,
+		SubjectArtifactID: d.subArtID,
+		Type:              "signature.cosign",
+		Size:              1234,
+		Digest:            "sha256:signature",
+	})
+	d.Require().Nil(err)
+	d.accID = id
+}
+
+func (d *daoTestSuite) TearDownTest() {
+	_, err := d.dao.DeleteAccessories(d.ctx, q.New(q.KeyWords{"SubjectArtifactID": d.subArtID}))
+	d.Require().Nil(err)
+}
+
+func (d *daoTestSuite) TestCount() {
+	total, err := d.dao.Count(d.ctx, q.New(q.KeyWords{"SubjectArtifactID": d.subArtID}))
+	d.Require().Nil(err)
+	d.Equal(int64(1), total)
+}
+
+func (d *daoTestSuite) TestList() {
+	accs, err := d.dao.List(d.ctx, q.New(q.KeyWords{"SubjectArtifactID": d.subArtID}))
+	d.Require().Nil(err)
+	d.Require().Len(accs, 1)
+	d.Equal(d.accID, accs[0].ID)
+	d.Equal(d.signatureID, accs[0].ArtifactID)
+	d.Equal("signature.cosign", accs[0].Type)
+}
+
+func (d *daoTestSuite) TestGet() {
+	// get the non-exist accessory
+	_, err := d.dao.Get(d.ctx, 10000)
+	d.Require().NotNil(err)
+	d.True(errors.IsErr(err, errors.NotFoundCode))
+
+	// get the exist accessory
+	acc, err := d.dao.Get(d.ctx, d.accID)
+	d.Require().Nil(err)
+	d.Equal(d.subArtID, acc.SubjectArtifactID)
+}
+
+func (d *daoTestSuite) TestCreate() {
+	// the happy pass case is covered in Setup
+
+	// conflict
+	_, err := d.dao.Create(d.ctx, &Accessory{
+		ArtifactID:        d.signatureID,
+		SubjectArtifactID: d.subArtID,
+		Type:              "signature.cosign",
+	})
+	d.Require().NotNil(err)
+	d.True(errors.IsErr(err, errors.ConflictCode))
+
+	// violating foreign key constraint: the artifact of the accessory doesn't exist
+	_, err = d.dao.Create(d.ctx, &Accessory{
+		ArtifactID:        10000,
+		SubjectArtifactID: d.subArtID,
+		Type:              "signature.cosign",
+	})
+	d.Require().NotNil(err)
+	d.True(errors.IsErr(err, errors.ViolateForeignKeyConstraintCode))
+}
+
+func (d *daoTestSuite) TestUpdate() {
+	// the accessory pushed before its subject artifact
+	id, err := d.dao.Create(d.ctx, &Accessory{
+		ArtifactID:            d.signatureID,
+		SubjectArtifactRepo:   "library/hello-world",
+		SubjectArtifactDigest: "sha256:subject",
+		Type:                  "subject.accessory",
+	})
+	d.Require().Nil(err)
+	defer d.dao.Delete(d.ctx, id)
+
+	err = d.dao.Update(d.ctx, &Accessory{
+		ID:                id,
+		SubjectArtifactID: 10000,
+	}, "SubjectArtifactID")
+	d.Require().Nil(err)
+
+	acc, err := d.dao.Get(d.ctx, id)
+	d.Require().Nil(err)
+	d.Equal(int64(10000), acc.SubjectArtifactID)
+	d.Equal("sha256:subject", acc.SubjectArtifactDigest)
+
+	// not exist
+	err = d.dao.Update(d.ctx, &Accessory{
+		ID: 10000,
+	}, "SubjectArtifactID")
+	d.Require().NotNil(err)
+	d.True(errors.IsErr(err, errors.NotFoundCode))
+}
+
+func (d *daoTestSuite) TestDelete() {
+	// the happy pass case is covered in TearDown
+
+	// not exist
+	err := d.dao.Delete(d.ctx, 10000)
+	d.Require().NotNil(err)
+	d.True(errors.IsErr(err, errors.NotFoundCode))
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &daoTestSuite{})
+}
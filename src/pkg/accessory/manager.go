@@ -0,0 +1,184 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessory
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory/dao"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+
+	// register the accessory types
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/base"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/cosign"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/subject"
+)
+
+var (
+	// Mgr is a global accessory manager instance
+	Mgr = NewManager()
+)
+
+// Manager is the only interface of accessory module to provide the management functions for accessories
+type Manager interface {
+	// Ensure the linkage between the accessory and its subject artifact exists, creates it if it doesn't exist.
+	// The "subArtID" is 0 if the subject artifact hasn't been pushed yet
+	Ensure(ctx context.Context, subArtDigest, subArtRepo string, subArtID, artifactID, size int64, digest, accType string) error
+	// AttachSubject links the accessories that were pushed before their subject artifact to the subject
+	AttachSubject(ctx context.Context, subArtRepo, subArtDigest string, subArtID int64) error
+	// Count returns the total count of accessories according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List accessories according to the query
+	List(ctx context.Context, query *q.Query) (accs []model.Accessory, err error)
+	// Get the accessory specified by ID
+	Get(ctx context.Context, id int64) (acc model.Accessory, err error)
+	// Create the accessory and returns the ID
+	Create(ctx context.Context, accessory model.AccessoryData) (id int64, err error)
+	// Delete the accessory specified by ID
+	Delete(ctx context.Context, id int64) (err error)
+	// DeleteAccessories deletes the accessories according to the query
+	DeleteAccessories(ctx context.Context, query *q.Query) (err error)
+}
+
+// NewManager creates an instance of the default accessory manager
+func NewManager() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Ensure(ctx context.Context, subArtDigest, subArtRepo string, subArtID, artifactID, size int64, digest, accType string) error {
+	accs, err := m.dao.List(ctx, q.New(q.KeyWords{
+		"ArtifactID":            artifactID,
+		"SubjectArtifactRepo":   subArtRepo,
+		"SubjectArtifactDigest": subArtDigest,
+	}))
+	if err != nil {
+		return err
+	}
+	if len(accs) > 0 {
+		// the subject artifact is pushed after the accessory
+		if accs[0].SubjectArtifactID == 0 && subArtID != 0 {
+			return m.AttachSubject(ctx, subArtRepo, subArtDigest, subArtID)
+		}
+		return nil
+	}
+	_, err = m.Create(ctx, model.AccessoryData{
+		ArtifactID:            artifactID,
+		SubjectArtifactID:     subArtID,
+		SubjectArtifactRepo:   subArtRepo,
+		SubjectArtifactDigest: subArtDigest,
+		Size:                  size,
+		Digest:                digest,
+		Type:                  accType,
+	})
+	// the linkage may be created by another request at the same time
+	if err != nil && errors.IsConflictErr(err) {
+		return nil
+	}
+	return err
+}
+
+func (m *manager) AttachSubject(ctx context.Context, subArtRepo, subArtDigest string, subArtID int64) error {
+	accs, err := m.dao.List(ctx, q.New(q.KeyWords{
+		"SubjectArtifactRepo":   subArtRepo,
+		"SubjectArtifactDigest": subArtDigest,
+		"SubjectArtifactID":     0,
+	}))
+	if err != nil {
+		return err
+	}
+	for _, acc := range accs {
+		acc.SubjectArtifactID = subArtID
+		if err = m.dao.Update(ctx, acc, "SubjectArtifactID"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]model.Accessory, error) {
+	accsDao, err := m.dao.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var accs []model.Accessory
+	for _, accD := range accsDao {
+		acc, err := model.New(accD.Type, toData(accD))
+		if err != nil {
+			return nil, errors.New(err).WithCode(errors.BadRequestCode)
+		}
+		accs = append(accs, acc)
+	}
+	return accs, nil
+}
+
+func (m *manager) Get(ctx context.Context, id int64) (model.Accessory, error) {
+	accD, err := m.dao.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := model.New(accD.Type, toData(accD))
+	if err != nil {
+		return nil, errors.New(err).WithCode(errors.BadRequestCode)
+	}
+	return acc, nil
+}
+
+func (m *manager) Create(ctx context.Context, accessory model.AccessoryData) (int64, error) {
+	acc := &dao.Accessory{
+		ArtifactID:            accessory.ArtifactID,
+		SubjectArtifactID:     accessory.SubjectArtifactID,
+		SubjectArtifactRepo:   accessory.SubjectArtifactRepo,
+		SubjectArtifactDigest: accessory.SubjectArtifactDigest,
+		Size:                  accessory.Size,
+		Digest:                accessory.Digest,
+		Type:                  accessory.Type,
+	}
+	return m.dao.Create(ctx, acc)
+}
+
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
+
+func (m *manager) DeleteAccessories(ctx context.Context, query *q.Query) error {
+	_, err := m.dao.DeleteAccessories(ctx, query)
+	return err
+}
+
+func toData(acc *dao.Accessory) model.AccessoryData {
+	return model.AccessoryData{
+		ID:                    acc.ID,
+		ArtifactID:            acc.ArtifactID,
+		SubjectArtifactID:     acc.SubjectArtifactID,
+		SubjectArtifactRepo:   acc.SubjectArtifactRepo,
+		SubjectArtifactDigest: acc.SubjectArtifactDigest,
+		Type:                  acc.Type,
+		Size:                  acc.Size,
+		Digest:                acc.Digest,
+		CreationTime:          acc.CreationTime,
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/accessory/dao"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testingdao "github.com/goharbor/harbor/src/testing/pkg/accessory/dao"
+	"github.com/stretchr/testify/suite"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr *manager
+	dao *testingdao.DAO
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &testingdao.DAO{}
+	m.mgr = &manager{
+		dao: m.dao,
+	}
+}
+
+func (m *managerTestSuite) TestEnsure() {
+	// the linkage already exists
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Accessory{
+		{ID: 1, SubjectArtifactID: 1},
+	}, nil)
+	err := m.mgr.Ensure(context.Background(), "sha256:subject", "library/hello-world", 1, 2, 1, "sha256:digest", model.TypeCosignSignature)
+	m.Nil(err)
+	m.dao.AssertNotCalled(m.T(), "Create", mock.Anything, mock.Anything)
+
+	// reset the mock
+	m.SetupTest()
+
+	// the linkage doesn't exist
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Accessory{}, nil)
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	err = m.mgr.Ensure(context.Background(), "sha256:subject", "library/hello-world", 1, 2, 1, "sha256:digest", model.TypeCosignSignature)
+	m.Nil(err)
+	m.dao.AssertExpectations(m.T())
+
+	// reset the mock
+	m.SetupTest()
+
+	// the linkage is created by others at the same time
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Accessory{}, nil)
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(0), errors.ConflictError(nil))
+	err = m.mgr.Ensure(context.Background(), "sha256:subject", "library/hello-world", 1, 2, 1, "sha256:digest", model.TypeCosignSignature)
+	m.Nil(err)
+
+	// reset the mock
+	m.SetupTest()
+
+	// the subject artifact is pushed after the accessory
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Accessory{
+		{ID: 1},
+	}, nil)
+	m.dao.On("Update", mock.Anything, mock.Anything, "SubjectArtifactID").Return(nil)
+	err = m.mgr.Ensure(context.Background(), "sha256:subject", "library/hello-world", 1, 2, 1, "sha256:digest", model.TypeSubject)
+	m.Nil(err)
+	m.dao.AssertExpectations(m.T())
+	m.dao.AssertNotCalled(m.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (m *managerTestSuite) TestAttachSubject() {
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Accessory{
+		{ID: 1},
+		{ID: 2},
+	}, nil)
+	m.dao.On("Update", mock.Anything, mock.Anything, "SubjectArtifactID").Return(nil)
+	err := m.mgr.AttachSubject(context.Background(), "library/hello-world", "sha256:subject", 1)
+	m.Nil(err)
+	m.dao.AssertNumberOfCalls(m.T(), "Update", 2)
+}
+
+func (m *managerTestSuite) TestCount() {
+	m.dao.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
+	n, err := m.mgr.Count(context.Background(), nil)
+	m.Nil(err)
+	m.Equal(int64(1), n)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestList() {
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*dao.Accessory{
+		{
+			ID:                1,
+			ArtifactID:        2,
+			SubjectArtifactID: 1,
+			Type:              model.TypeCosignSignature,
+		},
+	}, nil)
+	accs, err := m.mgr.List(context.Background(), nil)
+	m.Nil(err)
+	m.Require().Len(accs, 1)
+	m.True(accs[0].IsHard())
+	m.Equal(model.RefHard, accs[0].Kind())
+	m.Equal(int64(2), accs[0].GetData().ArtifactID)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestGet() {
+	m.dao.On("Get", mock.Anything, mock.Anything).Return(&dao.Accessory{
+		ID:   1,
+		Type: model.TypeNone,
+	}, nil)
+	acc, err := m.mgr.Get(context.Background(), 1)
+	m.Nil(err)
+	m.False(acc.IsHard())
+	m.Equal(model.RefNone, acc.Kind())
+	m.dao.AssertExpectations(m.T())
+
+	// reset the mock
+	m.SetupTest()
+
+	// unknown accessory type
+	m.dao.On("Get", mock.Anything, mock.Anything).Return(&dao.Accessory{
+		ID:   1,
+		Type: "unknown",
+	}, nil)
+	_, err = m.mgr.Get(context.Background(), 1)
+	m.NotNil(err)
+}
+
+func (m *managerTestSuite) TestCreate() {
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	id, err := m.mgr.Create(context.Background(), model.AccessoryData{
+		ArtifactID:        2,
+		SubjectArtifactID: 1,
+		Type:              model.TypeCosignSignature,
+	})
+	m.Nil(err)
+	m.Equal(int64(1), id)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDelete() {
+	m.dao.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	err := m.mgr.Delete(context.Background(), 1)
+	m.Nil(err)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDeleteAccessories() {
+	m.dao.On("DeleteAccessories", mock.Anything, mock.Anything).Return(int64(1), nil)
+	err := m.mgr.DeleteAccessories(context.Background(), nil)
+	m.Nil(err)
+	m.dao.AssertExpectations(m.T())
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
@@ -0,0 +1,94 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// RefNone identifies base reference
+	RefNone = "base"
+	// RefSoft identifies soft reference, the accessory is kept when its subject artifact is deleted
+	RefSoft = "soft"
+	// RefHard identifies hard reference, the accessory is deleted along with its subject artifact
+	RefHard = "hard"
+)
+
+const (
+	// TypeNone is the type of the accessory that isn't recognized
+	TypeNone = "base"
+	// TypeCosignSignature is the type of the signature generated by cosign
+	TypeCosignSignature = "signature.cosign"
+	// TypeSubject is the type of the artifact that declares its subject artifact with the OCI "subject" field
+	TypeSubject = "subject.accessory"
+)
+
+// AccessoryData is the data of the accessory
+type AccessoryData struct {
+	ID                    int64     `json:"id"`
+	ArtifactID            int64     `json:"artifact_id"`
+	SubjectArtifactID     int64     `json:"subject_artifact_id"`
+	SubjectArtifactRepo   string    `json:"subject_artifact_repo"`
+	SubjectArtifactDigest string    `json:"subject_artifact_digest"`
+	Type                  string    `json:"type"`
+	Size                  int64     `json:"size"`
+	Digest                string    `json:"digest"`
+	CreationTime          time.Time `json:"creation_time"`
+}
+
+// Accessory is independent, but linked to an existing subject artifact,
+// which enables the extensibility of an OCI artifact
+type Accessory interface {
+	// Kind returns the reference kind of the accessory: base, soft or hard
+	Kind() string
+	// IsSoft returns true when the accessory is kept after its subject artifact is deleted
+	IsSoft() bool
+	// IsHard returns true when the accessory must be deleted along with its subject artifact
+	IsHard() bool
+	// GetData returns the data of the accessory
+	GetData() AccessoryData
+}
+
+// NewAccessoryFunc takes data to return an accessory instance
+type NewAccessoryFunc func(data AccessoryData) Accessory
+
+var (
+	factories = map[string]NewAccessoryFunc{}
+	lock      = &sync.RWMutex{}
+)
+
+// Register registers the factory of the specified accessory type
+func Register(typ string, factory NewAccessoryFunc) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	factories[typ] = factory
+}
+
+// New returns an instance of the accessory according to its type
+func New(typ string, data AccessoryData) (Accessory, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	factory, ok := factories[typ]
+	if !ok {
+		return nil, fmt.Errorf("accessory type %s not supported", typ)
+	}
+	data.Type = typ
+	return factory(data), nil
+}
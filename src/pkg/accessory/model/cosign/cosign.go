@@ -0,0 +1,80 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/accessory/model/base"
+)
+
+const (
+	// MediaTypeSimpleSigning is the media type of the layer that carries the cosign signature payload
+	MediaTypeSimpleSigning = "application/vnd.dev.cosign.simplesigning.v1+json"
+)
+
+var (
+	// the tag that cosign pushes the signature with, e.g. sha256-<hex of the subject digest>.sig
+	signatureTagRe = regexp.MustCompile(`^sha256-([a-f0-9]{64})\.sig$`)
+)
+
+// Signature signature model
+type Signature struct {
+	base.Default
+}
+
+// Kind gives the reference type of cosign signature.
+func (c *Signature) Kind() string {
+	return model.RefHard
+}
+
+// IsHard ...
+func (c *Signature) IsHard() bool {
+	return true
+}
+
+// New returns cosign signature
+func New(data model.AccessoryData) model.Accessory {
+	return &Signature{base.Default{
+		Data: data,
+	}}
+}
+
+// IsSignatureTag checks whether the tag is the one cosign uses to store the signature
+func IsSignatureTag(tag string) bool {
+	return signatureTagRe.MatchString(tag)
+}
+
+// SubjectDigest returns the digest of the subject artifact that the signature tag points to,
+// an empty string is returned if the tag isn't a cosign signature tag
+func SubjectDigest(tag string) string {
+	matches := signatureTagRe.FindStringSubmatch(tag)
+	if len(matches) != 2 {
+		return ""
+	}
+	return fmt.Sprintf("sha256:%s", matches[1])
+}
+
+// SignatureTag returns the tag that cosign stores the signature of the subject artifact with
+func SignatureTag(subjectDigest string) string {
+	return strings.Replace(subjectDigest, ":", "-", 1) + ".sig"
+}
+
+func init() {
+	model.Register(model.TypeCosignSignature, New)
+}
@@ -0,0 +1,58 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type cosignTestSuite struct {
+	suite.Suite
+	digest string
+}
+
+func (c *cosignTestSuite) SetupSuite() {
+	c.digest = "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+}
+
+func (c *cosignTestSuite) TestNew() {
+	acc, err := model.New(model.TypeCosignSignature, model.AccessoryData{
+		ArtifactID:        2,
+		SubjectArtifactID: 1,
+	})
+	c.Require().Nil(err)
+	c.Equal(model.RefHard, acc.Kind())
+	c.True(acc.IsHard())
+	c.False(acc.IsSoft())
+	c.Equal(model.TypeCosignSignature, acc.GetData().Type)
+}
+
+func (c *cosignTestSuite) TestSignatureTag() {
+	tag := SignatureTag(c.digest)
+	c.Equal("sha256-418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180.sig", tag)
+	c.True(IsSignatureTag(tag))
+	c.Equal(c.digest, SubjectDigest(tag))
+
+	c.False(IsSignatureTag("latest"))
+	c.False(IsSignatureTag("sha256-418fb88ec412e340.sig"))
+	c.Equal("", SubjectDigest("latest"))
+}
+
+func TestCosignTestSuite(t *testing.T) {
+	suite.Run(t, &cosignTestSuite{})
+}
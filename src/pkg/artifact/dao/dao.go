@@ -54,12 +54,13 @@ type DAO interface {
 
 const (
 	// the QuerySetter of beego doesn't support "EXISTS" directly, use qs.FilterRaw("id", "=id AND xxx") to workaround the limitation
-	// base filter: both tagged and untagged artifacts
+	// base filter: both tagged and untagged artifacts, the accessories(e.g. signatures, referrers) are excluded,
+	// except the ones whose subject artifact doesn't exist
 	both = `=id AND (
 		EXISTS (SELECT 1 FROM tag WHERE tag.artifact_id = T0.id)
 		OR 
 		NOT EXISTS (SELECT 1 FROM artifact_reference ref WHERE ref.child_id = T0.id)
-	)`
+	) AND NOT EXISTS (SELECT 1 FROM artifact_accessory aa WHERE aa.artifact_id = T0.id AND aa.subject_artifact_id <> 0)`
 	// tag filter: only untagged artifacts
 	// the "untagged" filter is based on "base" filter, so we consider the tag only
 	untagged = `=id AND NOT EXISTS(
@@ -274,7 +275,7 @@ func querySetter(ctx context.Context, query *q.Query) (beegoorm.QuerySeter, erro
 // handle q=base=*
 // when "q=base=*" is specified in the query, the base collection is the all artifacts of database,
 // otherwise the base collection is only the tagged artifacts and untagged artifacts that aren't
-// referenced by others
+// referenced by others, and the accessories of other artifacts are excluded
 func setBaseQuery(qs beegoorm.QuerySeter, query *q.Query) (beegoorm.QuerySeter, error) {
 	if query == nil || len(query.Keywords) == 0 {
 		qs = qs.FilterRaw("id", both)
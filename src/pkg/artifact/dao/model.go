@@ -32,6 +32,7 @@ type Artifact struct {
 	Type              string    `orm:"column(type)"`                // image or chart
 	MediaType         string    `orm:"column(media_type)"`          // the media type of artifact
 	ManifestMediaType string    `orm:"column(manifest_media_type)"` // the media type of manifest/index
+	ArtifactType      string    `orm:"column(artifact_type)"`       // the artifact type declared in manifest/index
 	ProjectID         int64     `orm:"column(project_id)"`          // needed for quota
 	RepositoryID      int64     `orm:"column(repository_id)"`
 	RepositoryName    string    `orm:"column(repository_name)"`
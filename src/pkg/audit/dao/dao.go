@@ -16,6 +16,10 @@ package dao
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -34,6 +38,10 @@ type DAO interface {
 	Get(ctx context.Context, id int64) (access *model.AuditLog, err error)
 	// Delete the audit log specified by ID
 	Delete(ctx context.Context, id int64) (err error)
+	// Purge the audit logs older than the retention hours, only the ones of the specified operations
+	// are purged if the operations are provided. Nothing is deleted when dry run and the count of the
+	// audit logs which would be purged is returned
+	Purge(ctx context.Context, retentionHour int, includeOperations []string, dryRun bool) (total int64, err error)
 }
 
 // New returns an instance of the default DAO
@@ -94,6 +102,13 @@ func (d *dao) Create(ctx context.Context, audit *model.AuditLog) (int64, error)
 	if len(audit.Username) > 255 {
 		audit.Username = audit.Username[:252] + "..."
 	}
+	// so do the user agent and the resource
+	if len(audit.UserAgent) > 255 {
+		audit.UserAgent = audit.UserAgent[:252] + "..."
+	}
+	if len(audit.Resource) > 1024 {
+		audit.Resource = audit.Resource[:1021] + "..."
+	}
 	id, err := ormer.Insert(audit)
 	if err != nil {
 		return 0, err
@@ -118,3 +133,32 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// Purge ...
+func (d *dao) Purge(ctx context.Context, retentionHour int, includeOperations []string, dryRun bool) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	where := "op_time < ?"
+	params := []interface{}{time.Now().Add(-time.Duration(retentionHour) * time.Hour)}
+	if len(includeOperations) > 0 {
+		where += fmt.Sprintf(" AND lower(operation) IN (%s)", orm.ParamPlaceholderForIn(len(includeOperations)))
+		for _, operation := range includeOperations {
+			params = append(params, strings.ToLower(operation))
+		}
+	}
+
+	if dryRun {
+		var count int64
+		if err = ormer.Raw("SELECT count(1) FROM audit_log WHERE "+where, params...).QueryRow(&count); err != nil {
+			return 0, err
+		}
+		return count, nil
+	}
+	result, err := ormer.Raw("DELETE FROM audit_log WHERE "+where, params...).Exec()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/audit/model"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type daoTestSuite struct {
@@ -42,6 +43,10 @@ func (d *daoTestSuite) SetupSuite() {
 		ResourceType: "artifact",
 		Resource:     "library/test-audit",
 		Username:     "admin",
+		ClientIP:     "10.0.0.1",
+		UserAgent:    "docker/20.10.7",
+		AuthMethod:   "basic",
+		StatusCode:   201,
 	})
 	d.Require().Nil(err)
 	d.auditID = artifactID
@@ -63,6 +68,16 @@ func (d *daoTestSuite) TestCount() {
 	})
 	d.Require().Nil(err)
 	d.Equal(int64(1), total)
+	total, err = d.dao.Count(d.ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"Resource":    "library/test-audit",
+			"client_ip":   "10.0.0.1",
+			"auth_method": "basic",
+			"status_code": 201,
+		},
+	})
+	d.Require().Nil(err)
+	d.Equal(int64(1), total)
 }
 
 func (d *daoTestSuite) TestList() {
@@ -157,6 +172,55 @@ func (d *daoTestSuite) TestDelete() {
 	d.Equal(errors.NotFoundCode, e.Code)
 }
 
+func (d *daoTestSuite) TestPurge() {
+	var ids []int64
+	for _, audit := range []*model.AuditLog{
+		{Operation: "pull", Resource: "library/purge", OpTime: time.Now().Add(-48 * time.Hour)},
+		{Operation: "Create", Resource: "library/purge", OpTime: time.Now().Add(-48 * time.Hour)},
+		{Operation: "pull", Resource: "library/purge", OpTime: time.Now()},
+	} {
+		audit.ResourceType = "artifact"
+		audit.Username = "admin"
+		id, err := d.dao.Create(d.ctx, audit)
+		d.Require().Nil(err)
+		ids = append(ids, id)
+	}
+	defer func() {
+		for _, id := range ids {
+			d.dao.Delete(d.ctx, id)
+		}
+	}()
+	query := &q.Query{
+		Keywords: map[string]interface{}{
+			"Resource": "library/purge",
+		},
+	}
+
+	// dry run
+	n, err := d.dao.Purge(d.ctx, 24, []string{"pull"}, true)
+	d.Require().Nil(err)
+	d.Equal(int64(1), n)
+	total, err := d.dao.Count(d.ctx, query)
+	d.Require().Nil(err)
+	d.Equal(int64(3), total)
+
+	// only the pull operations
+	n, err = d.dao.Purge(d.ctx, 24, []string{"pull"}, false)
+	d.Require().Nil(err)
+	d.Equal(int64(1), n)
+	total, err = d.dao.Count(d.ctx, query)
+	d.Require().Nil(err)
+	d.Equal(int64(2), total)
+
+	// all the operations
+	_, err = d.dao.Purge(d.ctx, 24, nil, false)
+	d.Require().Nil(err)
+	audits, err := d.dao.List(d.ctx, query)
+	d.Require().Nil(err)
+	d.Require().Len(audits, 1)
+	d.Equal(ids[2], audits[0].ID)
+}
+
 func TestDaoTestSuite(t *testing.T) {
 	suite.Run(t, &daoTestSuite{})
 }
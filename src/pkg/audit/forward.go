@@ -0,0 +1,205 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
+)
+
+var (
+	// the max count of the audit logs buffered by each forwarder, the audit logs are dropped when the buffer is full
+	forwardBufferSize = 1000
+	// the max count of the audit logs sent to the sink at one time
+	forwardBatchSize = 100
+	// the interval to send the buffered audit logs even if the batch isn't full
+	forwardFlushInterval = time.Second
+	// the options to retry sending the audit logs, they're dropped if the sink is still failed after the timeout
+	forwardRetryOptions = []retry.Option{
+		retry.InitialInterval(500 * time.Millisecond),
+		retry.MaxInterval(10 * time.Second),
+		retry.Timeout(time.Minute),
+	}
+)
+
+// sink is the destination the audit logs are forwarded to
+type sink interface {
+	// Send the audit logs and return the count of the audit logs sent successfully
+	Send(logs []*model.AuditLog) (int, error)
+	// Close the sink
+	Close() error
+}
+
+// ValidateForwardEndpoint checks whether the endpoint is supported to forward the audit logs to
+func ValidateForwardEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return errors.BadRequestError(err).WithMessage("invalid audit log forward endpoint %s", endpoint)
+	}
+	switch u.Scheme {
+	case "tcp", "udp", "tls", "http", "https":
+	default:
+		return errors.BadRequestError(nil).WithMessage("unsupported scheme of the audit log forward endpoint %s, "+
+			"only tcp, udp, tls, http and https are supported", endpoint)
+	}
+	if len(u.Host) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the host of the audit log forward endpoint %s is required", endpoint)
+	}
+	return nil
+}
+
+func newSink(endpoint string, setting *cfgModels.AuditLogForward) (sink, error) {
+	if err := ValidateForwardEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+	u, _ := url.Parse(endpoint)
+	switch u.Scheme {
+	case "http", "https":
+		return newHTTPSink(endpoint, setting.AuthHeader, setting.Insecure), nil
+	default:
+		return newSyslogSink(u.Scheme, u.Host, setting.Insecure), nil
+	}
+}
+
+// forwarder forwards the audit logs to the sink asynchronously with a bounded buffer
+type forwarder struct {
+	endpoint string
+	sink     sink
+	buffer   chan *model.AuditLog
+	done     chan struct{}
+}
+
+func newForwarder(endpoint string, sink sink) *forwarder {
+	f := &forwarder{
+		endpoint: endpoint,
+		sink:     sink,
+		buffer:   make(chan *model.AuditLog, forwardBufferSize),
+		done:     make(chan struct{}),
+	}
+	go f.run()
+	return f
+}
+
+// Forward puts the audit log into the buffer, it never blocks the caller
+func (f *forwarder) Forward(audit *model.AuditLog) {
+	select {
+	case f.buffer <- audit:
+	default:
+		log.Warningf("the buffer of the audit log forwarder for %s is full, drop the audit log: %s %s %s by %s",
+			f.endpoint, audit.Operation, audit.ResourceType, audit.Resource, audit.Username)
+	}
+}
+
+// Close stops accepting the audit logs, the buffered ones are sent before the sink is closed
+func (f *forwarder) Close() {
+	close(f.buffer)
+	<-f.done
+}
+
+func (f *forwarder) run() {
+	defer close(f.done)
+	defer func() {
+		if err := f.sink.Close(); err != nil {
+			log.Errorf("failed to close the audit log sink %s: %v", f.endpoint, err)
+		}
+	}()
+
+	ticker := time.NewTicker(forwardFlushInterval)
+	defer ticker.Stop()
+	var batch []*model.AuditLog
+	for {
+		select {
+		case audit, ok := <-f.buffer:
+			if !ok {
+				f.send(batch)
+				return
+			}
+			batch = append(batch, audit)
+			if len(batch) >= forwardBatchSize {
+				f.send(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			f.send(batch)
+			batch = nil
+		}
+	}
+}
+
+// send the audit logs with retry, only the ones haven't been sent are retried
+func (f *forwarder) send(batch []*model.AuditLog) {
+	if len(batch) == 0 {
+		return
+	}
+	err := retry.Retry(func() error {
+		n, err := f.sink.Send(batch)
+		batch = batch[n:]
+		if err != nil {
+			log.Debugf("failed to forward the audit logs to %s: %v", f.endpoint, err)
+		}
+		return err
+	}, forwardRetryOptions...)
+	if err != nil {
+		log.Errorf("failed to forward %d audit logs to %s, drop them: %v", len(batch), f.endpoint, err)
+	}
+}
+
+// forwarders maintains the forwarders according to the setting, they're rebuilt when the setting changes
+type forwarders struct {
+	sync.Mutex
+	key        string
+	forwarders []*forwarder
+}
+
+// Forward the audit log to all the endpoints configured in the setting
+func (f *forwarders) Forward(setting *cfgModels.AuditLogForward, audit *model.AuditLog) {
+	f.Lock()
+	defer f.Unlock()
+
+	key := fmt.Sprintf("%s|%t|%s", strings.Join(setting.Endpoints, ","), setting.Insecure, setting.AuthHeader)
+	if key != f.key {
+		// close the old forwarders in the background as the buffered audit logs may take a while to send
+		old := f.forwarders
+		go func() {
+			for _, fwd := range old {
+				fwd.Close()
+			}
+		}()
+		f.key = key
+		f.forwarders = nil
+		for _, endpoint := range setting.Endpoints {
+			s, err := newSink(endpoint, setting)
+			if err != nil {
+				log.Errorf("failed to create the audit log forwarder for %s: %v", endpoint, err)
+				continue
+			}
+			f.forwarders = append(f.forwarders, newForwarder(endpoint, s))
+		}
+	}
+
+	for _, fwd := range f.forwarders {
+		fwd.Forward(audit)
+	}
+}
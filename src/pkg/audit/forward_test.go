@@ -0,0 +1,249 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
+	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	forwardFlushInterval = 10 * time.Millisecond
+	forwardRetryOptions = []retry.Option{
+		retry.InitialInterval(time.Millisecond),
+		retry.MaxInterval(time.Millisecond),
+		retry.Timeout(time.Second),
+	}
+}
+
+func newAuditLog(resource string) *model.AuditLog {
+	return &model.AuditLog{
+		ProjectID:    1,
+		Operation:    "create",
+		ResourceType: "artifact",
+		Resource:     resource,
+		Username:     "admin",
+		OpTime:       time.Date(2021, 10, 1, 8, 0, 0, 0, time.UTC),
+		ClientIP:     "10.0.0.1",
+		StatusCode:   http.StatusCreated,
+	}
+}
+
+func TestValidateForwardEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"tcp://syslog:514", "udp://10.0.0.1:514", "tls://syslog:6514", "https://siem.example.com/audit"} {
+		assert.Nil(t, ValidateForwardEndpoint(endpoint), endpoint)
+	}
+	for _, endpoint := range []string{"syslog:514", "ftp://syslog:514", "tcp://", "://"} {
+		assert.NotNil(t, ValidateForwardEndpoint(endpoint), endpoint)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	var (
+		received []*model.AuditLog
+		auth     string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	s := newHTTPSink(server.URL, "Bearer token", false)
+	n, err := s.Send([]*model.AuditLog{newAuditLog("library/hello-world"), newAuditLog("library/busybox")})
+	require.Nil(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "Bearer token", auth)
+	require.Len(t, received, 2)
+	assert.Equal(t, "library/busybox", received[1].Resource)
+
+	failed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failed.Close()
+	n, err = newHTTPSink(failed.URL, "", false).Send([]*model.AuditLog{newAuditLog("library/hello-world")})
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer listener.Close()
+
+	messages := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			// octet counting framing: "LEN SP MSG"
+			length, err := reader.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(length))
+			buf := make([]byte, n)
+			if _, err = io.ReadFull(reader, buf); err != nil {
+				return
+			}
+			messages <- string(buf)
+		}
+	}()
+
+	s := newSyslogSink("tcp", listener.Addr().String(), false)
+	defer s.Close()
+	failedLog := newAuditLog("/api/v2.0/projects")
+	failedLog.StatusCode = http.StatusForbidden
+	n, err := s.Send([]*model.AuditLog{newAuditLog("library/hello-world"), failedLog})
+	require.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	msg := <-messages
+	assert.True(t, strings.HasPrefix(msg, fmt.Sprintf("<110>1 2021-10-01T08:00:00.000000Z %s harbor - audit - {", s.hostname)), msg)
+	assert.Contains(t, msg, `"resource":"library/hello-world"`)
+	msg = <-messages
+	assert.True(t, strings.HasPrefix(msg, "<108>1 "), msg)
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer conn.Close()
+
+	s := newSyslogSink("udp", conn.LocalAddr().String(), false)
+	defer s.Close()
+	n, err := s.Send([]*model.AuditLog{newAuditLog("library/hello-world")})
+	require.Nil(t, err)
+	assert.Equal(t, 1, n)
+
+	buf := make([]byte, 4096)
+	require.Nil(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	l, _, err := conn.ReadFrom(buf)
+	require.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(buf[:l]), "<110>1 "))
+}
+
+type fakeSink struct {
+	sync.Mutex
+	failures int
+	sent     []*model.AuditLog
+	closed   bool
+}
+
+func (f *fakeSink) Send(logs []*model.AuditLog) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		// only the first one is sent
+		f.sent = append(f.sent, logs[0])
+		return 1, fmt.Errorf("failed")
+	}
+	f.sent = append(f.sent, logs...)
+	return len(logs), nil
+}
+
+func (f *fakeSink) Close() error {
+	f.Lock()
+	defer f.Unlock()
+	f.closed = true
+	return nil
+}
+
+func TestForwarder(t *testing.T) {
+	s := &fakeSink{failures: 1}
+	f := newForwarder("fake", s)
+	f.Forward(newAuditLog("library/hello-world"))
+	f.Forward(newAuditLog("library/busybox"))
+	f.Forward(newAuditLog("library/nginx"))
+	f.Close()
+
+	// the ones sent before the failure aren't sent again
+	require.Len(t, s.sent, 3)
+	assert.Equal(t, "library/hello-world", s.sent[0].Resource)
+	assert.Equal(t, "library/busybox", s.sent[1].Resource)
+	assert.Equal(t, "library/nginx", s.sent[2].Resource)
+	assert.True(t, s.closed)
+}
+
+func TestForwarderBufferFull(t *testing.T) {
+	size := forwardBufferSize
+	defer func() { forwardBufferSize = size }()
+	forwardBufferSize = 1
+
+	// the forwarder isn't started, so the buffer is never consumed
+	f := &forwarder{
+		endpoint: "fake",
+		buffer:   make(chan *model.AuditLog, forwardBufferSize),
+	}
+	f.Forward(newAuditLog("library/hello-world"))
+	f.Forward(newAuditLog("library/busybox"))
+	assert.Len(t, f.buffer, 1)
+}
+
+func TestManagerForward(t *testing.T) {
+	received := make(chan []*model.AuditLog, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var logs []*model.AuditLog
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&logs))
+		received <- logs
+	}))
+	defer server.Close()
+
+	dao := &fakeDao{}
+	mgr := &manager{
+		dao: dao,
+		forwardSetting: func(ctx context.Context) *cfgModels.AuditLogForward {
+			return &cfgModels.AuditLogForward{
+				Endpoints:    []string{server.URL},
+				SkipDatabase: true,
+			}
+		},
+		forwarders: &forwarders{},
+	}
+	id, err := mgr.Create(context.Background(), newAuditLog("library/hello-world"))
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), id)
+	dao.AssertNotCalled(t, "Create")
+
+	select {
+	case logs := <-received:
+		require.Len(t, logs, 1)
+		assert.Equal(t, "library/hello-world", logs[0].Resource)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the audit log isn't forwarded")
+	}
+}
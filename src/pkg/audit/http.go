@@ -0,0 +1,77 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+)
+
+// httpSink posts the audit logs to the HTTP endpoint as a JSON array
+type httpSink struct {
+	url        string
+	authHeader string
+	client     *http.Client
+}
+
+func newHTTPSink(url, authHeader string, insecure bool) *httpSink {
+	return &httpSink{
+		url:        url,
+		authHeader: authHeader,
+		client: &http.Client{
+			Transport: commonhttp.GetHTTPTransport(commonhttp.WithInsecure(insecure)),
+			Timeout:   30 * time.Second,
+		},
+	}
+}
+
+// Send all the audit logs in one request
+func (h *httpSink) Send(logs []*model.AuditLog) (int, error) {
+	data, err := json.Marshal(logs)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(h.authHeader) > 0 {
+		req.Header.Set("Authorization", h.authHeader)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, h.url, string(body))
+	}
+	return len(logs), nil
+}
+
+// Close does nothing as the connections are managed by the transport
+func (h *httpSink) Close() error {
+	return nil
+}
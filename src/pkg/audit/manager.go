@@ -16,6 +16,9 @@ package audit
 
 import (
 	"context"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/audit/dao"
 	"github.com/goharbor/harbor/src/pkg/audit/model"
@@ -36,17 +39,24 @@ type Manager interface {
 	Create(ctx context.Context, audit *model.AuditLog) (id int64, err error)
 	// Delete the audit log specified by ID
 	Delete(ctx context.Context, id int64) (err error)
+	// Purge the audit logs older than the retention hours, only the ones of the specified operations
+	// are purged if the operations are provided. Nothing is deleted when dry run
+	Purge(ctx context.Context, retentionHour int, includeOperations []string, dryRun bool) (total int64, err error)
 }
 
 // New returns a default implementation of Manager
 func New() Manager {
 	return &manager{
-		dao: dao.New(),
+		dao:            dao.New(),
+		forwardSetting: config.AuditLogForwardSetting,
+		forwarders:     &forwarders{},
 	}
 }
 
 type manager struct {
-	dao dao.DAO
+	dao            dao.DAO
+	forwardSetting func(ctx context.Context) *cfgModels.AuditLogForward
+	forwarders     *forwarders
 }
 
 // Count ...
@@ -64,12 +74,34 @@ func (m *manager) Get(ctx context.Context, id int64) (*model.AuditLog, error) {
 	return m.dao.Get(ctx, id)
 }
 
-// Create ...
+// Create the audit log in the database and forward it to the configured endpoints asynchronously,
+// the database is skipped if it's configured when the forwarding is enabled
 func (m *manager) Create(ctx context.Context, audit *model.AuditLog) (int64, error) {
-	return m.dao.Create(ctx, audit)
+	setting := m.forwardSetting(ctx)
+	if len(setting.Endpoints) == 0 {
+		return m.dao.Create(ctx, audit)
+	}
+
+	var (
+		id  int64
+		err error
+	)
+	if !setting.SkipDatabase {
+		id, err = m.dao.Create(ctx, audit)
+	}
+	// forward a copy as the caller may change the audit log after it's created
+	forwarded := *audit
+	forwarded.ID = id
+	m.forwarders.Forward(setting, &forwarded)
+	return id, err
 }
 
 // Delete ...
 func (m *manager) Delete(ctx context.Context, id int64) error {
 	return m.dao.Delete(ctx, id)
 }
+
+// Purge ...
+func (m *manager) Purge(ctx context.Context, retentionHour int, includeOperations []string, dryRun bool) (int64, error) {
+	return m.dao.Purge(ctx, retentionHour, includeOperations, dryRun)
+}
@@ -16,6 +16,7 @@ package audit
 
 import (
 	"context"
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/audit/model"
 	"github.com/stretchr/testify/mock"
@@ -47,6 +48,10 @@ func (f *fakeDao) Delete(ctx context.Context, id int64) error {
 	args := f.Called()
 	return args.Error(0)
 }
+func (f *fakeDao) Purge(ctx context.Context, retentionHour int, includeOperations []string, dryRun bool) (int64, error) {
+	args := f.Called(retentionHour, includeOperations, dryRun)
+	return int64(args.Int(0)), args.Error(1)
+}
 
 type managerTestSuite struct {
 	suite.Suite
@@ -58,6 +63,10 @@ func (m *managerTestSuite) SetupTest() {
 	m.dao = &fakeDao{}
 	m.mgr = &manager{
 		dao: m.dao,
+		forwardSetting: func(ctx context.Context) *cfgModels.AuditLogForward {
+			return &cfgModels.AuditLogForward{}
+		},
+		forwarders: &forwarders{},
 	}
 }
 
@@ -114,6 +123,14 @@ func (m *managerTestSuite) TestDelete() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestPurge() {
+	m.dao.On("Purge", 24, []string{"pull"}, true).Return(3, nil)
+	total, err := m.mgr.Purge(nil, 24, []string{"pull"}, true)
+	m.Require().Nil(err)
+	m.Equal(int64(3), total)
+	m.dao.AssertExpectations(m.T())
+}
+
 func TestManager(t *testing.T) {
 	suite.Run(t, &managerTestSuite{})
 }
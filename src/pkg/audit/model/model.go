@@ -18,6 +18,12 @@ type AuditLog struct {
 	Resource     string    `orm:"column(resource)" json:"resource"`
 	Username     string    `orm:"column(username)"  json:"username"`
 	OpTime       time.Time `orm:"column(op_time)" json:"op_time" sort:"default:desc"`
+	ClientIP     string    `orm:"column(client_ip)" json:"client_ip"`
+	UserAgent    string    `orm:"column(user_agent)" json:"user_agent"`
+	// AuthMethod is the method the client authenticated by, e.g. basic, robot, oidc, token
+	AuthMethod string `orm:"column(auth_method)" json:"auth_method"`
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `orm:"column(status_code)" json:"status_code"`
 }
 
 // TableName for audit log
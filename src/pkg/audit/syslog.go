@@ -0,0 +1,135 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+)
+
+const (
+	// the "log audit" facility defined in RFC5424
+	syslogFacilityAudit = 13
+	syslogSeverityWarn  = 4
+	syslogSeverityInfo  = 6
+	syslogAppName       = "harbor"
+	syslogMsgID         = "audit"
+	// the timestamp format defined in RFC5424, the fractional seconds are limited to 6 digits
+	syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+	syslogTimeout    = 10 * time.Second
+)
+
+// syslogSink sends the audit logs to the syslog server in the RFC5424 format over TCP, UDP or TLS,
+// the messages sent over TCP and TLS are framed by the octet counting defined in RFC6587
+type syslogSink struct {
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	hostname  string
+	conn      net.Conn
+}
+
+func newSyslogSink(scheme, address string, insecure bool) *syslogSink {
+	hostname, err := os.Hostname()
+	if err != nil || len(hostname) == 0 {
+		hostname = "-"
+	}
+	s := &syslogSink{
+		network:  scheme,
+		address:  address,
+		hostname: hostname,
+	}
+	if scheme == "tls" {
+		s.network = "tcp"
+		s.tlsConfig = &tls.Config{
+			InsecureSkipVerify: insecure, // nolint:gosec
+		}
+	}
+	return s
+}
+
+// Send the audit logs one by one, the connection is re-established in the next sending when it's broken
+func (s *syslogSink) Send(logs []*model.AuditLog) (int, error) {
+	if s.conn == nil {
+		conn, err := s.dial()
+		if err != nil {
+			return 0, err
+		}
+		s.conn = conn
+	}
+	for i, audit := range logs {
+		msg, err := s.format(audit)
+		if err != nil {
+			return i, err
+		}
+		if s.network != "udp" {
+			msg = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+		}
+		if err = s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout)); err == nil {
+			_, err = s.conn.Write(msg)
+		}
+		if err != nil {
+			s.conn.Close()
+			s.conn = nil
+			return i, err
+		}
+	}
+	return len(logs), nil
+}
+
+// Close the connection
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *syslogSink) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	if s.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, s.network, s.address, s.tlsConfig)
+	}
+	return dialer.Dial(s.network, s.address)
+}
+
+// format the audit log as "<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG",
+// the message is the audit log in JSON
+func (s *syslogSink) format(audit *model.AuditLog) ([]byte, error) {
+	data, err := json.Marshal(audit)
+	if err != nil {
+		return nil, err
+	}
+	severity := syslogSeverityInfo
+	if audit.StatusCode >= http.StatusBadRequest {
+		severity = syslogSeverityWarn
+	}
+	opTime := audit.OpTime
+	if opTime.IsZero() {
+		opTime = time.Now()
+	}
+	header := fmt.Sprintf("<%d>1 %s %s %s - %s - ", syslogFacilityAudit*8+severity,
+		opTime.UTC().Format(syslogTimeFormat), s.hostname, syslogAppName, syslogMsgID)
+	return append([]byte(header), data...), nil
+}
@@ -18,12 +18,20 @@ import (
 	"fmt"
 	modelsv2 "github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/lib/encode/repository"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
-func (c *client) ListAllArtifacts(project, repo string) ([]*modelsv2.Artifact, error) {
+// Artifact is the artifact returned by the artifact API of core, including the scan overview
+type Artifact struct {
+	modelsv2.Artifact
+	// the summaries of the scan reports, keyed by the mime type of the reports
+	ScanOverview map[string]*vuln.NativeReportSummary `json:"scan_overview"`
+}
+
+func (c *client) ListAllArtifacts(project, repo string) ([]*Artifact, error) {
 	repo = repository.Encode(repo)
-	url := c.buildURL(fmt.Sprintf("/api/v2.0/projects/%s/repositories/%s/artifacts", project, repo))
-	var arts []*modelsv2.Artifact
+	url := c.buildURL(fmt.Sprintf("/api/v2.0/projects/%s/repositories/%s/artifacts?with_scan_overview=true", project, repo))
+	var arts []*Artifact
 	if err := c.httpclient.GetAndIteratePagination(url, &arts); err != nil {
 		return nil, err
 	}
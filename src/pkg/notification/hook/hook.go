@@ -45,13 +45,14 @@ func (hm *DefaultManager) StartHook(ctx context.Context, event *model.HookEvent,
 
 	t := time.Now()
 	id, err := hm.jobMgr.Create(ctx, &job_model.Job{
-		PolicyID:     event.PolicyID,
-		EventType:    event.EventType,
-		NotifyType:   event.Target.Type,
-		Status:       cModels.JobPending,
-		CreationTime: t,
-		UpdateTime:   t,
-		JobDetail:    string(payload),
+		PolicyID:      event.PolicyID,
+		EventType:     event.EventType,
+		NotifyType:    event.Target.Type,
+		NotifyAddress: event.Target.Address,
+		Status:        cModels.JobPending,
+		CreationTime:  t,
+		UpdateTime:    t,
+		JobDetail:     string(payload),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create the job record for notification based on policy %d: %v", event.PolicyID, err)
@@ -36,6 +36,18 @@ type DAO interface {
 
 	// DeleteByPolicyID
 	DeleteByPolicyID(ctx context.Context, policyID int64) error
+
+	// CreateDelivery records a delivery attempt of the job
+	CreateDelivery(ctx context.Context, delivery *model.Delivery) (int64, error)
+
+	// CountDeliveries ...
+	CountDeliveries(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// ListDeliveries ...
+	ListDeliveries(ctx context.Context, query *q.Query) ([]*model.Delivery, error)
+
+	// PruneDeliveries deletes the deliveries of the policy except the latest retained ones
+	PruneDeliveries(ctx context.Context, policyID int64, retain int) (int64, error)
 }
 
 // New creates a default implementation for Dao
@@ -183,3 +195,55 @@ func (d *dao) DeleteByPolicyID(ctx context.Context, policyID int64) error {
 	}
 	return nil
 }
+
+// CreateDelivery ...
+func (d *dao) CreateDelivery(ctx context.Context, delivery *model.Delivery) (int64, error) {
+	if delivery == nil {
+		return 0, errors.New("nil delivery")
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ormer.Insert(delivery)
+}
+
+// CountDeliveries ...
+func (d *dao) CountDeliveries(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Delivery{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
+
+// ListDeliveries ...
+func (d *dao) ListDeliveries(ctx context.Context, query *q.Query) ([]*model.Delivery, error) {
+	deliveries := []*model.Delivery{}
+
+	qs, err := orm.QuerySetter(ctx, &model.Delivery{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// PruneDeliveries ...
+func (d *dao) PruneDeliveries(ctx context.Context, policyID int64, retain int) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	sql := `DELETE FROM notification_delivery WHERE policy_id = ? AND id < (
+		SELECT min(id) FROM (
+			SELECT id FROM notification_delivery WHERE policy_id = ? ORDER BY id DESC LIMIT ?
+		) AS retained)`
+	res, err := ormer.Raw(sql, policyID, policyID, retain).Exec()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
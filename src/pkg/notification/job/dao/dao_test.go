@@ -49,7 +49,7 @@ type DaoTestSuite struct {
 func (suite *DaoTestSuite) SetupSuite() {
 	suite.Suite.SetupSuite()
 	suite.dao = New()
-	suite.Suite.ClearTables = []string{"notification_job"}
+	suite.Suite.ClearTables = []string{"notification_delivery", "notification_job"}
 	suite.jobs()
 }
 
@@ -182,6 +182,50 @@ func (suite *DaoTestSuite) TestGetLastTriggerJobsGroupByEventType() {
 	suite.Equal(2, len(jobs))
 }
 
+func (suite *DaoTestSuite) TestDeliveries() {
+	_, err := suite.dao.CreateDelivery(orm.Context(), nil)
+	suite.NotNil(err)
+
+	_, err = suite.dao.CreateDelivery(orm.Context(), &model.Delivery{
+		JobID:      suite.jobID1,
+		PolicyID:   1111,
+		RequestURL: "http://127.0.0.1:8080",
+		StatusCode: 500,
+	})
+	suite.Nil(err)
+	_, err = suite.dao.CreateDelivery(orm.Context(), &model.Delivery{
+		JobID:      suite.jobID1,
+		PolicyID:   1111,
+		Success:    true,
+		RequestURL: "http://127.0.0.1:8080",
+		StatusCode: 200,
+	})
+	suite.Nil(err)
+
+	query := &q.Query{
+		Keywords: map[string]interface{}{
+			"PolicyID": 1111,
+			"Success":  false,
+		},
+	}
+	total, err := suite.dao.CountDeliveries(orm.Context(), query)
+	suite.Nil(err)
+	suite.Equal(int64(1), total)
+	deliveries, err := suite.dao.ListDeliveries(orm.Context(), query)
+	suite.Nil(err)
+	suite.Require().Len(deliveries, 1)
+	suite.Equal(500, deliveries[0].StatusCode)
+
+	// only the latest one is retained
+	n, err := suite.dao.PruneDeliveries(orm.Context(), 1111, 1)
+	suite.Nil(err)
+	suite.Equal(int64(1), n)
+	deliveries, err = suite.dao.ListDeliveries(orm.Context(), &q.Query{Keywords: map[string]interface{}{"PolicyID": 1111}})
+	suite.Nil(err)
+	suite.Require().Len(deliveries, 1)
+	suite.Equal(200, deliveries[0].StatusCode)
+}
+
 func TestDaoTestSuite(t *testing.T) {
 	suite.Run(t, &DaoTestSuite{})
 }
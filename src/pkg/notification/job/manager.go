@@ -2,6 +2,8 @@ package job
 
 import (
 	"context"
+
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/notification/job/dao"
 	"github.com/goharbor/harbor/src/pkg/notification/job/model"
@@ -10,6 +12,8 @@ import (
 var (
 	// Mgr is a global variable for the default notification job
 	Mgr = NewManager()
+	// the max count of the deliveries retained for each policy, the older ones are pruned
+	maxDeliveriesPerPolicy = 1000
 )
 
 // Manager manages notification jobs recorded in database
@@ -17,6 +21,9 @@ type Manager interface {
 	// Create create a notification job
 	Create(ctx context.Context, job *model.Job) (int64, error)
 
+	// Get the notification job specified by ID
+	Get(ctx context.Context, id int64) (*model.Job, error)
+
 	// List list notification jobs
 	List(ctx context.Context, query *q.Query) ([]*model.Job, error)
 
@@ -28,6 +35,15 @@ type Manager interface {
 
 	// Count ...
 	Count(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// CreateDelivery records a delivery attempt of the notification job
+	CreateDelivery(ctx context.Context, delivery *model.Delivery) (int64, error)
+
+	// CountDeliveries ...
+	CountDeliveries(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// ListDeliveries lists the delivery attempts of the notification jobs
+	ListDeliveries(ctx context.Context, query *q.Query) ([]*model.Delivery, error)
 }
 
 var _ Manager = &manager{}
@@ -48,6 +64,11 @@ func (d *manager) Create(ctx context.Context, job *model.Job) (int64, error) {
 	return d.dao.Create(ctx, job)
 }
 
+// Get ...
+func (d *manager) Get(ctx context.Context, id int64) (*model.Job, error) {
+	return d.dao.Get(ctx, id)
+}
+
 // Count ...
 func (d *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return d.dao.Count(ctx, query)
@@ -67,3 +88,25 @@ func (d *manager) Update(ctx context.Context, job *model.Job, props ...string) e
 func (d *manager) ListJobsGroupByEventType(ctx context.Context, policyID int64) ([]*model.Job, error) {
 	return d.dao.GetLastTriggerJobsGroupByEventType(ctx, policyID)
 }
+
+// CreateDelivery records the delivery and prunes the old deliveries of the policy
+func (d *manager) CreateDelivery(ctx context.Context, delivery *model.Delivery) (int64, error) {
+	id, err := d.dao.CreateDelivery(ctx, delivery)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := d.dao.PruneDeliveries(ctx, delivery.PolicyID, maxDeliveriesPerPolicy); err != nil {
+		log.Errorf("failed to prune the deliveries of the notification policy %d: %v", delivery.PolicyID, err)
+	}
+	return id, nil
+}
+
+// CountDeliveries ...
+func (d *manager) CountDeliveries(ctx context.Context, query *q.Query) (int64, error) {
+	return d.dao.CountDeliveries(ctx, query)
+}
+
+// ListDeliveries ...
+func (d *manager) ListDeliveries(ctx context.Context, query *q.Query) ([]*model.Delivery, error) {
+	return d.dao.ListDeliveries(ctx, query)
+}
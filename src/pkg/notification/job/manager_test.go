@@ -76,6 +76,38 @@ func (m *managerTestSuite) TestListJobsGroupByEventType() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestGet() {
+	m.dao.On("Get", mock.Anything, int64(1)).Return(&model.Job{ID: 1}, nil)
+	job, err := m.mgr.Get(context.Background(), 1)
+	m.Nil(err)
+	m.Equal(int64(1), job.ID)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDeliveries() {
+	m.dao.On("CreateDelivery", mock.Anything, mock.Anything).Return(int64(1), nil)
+	m.dao.On("PruneDeliveries", mock.Anything, int64(1), maxDeliveriesPerPolicy).Return(int64(0), nil)
+	m.dao.On("CountDeliveries", mock.Anything, mock.Anything).Return(int64(1), nil)
+	m.dao.On("ListDeliveries", mock.Anything, mock.Anything).Return([]*model.Delivery{
+		{
+			ID:         1,
+			JobID:      1,
+			StatusCode: 500,
+		},
+	}, nil)
+
+	id, err := m.mgr.CreateDelivery(context.Background(), &model.Delivery{JobID: 1, PolicyID: 1})
+	m.Nil(err)
+	m.Equal(int64(1), id)
+	n, err := m.mgr.CountDeliveries(context.Background(), nil)
+	m.Nil(err)
+	m.Equal(int64(1), n)
+	deliveries, err := m.mgr.ListDeliveries(context.Background(), nil)
+	m.Nil(err)
+	m.Len(deliveries, 1)
+	m.dao.AssertExpectations(m.T())
+}
+
 func TestManager(t *testing.T) {
 	suite.Run(t, &managerTestSuite{})
 }
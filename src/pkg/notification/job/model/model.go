@@ -6,23 +6,60 @@ import (
 )
 
 func init() {
-	orm.RegisterModel(&Job{})
+	orm.RegisterModel(&Job{}, &Delivery{})
 }
 
 // Job is the model for a notification job
 type Job struct {
-	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
-	PolicyID     int64     `orm:"column(policy_id)" json:"policy_id"`
-	EventType    string    `orm:"column(event_type)" json:"event_type"`
-	NotifyType   string    `orm:"column(notify_type)" json:"notify_type"`
-	Status       string    `orm:"column(status)" json:"status"`
-	JobDetail    string    `orm:"column(job_detail)" json:"job_detail"`
-	UUID         string    `orm:"column(job_uuid)" json:"-"`
-	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
-	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time" sort:"default:desc"`
+	ID         int64  `orm:"pk;auto;column(id)" json:"id"`
+	PolicyID   int64  `orm:"column(policy_id)" json:"policy_id"`
+	EventType  string `orm:"column(event_type)" json:"event_type"`
+	NotifyType string `orm:"column(notify_type)" json:"notify_type"`
+	// NotifyAddress is the address of the target the job is sent to, used to redeliver the job to the same target
+	NotifyAddress string    `orm:"column(notify_address)" json:"notify_address"`
+	Status        string    `orm:"column(status)" json:"status"`
+	JobDetail     string    `orm:"column(job_detail)" json:"job_detail"`
+	UUID          string    `orm:"column(job_uuid)" json:"-"`
+	CreationTime  time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime    time.Time `orm:"column(update_time);auto_now" json:"update_time" sort:"default:desc"`
 }
 
 // TableName set table name for ORM.
 func (j *Job) TableName() string {
 	return "notification_job"
 }
+
+// the max length of the response body recorded in the delivery
+const maxResponseBodyLength = 4096
+
+// Delivery records a delivery attempt of the notification job, the webhook jobs check in
+// the delivery after each attempt and it is recorded when core receives the check in
+type Delivery struct {
+	ID       int64 `orm:"pk;auto;column(id)" json:"id"`
+	JobID    int64 `orm:"column(job_id)" json:"job_id"`
+	PolicyID int64 `orm:"column(policy_id)" json:"policy_id"`
+	// Success is true when the response code is 2xx
+	Success        bool   `orm:"column(success)" json:"success"`
+	RequestURL     string `orm:"column(request_url)" json:"request_url"`
+	RequestHeaders string `orm:"column(request_headers)" json:"request_headers"`
+	RequestBody    string `orm:"column(request_body)" json:"request_body"`
+	StatusCode     int    `orm:"column(status_code)" json:"status_code"`
+	ResponseBody   string `orm:"column(response_body)" json:"response_body"`
+	// Latency of the delivery in milliseconds
+	Latency      int64     `orm:"column(latency)" json:"latency"`
+	Error        string    `orm:"column(error)" json:"error"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
+}
+
+// TableName set table name for ORM.
+func (d *Delivery) TableName() string {
+	return "notification_delivery"
+}
+
+// SetResponseBody records the response body, it is truncated if it's too long
+func (d *Delivery) SetResponseBody(body []byte) {
+	if len(body) > maxResponseBodyLength {
+		body = body[:maxResponseBodyLength]
+	}
+	d.ResponseBody = string(body)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/event"
 	notifier_model "github.com/goharbor/harbor/src/pkg/notifier/model"
 
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/notification/hook"
 	"github.com/goharbor/harbor/src/pkg/notification/job"
@@ -28,6 +29,9 @@ var (
 
 	// SupportedNotifyTypes is a map to store notification type, eg. HTTP, Email etc
 	SupportedNotifyTypes map[string]struct{}
+
+	// SupportedPayloadFormats is a map to store the payload formats of the http notifications, eg. CloudEvents
+	SupportedPayloadFormats map[string]struct{}
 )
 
 // Init ...
@@ -47,6 +51,7 @@ func Init() {
 func initSupportedNotifyType() {
 	SupportedEventTypes = make(map[string]struct{}, 0)
 	SupportedNotifyTypes = make(map[string]struct{}, 0)
+	SupportedPayloadFormats = make(map[string]struct{}, 0)
 
 	eventTypes := []string{
 		event.TopicPushArtifact,
@@ -67,10 +72,20 @@ func initSupportedNotifyType() {
 		SupportedEventTypes[eventType] = struct{}{}
 	}
 
-	notifyTypes := []string{notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack}
+	notifyTypes := []string{notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack,
+		notifier_model.NotifyTypeTeams, notifier_model.NotifyTypeEmail}
 	for _, notifyType := range notifyTypes {
 		SupportedNotifyTypes[notifyType] = struct{}{}
 	}
+
+	payloadFormats := []string{
+		notifier_model.PayloadFormatDefault,
+		notifier_model.PayloadFormatCloudEvents,
+		notifier_model.PayloadFormatCloudEventsBinary,
+	}
+	for _, payloadFormat := range payloadFormats {
+		SupportedPayloadFormats[payloadFormat] = struct{}{}
+	}
 }
 
 type eventKey struct{}
@@ -79,6 +94,8 @@ type eventKey struct{}
 type EventCtx struct {
 	Events     *list.List
 	MustNotify bool
+	// Client is the details of the client which sends the request triggering the events
+	Client lib.ClientInfo
 }
 
 // NewEventCtx returns instance of EventCtx
@@ -111,6 +128,9 @@ func AddEvent(ctx context.Context, m n_event.Metadata, notify ...bool) {
 	if len(notify) != 0 {
 		e.MustNotify = notify[0]
 	}
+	if client := lib.GetClientInfo(ctx); len(client.IP) > 0 {
+		e.Client = client
+	}
 	e.Events.PushBack(m)
 	return
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/mail"
 	"time"
 
 	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/lib/encrypt"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -18,6 +20,12 @@ import (
 var (
 	// Mgr is a global variable for the default notification policies
 	Mgr = NewManager()
+
+	// SigningSecretRotationWindow is the duration in which the previous signing secret is
+	// still used to sign the payload after the secret of the target is changed
+	SigningSecretRotationWindow = 24 * time.Hour
+
+	encryptor = encrypt.Instance
 )
 
 // Manager manages the notification policies
@@ -61,7 +69,7 @@ func (m *manager) Create(ctx context.Context, policy *model.Policy) (int64, erro
 	policy.CreationTime = t
 	policy.UpdateTime = t
 
-	err := policy.ConvertToDBModel()
+	err := toDBModel(policy)
 	if err != nil {
 		return 0, err
 	}
@@ -77,7 +85,7 @@ func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Policy, er
 	}
 
 	for _, policy := range persisPolicies {
-		err := policy.ConvertFromDBModel()
+		err := fromDBModel(policy)
 		if err != nil {
 			return nil, err
 		}
@@ -101,7 +109,7 @@ func (m *manager) Get(ctx context.Context, id int64) (*model.Policy, error) {
 	if policy == nil {
 		return nil, nil
 	}
-	if err := policy.ConvertFromDBModel(); err != nil {
+	if err := fromDBModel(policy); err != nil {
 		return nil, err
 	}
 	return policy, err
@@ -118,7 +126,7 @@ func (m *manager) GetByNameAndProjectID(ctx context.Context, name string, projec
 		return nil, errors.New(nil).WithCode(errors.NotFoundCode).WithMessage("no notification policy found")
 	}
 	policy := policies[0]
-	if err := policy.ConvertFromDBModel(); err != nil {
+	if err := fromDBModel(policy); err != nil {
 		return nil, err
 	}
 	return policy, err
@@ -126,8 +134,15 @@ func (m *manager) GetByNameAndProjectID(ctx context.Context, name string, projec
 
 // Update the specified notification policy
 func (m *manager) Update(ctx context.Context, policy *model.Policy) error {
+	old, err := m.Get(ctx, policy.ID)
+	if err != nil {
+		return err
+	}
 	policy.UpdateTime = time.Now()
-	err := policy.ConvertToDBModel()
+	if old != nil {
+		rotateSigningSecrets(old.Targets, policy.Targets, policy.UpdateTime)
+	}
+	err = toDBModel(policy)
 	if err != nil {
 		return err
 	}
@@ -143,8 +158,10 @@ func (m *manager) Delete(ctx context.Context, policyID int64) error {
 func (m *manager) Test(policy *model.Policy) error {
 	for _, target := range policy.Targets {
 		switch target.Type {
-		case notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack:
+		case notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack, notifier_model.NotifyTypeTeams:
 			return m.policyHTTPTest(target.Address, target.SkipCertVerify)
+		case notifier_model.NotifyTypeEmail:
+			return m.policyEmailTest(target.Recipients())
 		default:
 			return fmt.Errorf("invalid policy target type: %s", target.Type)
 		}
@@ -173,6 +190,19 @@ func (m *manager) policyHTTPTest(address string, skipCertVerify bool) error {
 	return nil
 }
 
+func (m *manager) policyEmailTest(recipients []string) error {
+	if len(recipients) == 0 {
+		return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("no recipients")
+	}
+	for _, recipient := range recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return errors.New(err).WithCode(errors.BadRequestCode).WithMessage("invalid recipient %s", recipient)
+		}
+	}
+	log.Debugf("policy test success with recipients %v", recipients)
+	return nil
+}
+
 // GetRelatedPolices get policies including event type in project
 func (m *manager) GetRelatedPolices(ctx context.Context, projectID int64, eventType string) ([]*model.Policy, error) {
 	policies, err := m.List(ctx, q.New(q.KeyWords{"project_id": projectID}))
@@ -196,3 +226,74 @@ func (m *manager) GetRelatedPolices(ctx context.Context, projectID int64, eventT
 
 	return result, nil
 }
+
+// rotateSigningSecrets keeps the signing secrets of the targets if they aren't specified in the update,
+// and keeps the replaced secrets as the previous ones during the rotation window
+func rotateSigningSecrets(oldTargets, targets []model.EventTarget, now time.Time) {
+	for i := range targets {
+		t := &targets[i]
+		for _, old := range oldTargets {
+			if old.Address != t.Address {
+				continue
+			}
+			switch {
+			case len(t.SigningSecret) == 0 || t.SigningSecret == old.SigningSecret:
+				t.SigningSecret = old.SigningSecret
+				t.PreviousSigningSecret = old.PreviousSigningSecret
+				t.PreviousSigningSecretExpiry = old.PreviousSigningSecretExpiry
+			case len(old.SigningSecret) > 0:
+				t.PreviousSigningSecret = old.SigningSecret
+				t.PreviousSigningSecretExpiry = now.Add(SigningSecretRotationWindow).Unix()
+			}
+			break
+		}
+	}
+}
+
+// toDBModel converts the policy to the DB model with the signing secrets encrypted
+func toDBModel(policy *model.Policy) error {
+	targets := policy.Targets
+	defer func() {
+		policy.Targets = targets
+	}()
+
+	encrypted := make([]model.EventTarget, 0, len(targets))
+	for _, t := range targets {
+		var err error
+		if len(t.SigningSecret) > 0 {
+			if t.SigningSecret, err = encryptor().Encrypt(t.SigningSecret); err != nil {
+				return errors.Wrap(err, "failed to encrypt the signing secret")
+			}
+		}
+		if len(t.PreviousSigningSecret) > 0 {
+			if t.PreviousSigningSecret, err = encryptor().Encrypt(t.PreviousSigningSecret); err != nil {
+				return errors.Wrap(err, "failed to encrypt the previous signing secret")
+			}
+		}
+		encrypted = append(encrypted, t)
+	}
+	policy.Targets = encrypted
+	return policy.ConvertToDBModel()
+}
+
+// fromDBModel converts the policy from the DB model with the signing secrets decrypted
+func fromDBModel(policy *model.Policy) error {
+	if err := policy.ConvertFromDBModel(); err != nil {
+		return err
+	}
+	for i := range policy.Targets {
+		t := &policy.Targets[i]
+		var err error
+		if len(t.SigningSecret) > 0 {
+			if t.SigningSecret, err = encryptor().Decrypt(t.SigningSecret); err != nil {
+				return errors.Wrap(err, "failed to decrypt the signing secret")
+			}
+		}
+		if len(t.PreviousSigningSecret) > 0 {
+			if t.PreviousSigningSecret, err = encryptor().Decrypt(t.PreviousSigningSecret); err != nil {
+				return errors.Wrap(err, "failed to decrypt the previous signing secret")
+			}
+		}
+	}
+	return nil
+}
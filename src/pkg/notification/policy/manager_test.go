@@ -2,12 +2,14 @@ package policy
 
 import (
 	"context"
+	"github.com/goharbor/harbor/src/lib/encrypt"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/notification/policy/model"
 	"github.com/goharbor/harbor/src/testing/mock"
 	"github.com/goharbor/harbor/src/testing/pkg/notification/policy/dao"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type managerTestSuite struct {
@@ -38,12 +40,70 @@ func (m *managerTestSuite) TestDelete() {
 }
 
 func (m *managerTestSuite) TestUpdate() {
+	m.dao.On("Get", mock.Anything, mock.Anything).Return(&model.Policy{}, nil)
 	m.dao.On("Update", mock.Anything, mock.Anything).Return(nil)
 	err := m.mgr.Update(context.Background(), &model.Policy{})
 	m.Nil(err)
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestSigningSecret() {
+	key := "0123456789abcdef"
+	enc := encrypt.NewAESEncryptor(&encrypt.PresetKeyProvider{Key: key})
+	encryptor = func() encrypt.Encryptor { return enc }
+	defer func() {
+		encryptor = encrypt.Instance
+	}()
+
+	// the secret is encrypted when persisted
+	var targetsDB string
+	m.dao.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		targetsDB = args.Get(1).(*model.Policy).TargetsDB
+	}).Return(int64(1), nil)
+	policy := &model.Policy{
+		Targets: []model.EventTarget{
+			{
+				Type:          "http",
+				Address:       "http://127.0.0.1",
+				SigningSecret: "secret1",
+			},
+		},
+	}
+	_, err := m.mgr.Create(context.Background(), policy)
+	m.Require().Nil(err)
+	m.NotContains(targetsDB, "secret1")
+	m.Equal("secret1", policy.Targets[0].SigningSecret)
+
+	// the secret is decrypted when read
+	m.dao.On("Get", mock.Anything, mock.Anything).Return(&model.Policy{ID: 1, TargetsDB: targetsDB}, nil).Once()
+	policy, err = m.mgr.Get(context.Background(), 1)
+	m.Require().Nil(err)
+	m.Equal("secret1", policy.Targets[0].SigningSecret)
+
+	// the secret is kept if it isn't specified in the update
+	m.dao.On("Get", mock.Anything, mock.Anything).Return(&model.Policy{ID: 1, TargetsDB: targetsDB}, nil).Once()
+	m.dao.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	policy = &model.Policy{
+		ID: 1,
+		Targets: []model.EventTarget{
+			{
+				Type:    "http",
+				Address: "http://127.0.0.1",
+			},
+		},
+	}
+	m.Require().Nil(m.mgr.Update(context.Background(), policy))
+	m.Equal([]string{"secret1"}, policy.Targets[0].SigningSecrets(time.Now()))
+
+	// the previous secret is active in the rotation window after the secret is changed
+	m.dao.On("Get", mock.Anything, mock.Anything).Return(&model.Policy{ID: 1, TargetsDB: targetsDB}, nil).Once()
+	m.dao.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	policy.Targets[0].SigningSecret = "secret2"
+	m.Require().Nil(m.mgr.Update(context.Background(), policy))
+	m.Equal([]string{"secret2", "secret1"}, policy.Targets[0].SigningSecrets(time.Now()))
+	m.Equal([]string{"secret2"}, policy.Targets[0].SigningSecrets(time.Now().Add(SigningSecretRotationWindow)))
+}
+
 func (m *managerTestSuite) TestGet() {
 	m.dao.On("Get", mock.Anything, mock.Anything).Return(&model.Policy{
 		Name: "test_policy",
@@ -111,6 +171,24 @@ func (m *managerTestSuite) TestGetRelatedPolices() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestEmailTest() {
+	policy := &model.Policy{
+		Targets: []model.EventTarget{
+			{
+				Type:    "email",
+				Address: "dev@example.com, Ops <ops@example.com>",
+			},
+		},
+	}
+	m.Nil(m.mgr.Test(policy))
+
+	policy.Targets[0].Address = "dev@example.com,invalid"
+	m.NotNil(m.mgr.Test(policy))
+
+	policy.Targets[0].Address = ""
+	m.NotNil(m.mgr.Test(policy))
+}
+
 func TestManager(t *testing.T) {
 	suite.Run(t, &managerTestSuite{})
 }
@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"github.com/astaxie/beego/orm"
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,8 @@ type Policy struct {
 	Targets      []EventTarget `orm:"-" json:"targets"`
 	EventTypesDB string        `orm:"column(event_types)" json:"-"`
 	EventTypes   []string      `orm:"-" json:"event_types"`
+	FiltersDB    string        `orm:"column(filters)" json:"-"`
+	Filters      *Filters      `orm:"-" json:"filters,omitempty"`
 	Creator      string        `orm:"column(creator)" json:"creator"`
 	CreationTime time.Time     `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
 	UpdateTime   time.Time     `orm:"column(update_time);auto_now_add" json:"update_time"`
@@ -47,6 +50,14 @@ func (w *Policy) ConvertToDBModel() error {
 		}
 		w.EventTypesDB = string(eventTypes)
 	}
+	w.FiltersDB = ""
+	if w.Filters != nil && !w.Filters.IsEmpty() {
+		filters, err := json.Marshal(w.Filters)
+		if err != nil {
+			return err
+		}
+		w.FiltersDB = string(filters)
+	}
 
 	return nil
 }
@@ -71,13 +82,73 @@ func (w *Policy) ConvertFromDBModel() error {
 	}
 	w.EventTypes = types
 
+	if len(w.FiltersDB) != 0 {
+		filters := &Filters{}
+		if err := json.Unmarshal([]byte(w.FiltersDB), filters); err != nil {
+			return err
+		}
+		w.Filters = filters
+	}
+
 	return nil
 }
 
+// Filters of the policy, the events are notified only when they match all the filters.
+// The filters not applicable to the event are ignored, e.g. the tag filter for the quota events
+type Filters struct {
+	// Repository is the doublestar pattern of the repository name without the project name
+	Repository string `json:"repository,omitempty"`
+	// Tag is the doublestar pattern of the tag
+	Tag string `json:"tag,omitempty"`
+	// Labels are the names of the labels that the artifact must have
+	Labels []string `json:"labels,omitempty"`
+	// Severity is the minimum vulnerability severity of the scan completed events, e.g. "High"
+	Severity string `json:"severity,omitempty"`
+}
+
+// IsEmpty returns true if no filter is set
+func (f *Filters) IsEmpty() bool {
+	return len(f.Repository) == 0 && len(f.Tag) == 0 && len(f.Labels) == 0 && len(f.Severity) == 0
+}
+
 // EventTarget defines the structure of target a notification send to
 type EventTarget struct {
-	Type           string `json:"type"`
+	Type string `json:"type"`
+	// Address is the URL of the webhook, or the comma separated recipients for the email notifications
 	Address        string `json:"address"`
 	AuthHeader     string `json:"auth_header,omitempty"`
 	SkipCertVerify bool   `json:"skip_cert_verify"`
+	// PayloadFormat of the http notifications, "Default", "CloudEvents" or "CloudEventsBinary",
+	// the legacy format is used if it's empty
+	PayloadFormat string `json:"payload_format,omitempty"`
+	// SigningSecret is used to sign the payload with HMAC-SHA256, it is encrypted when persisted
+	SigningSecret string `json:"signing_secret,omitempty"`
+	// PreviousSigningSecret is the secret replaced by the current one, it is still used to sign
+	// the payload until PreviousSigningSecretExpiry to allow the receivers to rotate the secret
+	PreviousSigningSecret string `json:"previous_signing_secret,omitempty"`
+	// PreviousSigningSecretExpiry is the unix timestamp in seconds when the previous secret expires
+	PreviousSigningSecretExpiry int64 `json:"previous_signing_secret_expiry,omitempty"`
+}
+
+// Recipients returns the recipients of the email notifications
+func (t *EventTarget) Recipients() []string {
+	var recipients []string
+	for _, r := range strings.Split(t.Address, ",") {
+		if r = strings.TrimSpace(r); len(r) > 0 {
+			recipients = append(recipients, r)
+		}
+	}
+	return recipients
+}
+
+// SigningSecrets returns the active signing secrets at the specified time, the current one goes first
+func (t *EventTarget) SigningSecrets(now time.Time) []string {
+	var secrets []string
+	if len(t.SigningSecret) > 0 {
+		secrets = append(secrets, t.SigningSecret)
+	}
+	if len(t.PreviousSigningSecret) > 0 && now.Unix() < t.PreviousSigningSecretExpiry {
+		secrets = append(secrets, t.PreviousSigningSecret)
+	}
+	return secrets
 }
@@ -98,3 +98,34 @@ func TestPolicy_ConvertToDBModel(t *testing.T) {
 		})
 	}
 }
+
+func TestEventTarget_Recipients(t *testing.T) {
+	target := &EventTarget{
+		Type:    "email",
+		Address: "dev@example.com, ops@example.com,,",
+	}
+	assert.Equal(t, []string{"dev@example.com", "ops@example.com"}, target.Recipients())
+
+	target.Address = ""
+	assert.Empty(t, target.Recipients())
+}
+
+func TestPolicy_Filters(t *testing.T) {
+	policy := &Policy{
+		Filters: &Filters{
+			Repository: "nginx*",
+			Labels:     []string{"prod"},
+		},
+	}
+	require.Nil(t, policy.ConvertToDBModel())
+	assert.Equal(t, `{"repository":"nginx*","labels":["prod"]}`, policy.FiltersDB)
+
+	policy.Filters = nil
+	require.Nil(t, policy.ConvertFromDBModel())
+	assert.Equal(t, &Filters{Repository: "nginx*", Labels: []string{"prod"}}, policy.Filters)
+
+	// the empty filters aren't persisted
+	policy.Filters = &Filters{}
+	require.Nil(t, policy.ConvertToDBModel())
+	assert.Empty(t, policy.FiltersDB)
+}
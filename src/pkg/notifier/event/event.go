@@ -1,6 +1,7 @@
 package event
 
 import (
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
@@ -38,6 +39,7 @@ type Metadata interface {
 
 // HookMetaData defines hook notification related event data
 type HookMetaData struct {
+	ProjectID int64
 	PolicyID  int64
 	EventType string
 	Target    *policy_model.EventTarget
@@ -47,6 +49,7 @@ type HookMetaData struct {
 // Resolve hook metadata into hook event
 func (h *HookMetaData) Resolve(evt *Event) error {
 	data := &model.HookEvent{
+		ProjectID: h.ProjectID,
 		PolicyID:  h.PolicyID,
 		EventType: h.EventType,
 		Target:    h.Target,
@@ -58,6 +61,26 @@ func (h *HookMetaData) Resolve(evt *Event) error {
 	return nil
 }
 
+// ClientSetter is implemented by the events which record the details of the client triggering them
+type ClientSetter interface {
+	SetClient(client lib.ClientInfo, statusCode int)
+}
+
+// ClientMetadata sets the details of the client into the event resolved by the former metadata,
+// it takes no effect if the event doesn't implement the ClientSetter
+type ClientMetadata struct {
+	Client     lib.ClientInfo
+	StatusCode int
+}
+
+// Resolve sets the client details into the event data
+func (c *ClientMetadata) Resolve(evt *Event) error {
+	if setter, ok := evt.Data.(ClientSetter); ok {
+		setter.SetClient(c.Client, c.StatusCode)
+	}
+	return nil
+}
+
 // Build an event by metadata
 func (e *Event) Build(metadata ...Metadata) error {
 	for _, md := range metadata {
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"github.com/goharbor/harbor/src/lib"
 	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
 	notifierModel "github.com/goharbor/harbor/src/pkg/notifier/model"
 	"github.com/stretchr/testify/assert"
@@ -51,6 +52,35 @@ func TestHookEvent_Build(t *testing.T) {
 	}
 }
 
+type clientEvent struct {
+	client     lib.ClientInfo
+	statusCode int
+}
+
+func (c *clientEvent) SetClient(client lib.ClientInfo, statusCode int) {
+	c.client = client
+	c.statusCode = statusCode
+}
+
+func TestClientMetadata_Resolve(t *testing.T) {
+	client := &ClientMetadata{
+		Client:     lib.ClientInfo{IP: "10.0.0.1", AuthMethod: "robot"},
+		StatusCode: 201,
+	}
+
+	// the event doesn't record the client
+	evt := &Event{Data: "data"}
+	require.Nil(t, client.Resolve(evt))
+	assert.Equal(t, "data", evt.Data)
+
+	data := &clientEvent{}
+	evt = &Event{Data: data}
+	require.Nil(t, client.Resolve(evt))
+	assert.Equal(t, "10.0.0.1", data.client.IP)
+	assert.Equal(t, "robot", data.client.AuthMethod)
+	assert.Equal(t, 201, data.statusCode)
+}
+
 func TestEvent_Publish(t *testing.T) {
 	type args struct {
 		event *Event
@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/google/uuid"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	// the content type of the CloudEvents in the structured content mode
+	cloudEventsContentType = "application/cloudevents+json"
+	// the content type of the data of the CloudEvents
+	dataContentType = "application/json"
+	// the prefix of the attributes in the headers in the binary content mode
+	cloudEventsHeaderPrefix = "ce-"
+)
+
+// cloudEventTypes maps the event types to the stable types of the CloudEvents,
+// never change the existing ones as the receivers route the events by them
+var cloudEventTypes = map[string]string{
+	event.TopicCreateProject:     "harbor.project.created",
+	event.TopicDeleteProject:     "harbor.project.deleted",
+	event.TopicPushArtifact:      "harbor.artifact.pushed",
+	event.TopicPullArtifact:      "harbor.artifact.pulled",
+	event.TopicDeleteArtifact:    "harbor.artifact.deleted",
+	event.TopicDeleteRepository:  "harbor.repository.deleted",
+	event.TopicCreateTag:         "harbor.tag.created",
+	event.TopicDeleteTag:         "harbor.tag.deleted",
+	event.TopicScanningFailed:    "harbor.scan.failed",
+	event.TopicScanningStopped:   "harbor.scan.stopped",
+	event.TopicScanningCompleted: "harbor.scan.completed",
+	event.TopicQuotaWarning:      "harbor.quota.warned",
+	event.TopicQuotaExceed:       "harbor.quota.exceeded",
+	event.TopicUploadChart:       "harbor.chart.uploaded",
+	event.TopicDownloadChart:     "harbor.chart.downloaded",
+	event.TopicDeleteChart:       "harbor.chart.deleted",
+	event.TopicReplication:       "harbor.replication.status.changed",
+	event.TopicArtifactLabeled:   "harbor.artifact.labeled",
+	event.TopicTagRetention:      "harbor.tag_retention.finished",
+}
+
+// cloudEvent is the event in the format of CloudEvents 1.0, see https://github.com/cloudevents/spec
+type cloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject,omitempty"`
+	Time            string `json:"time,omitempty"`
+	DataContentType string `json:"datacontenttype,omitempty"`
+	// the extension attribute of the user who triggers the event
+	Operator string           `json:"operator,omitempty"`
+	Data     *model.EventData `json:"data,omitempty"`
+}
+
+// newCloudEvent converts the hook event to the CloudEvent
+func newCloudEvent(e *model.HookEvent) *cloudEvent {
+	ce := &cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              uuid.New().String(),
+		Source:          fmt.Sprintf("/projects/%d/webhook/policies/%d", e.ProjectID, e.PolicyID),
+		Type:            cloudEventType(e.EventType),
+		DataContentType: dataContentType,
+	}
+	if e.Payload == nil {
+		return ce
+	}
+
+	if e.Payload.OccurAt > 0 {
+		ce.Time = time.Unix(e.Payload.OccurAt, 0).UTC().Format(time.RFC3339)
+	}
+	ce.Operator = e.Payload.Operator
+	ce.Data = e.Payload.EventData
+	ce.Subject = cloudEventSubject(e.Payload.EventData)
+	return ce
+}
+
+// headers returns the attributes as the headers in the binary content mode
+func (ce *cloudEvent) headers() map[string]string {
+	headers := map[string]string{
+		"Content-Type":                          ce.DataContentType,
+		cloudEventsHeaderPrefix + "specversion": ce.SpecVersion,
+		cloudEventsHeaderPrefix + "id":          ce.ID,
+		cloudEventsHeaderPrefix + "source":      ce.Source,
+		cloudEventsHeaderPrefix + "type":        ce.Type,
+	}
+	optional := map[string]string{
+		"subject":  ce.Subject,
+		"time":     ce.Time,
+		"operator": ce.Operator,
+	}
+	for k, v := range optional {
+		if len(v) > 0 {
+			headers[cloudEventsHeaderPrefix+k] = v
+		}
+	}
+	return headers
+}
+
+func cloudEventType(eventType string) string {
+	if t, ok := cloudEventTypes[eventType]; ok {
+		return t
+	}
+	return "harbor." + strings.ToLower(eventType)
+}
+
+// cloudEventSubject returns the resource URL if there is only one resource in the event,
+// otherwise the full name of the repository
+func cloudEventSubject(data *model.EventData) string {
+	if data == nil {
+		return ""
+	}
+	if len(data.Resources) == 1 && len(data.Resources[0].ResourceURL) > 0 {
+		return data.Resources[0].ResourceURL
+	}
+	if data.Repository != nil {
+		return data.Repository.RepoFullName
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"encoding/json"
+	"testing"
+
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHookEvent(format string) *model.HookEvent {
+	return &model.HookEvent{
+		ProjectID: 1,
+		PolicyID:  2,
+		EventType: "PUSH_ARTIFACT",
+		Target: &policy_model.EventTarget{
+			Type:          "http",
+			Address:       "http://127.0.0.1:8080",
+			PayloadFormat: format,
+		},
+		Payload: &model.Payload{
+			Type:     "PUSH_ARTIFACT",
+			OccurAt:  1600000000,
+			Operator: "admin",
+			EventData: &model.EventData{
+				Resources: []*model.Resource{
+					{
+						Tag:         "latest",
+						ResourceURL: "harbor.domain/library/nginx:latest",
+					},
+				},
+				Repository: &model.Repository{
+					Name:         "nginx",
+					Namespace:    "library",
+					RepoFullName: "library/nginx",
+				},
+			},
+		},
+	}
+}
+
+func TestFormatPayloadDefault(t *testing.T) {
+	payload, headers, err := formatPayload(newHookEvent(""))
+	require.Nil(t, err)
+	assert.Empty(t, headers)
+	p := &model.Payload{}
+	require.Nil(t, json.Unmarshal(payload, p))
+	assert.Equal(t, "PUSH_ARTIFACT", p.Type)
+	assert.Equal(t, "library/nginx", p.EventData.Repository.RepoFullName)
+}
+
+func TestFormatPayloadCloudEvents(t *testing.T) {
+	payload, headers, err := formatPayload(newHookEvent(model.PayloadFormatCloudEvents))
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{"Content-Type": "application/cloudevents+json"}, headers)
+
+	ce := &cloudEvent{}
+	require.Nil(t, json.Unmarshal(payload, ce))
+	assert.Equal(t, "1.0", ce.SpecVersion)
+	assert.NotEmpty(t, ce.ID)
+	assert.Equal(t, "/projects/1/webhook/policies/2", ce.Source)
+	assert.Equal(t, "harbor.artifact.pushed", ce.Type)
+	assert.Equal(t, "harbor.domain/library/nginx:latest", ce.Subject)
+	assert.Equal(t, "2020-09-13T12:26:40Z", ce.Time)
+	assert.Equal(t, "application/json", ce.DataContentType)
+	assert.Equal(t, "admin", ce.Operator)
+	require.NotNil(t, ce.Data)
+	assert.Equal(t, "library/nginx", ce.Data.Repository.RepoFullName)
+}
+
+func TestFormatPayloadCloudEventsBinary(t *testing.T) {
+	payload, headers, err := formatPayload(newHookEvent(model.PayloadFormatCloudEventsBinary))
+	require.Nil(t, err)
+	assert.Equal(t, "application/json", headers["Content-Type"])
+	assert.Equal(t, "1.0", headers["ce-specversion"])
+	assert.NotEmpty(t, headers["ce-id"])
+	assert.Equal(t, "/projects/1/webhook/policies/2", headers["ce-source"])
+	assert.Equal(t, "harbor.artifact.pushed", headers["ce-type"])
+	assert.Equal(t, "harbor.domain/library/nginx:latest", headers["ce-subject"])
+	assert.Equal(t, "2020-09-13T12:26:40Z", headers["ce-time"])
+	assert.Equal(t, "admin", headers["ce-operator"])
+
+	data := &model.EventData{}
+	require.Nil(t, json.Unmarshal(payload, data))
+	assert.Equal(t, "library/nginx", data.Repository.RepoFullName)
+}
+
+func TestCloudEventType(t *testing.T) {
+	assert.Equal(t, "harbor.scan.completed", cloudEventType("SCANNING_COMPLETED"))
+	assert.Equal(t, "harbor.quota.exceeded", cloudEventType("QUOTA_EXCEED"))
+	assert.Equal(t, "harbor.unknown_event", cloudEventType("UNKNOWN_EVENT"))
+}
+
+func TestCloudEventSubject(t *testing.T) {
+	assert.Empty(t, cloudEventSubject(nil))
+	assert.Equal(t, "library/nginx", cloudEventSubject(&model.EventData{
+		Resources: []*model.Resource{
+			{ResourceURL: "harbor.domain/library/nginx:1"},
+			{ResourceURL: "harbor.domain/library/nginx:2"},
+		},
+		Repository: &model.Repository{RepoFullName: "library/nginx"},
+	}))
+}
@@ -0,0 +1,209 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+// the subjects of the emails per event type, the generic subject is used for the event types not listed
+var emailSubjects = map[string]string{
+	event.TopicCreateProject:     `[Harbor] Project {{.Project}} created`,
+	event.TopicDeleteProject:     `[Harbor] Project {{.Project}} deleted`,
+	event.TopicPushArtifact:      `[Harbor] Artifact pushed to {{.Repository}}`,
+	event.TopicPullArtifact:      `[Harbor] Artifact pulled from {{.Repository}}`,
+	event.TopicDeleteArtifact:    `[Harbor] Artifact deleted from {{.Repository}}`,
+	event.TopicDeleteRepository:  `[Harbor] Repository {{.Repository}} deleted`,
+	event.TopicCreateTag:         `[Harbor] Tag created in {{.Repository}}`,
+	event.TopicDeleteTag:         `[Harbor] Tag deleted from {{.Repository}}`,
+	event.TopicScanningFailed:    `[Harbor] Scanning of {{.Repository}} failed`,
+	event.TopicScanningStopped:   `[Harbor] Scanning of {{.Repository}} stopped`,
+	event.TopicScanningCompleted: `[Harbor] Scanning of {{.Repository}} completed`,
+	event.TopicQuotaWarning:      `[Harbor] Quota of project {{.Project}} is nearly exceeded`,
+	event.TopicQuotaExceed:       `[Harbor] Quota of project {{.Project}} exceeded`,
+	event.TopicUploadChart:       `[Harbor] Chart uploaded to {{.Repository}}`,
+	event.TopicDownloadChart:     `[Harbor] Chart downloaded from {{.Repository}}`,
+	event.TopicDeleteChart:       `[Harbor] Chart deleted from {{.Repository}}`,
+	event.TopicReplication:       `[Harbor] Replication {{.Status}}`,
+	event.TopicTagRetention:      `[Harbor] Tag retention of project {{.Project}} {{.Status}}`,
+}
+
+const (
+	emailGenericSubject = `[Harbor] {{.Type}} event`
+
+	// emailBodyTemplate is rendered by html/template, so the values are escaped
+	emailBodyTemplate = `<h3>{{.Subject}}</h3>
+<table>
+<tr><td><b>Event type</b></td><td>{{.Type}}</td></tr>
+<tr><td><b>Occur at</b></td><td>{{.OccurAt}}</td></tr>
+<tr><td><b>Operator</b></td><td>{{.Operator}}</td></tr>
+{{- if .Project}}
+<tr><td><b>Project</b></td><td>{{.Project}}</td></tr>
+{{- end}}
+{{- if .Repository}}
+<tr><td><b>Repository</b></td><td>{{.Repository}}</td></tr>
+{{- end}}
+{{- range .Data.Resources}}
+<tr><td><b>Resource</b></td><td>{{.ResourceURL}}{{if .Digest}} ({{.Digest}}){{end}}</td></tr>
+{{- end}}
+{{- with .Data.Replication}}
+<tr><td><b>Replication status</b></td><td>{{.JobStatus}}</td></tr>
+{{- range .SuccessfulArtifact}}
+<tr><td><b>Replicated</b></td><td>{{.NameAndTag}}</td></tr>
+{{- end}}
+{{- range .FailedArtifact}}
+<tr><td><b>Failed</b></td><td>{{.NameAndTag}} {{.FailReason}}</td></tr>
+{{- end}}
+{{- end}}
+{{- with .Data.Retention}}
+<tr><td><b>Retention result</b></td><td>{{.Status}}, {{.Retained}} of {{.Total}} retained</td></tr>
+{{- range .DeletedArtifact}}
+<tr><td><b>Deleted</b></td><td>{{.NameAndTag}}</td></tr>
+{{- end}}
+{{- end}}
+{{- range $key, $value := .Data.Custom}}
+<tr><td><b>{{$key}}</b></td><td>{{$value}}</td></tr>
+{{- end}}
+</table>`
+)
+
+// emailSettings returns the SMTP settings of Harbor
+var emailSettings = config.Email
+
+// emailContent is the data to render the templates of the email
+type emailContent struct {
+	Type       string
+	OccurAt    string
+	Operator   string
+	Project    string
+	Repository string
+	Status     string
+	Subject    string
+	Data       *model.EventData
+}
+
+// EmailHandler preprocess event data to email and start the hook processing
+type EmailHandler struct {
+}
+
+// Name ...
+func (e *EmailHandler) Name() string {
+	return "Email"
+}
+
+// Handle handles event to email
+func (e *EmailHandler) Handle(ctx context.Context, value interface{}) error {
+	if value == nil {
+		return errors.New("EmailHandler cannot handle nil value")
+	}
+
+	event, ok := value.(*model.HookEvent)
+	if !ok || event == nil {
+		return errors.New("invalid notification email event")
+	}
+
+	return e.process(ctx, event)
+}
+
+// IsStateful ...
+func (e *EmailHandler) IsStateful() bool {
+	return false
+}
+
+func (e *EmailHandler) process(ctx context.Context, event *model.HookEvent) error {
+	recipients := event.Target.Recipients()
+	if len(recipients) == 0 {
+		return fmt.Errorf("no recipients of the email notification of policy %d", event.PolicyID)
+	}
+	settings, err := emailSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the email settings: %v", err)
+	}
+	if len(settings.Host) == 0 {
+		return errors.New("the email server isn't configured")
+	}
+
+	j := &models.JobData{
+		Metadata: &models.JobMetadata{
+			JobKind: job.KindGeneric,
+		},
+	}
+	// Create an emailJob to send the email
+	j.Name = job.EmailJob
+
+	subject, message, err := e.render(event.Payload)
+	if err != nil {
+		return fmt.Errorf("render the email failed: %v", err)
+	}
+
+	// the SMTP settings aren't passed as the parameters are stored in plaintext, the job
+	// reads them from the configurations loaded from core with the secret of jobservice
+	j.Parameters = map[string]interface{}{
+		"recipients": recipients,
+		"subject":    subject,
+		"message":    message,
+	}
+	return notification.HookManager.StartHook(ctx, event, j)
+}
+
+// render returns the subject and the HTML message of the email
+func (e *EmailHandler) render(payload *model.Payload) (string, string, error) {
+	content := &emailContent{
+		Type:     payload.Type,
+		OccurAt:  time.Unix(payload.OccurAt, 0).UTC().Format(time.RFC1123),
+		Operator: payload.Operator,
+		Data:     payload.EventData,
+	}
+	if content.Data == nil {
+		content.Data = &model.EventData{}
+	}
+	if repo := content.Data.Repository; repo != nil {
+		content.Project = repo.Namespace
+		content.Repository = repo.RepoFullName
+	}
+	if rep := content.Data.Replication; rep != nil {
+		content.Status = rep.JobStatus
+	}
+	if ret := content.Data.Retention; ret != nil {
+		content.Project = ret.ProjectName
+		content.Status = ret.Status
+	}
+
+	subject, ok := emailSubjects[payload.Type]
+	if !ok {
+		subject = emailGenericSubject
+	}
+	// the subject is a header of the email rather than HTML, so it is rendered by text/template
+	// and the line breaks are removed to avoid injecting the headers
+	st, err := texttemplate.New("subject").Parse(subject)
+	if err != nil {
+		return "", "", err
+	}
+	var subjectBuf bytes.Buffer
+	if err := st.Execute(&subjectBuf, content); err != nil {
+		return "", "", err
+	}
+	content.Subject = strings.NewReplacer("\r", "", "\n", " ").Replace(subjectBuf.String())
+
+	bt, err := template.New("email").Parse(emailBodyTemplate)
+	if err != nil {
+		return "", "", err
+	}
+	var bodyBuf bytes.Buffer
+	if err := bt.Execute(&bodyBuf, content); err != nil {
+		return "", "", err
+	}
+	return content.Subject, bodyBuf.String(), nil
+}
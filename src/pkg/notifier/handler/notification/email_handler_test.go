@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailHandler_Handle(t *testing.T) {
+	hookMgr := notification.HookManager
+	settings := emailSettings
+	defer func() {
+		notification.HookManager = hookMgr
+		emailSettings = settings
+	}()
+	notification.HookManager = &fakedHookManager{}
+	cfg := &cfgModels.Email{}
+	emailSettings = func(ctx context.Context) (*cfgModels.Email, error) {
+		return cfg, nil
+	}
+
+	handler := &EmailHandler{}
+	assert.NotNil(t, handler.Handle(context.TODO(), nil))
+	assert.NotNil(t, handler.Handle(context.TODO(), &model.EventData{}))
+
+	event := newHookEvent("")
+	event.Target = &policy_model.EventTarget{
+		Type:    "email",
+		Address: "dev@example.com,ops@example.com",
+	}
+	// the email server isn't configured
+	assert.NotNil(t, handler.Handle(context.TODO(), event))
+
+	cfg.Host = "smtp.example.com"
+	cfg.Port = 25
+	cfg.From = "harbor@example.com"
+	assert.Nil(t, handler.Handle(context.TODO(), event))
+
+	// no recipients
+	event.Target.Address = ""
+	assert.NotNil(t, handler.Handle(context.TODO(), event))
+}
+
+func TestEmailHandler_Render(t *testing.T) {
+	handler := &EmailHandler{}
+	event := newHookEvent("")
+	event.Payload.EventData.Repository.Name = "<nginx>"
+	event.Payload.EventData.Repository.RepoFullName = "library/<nginx>"
+	subject, message, err := handler.render(event.Payload)
+	require.Nil(t, err)
+	assert.Equal(t, "[Harbor] Artifact pushed to library/<nginx>", subject)
+	assert.Contains(t, message, "<h3>[Harbor] Artifact pushed to library/&lt;nginx&gt;</h3>")
+	assert.Contains(t, message, "<td>harbor.domain/library/nginx:latest</td>")
+	assert.Contains(t, message, "<td>admin</td>")
+	assert.Contains(t, message, "<td>Sun, 13 Sep 2020 12:26:40 UTC</td>")
+
+	// the generic subject
+	event.Payload.Type = "UNKNOWN"
+	subject, _, err = handler.render(event.Payload)
+	require.Nil(t, err)
+	assert.Equal(t, "[Harbor] UNKNOWN event", subject)
+
+	subject, message, err = handler.render(&model.Payload{
+		Type:    "QUOTA_EXCEED",
+		OccurAt: 1600000000,
+		EventData: &model.EventData{
+			Repository: &model.Repository{Namespace: "library"},
+			Custom:     map[string]string{"Details": "quota exceeded"},
+		},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "[Harbor] Quota of project library exceeded", subject)
+	assert.Contains(t, message, "<tr><td><b>Details</b></td><td>quota exceeded</td></tr>")
+
+	// the event without event data
+	_, _, err = handler.render(&model.Payload{Type: "CREATE_PROJECT", OccurAt: 1600000000})
+	require.Nil(t, err)
+}
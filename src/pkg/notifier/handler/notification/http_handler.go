@@ -9,6 +9,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/notifier/model"
+	"time"
 )
 
 // HTTPHandler preprocess http event data and start the hook processing
@@ -46,9 +47,9 @@ func (h *HTTPHandler) process(ctx context.Context, event *model.HookEvent) error
 	}
 	j.Name = job.WebhookJob
 
-	payload, err := json.Marshal(event.Payload)
+	payload, headers, err := formatPayload(event)
 	if err != nil {
-		return fmt.Errorf("marshal from payload %v failed: %v", event.Payload, err)
+		return err
 	}
 
 	j.Parameters = map[string]interface{}{
@@ -58,6 +59,36 @@ func (h *HTTPHandler) process(ctx context.Context, event *model.HookEvent) error
 		// So it will be sent in header in http request.
 		"auth_header":      event.Target.AuthHeader,
 		"skip_cert_verify": event.Target.SkipCertVerify,
+		// The payload is signed by the secrets in the job, see the webhook job for the details.
+		"signing_secrets": event.Target.SigningSecrets(time.Now()),
+	}
+	if len(headers) > 0 {
+		j.Parameters["headers"] = headers
 	}
 	return notification.HookManager.StartHook(ctx, event, j)
 }
+
+// formatPayload formats the payload according to the payload format of the target,
+// the headers are returned if they should be sent along with the payload
+func formatPayload(event *model.HookEvent) ([]byte, map[string]string, error) {
+	var (
+		payload []byte
+		headers map[string]string
+		err     error
+	)
+	switch event.Target.PayloadFormat {
+	case model.PayloadFormatCloudEvents:
+		payload, err = json.Marshal(newCloudEvent(event))
+		headers = map[string]string{"Content-Type": cloudEventsContentType}
+	case model.PayloadFormatCloudEventsBinary:
+		ce := newCloudEvent(event)
+		payload, err = json.Marshal(ce.Data)
+		headers = ce.headers()
+	default:
+		payload, err = json.Marshal(event.Payload)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal from payload %v failed: %v", event.Payload, err)
+	}
+	return payload, headers, nil
+}
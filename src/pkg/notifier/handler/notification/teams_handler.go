@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.2"
+)
+
+// teamsMessage is the message posted to the incoming webhooks of Microsoft Teams,
+// refer to https://docs.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using
+type teamsMessage struct {
+	Type        string             `json:"type"`
+	Attachments []*teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string        `json:"contentType"`
+	Content     *adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string                   `json:"$schema"`
+	Type    string                   `json:"type"`
+	Version string                   `json:"version"`
+	Body    []map[string]interface{} `json:"body"`
+}
+
+type adaptiveFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// TeamsHandler preprocess event data to Microsoft Teams and start the hook processing
+type TeamsHandler struct {
+}
+
+// Name ...
+func (t *TeamsHandler) Name() string {
+	return "Teams"
+}
+
+// Handle handles event to Microsoft Teams
+func (t *TeamsHandler) Handle(ctx context.Context, value interface{}) error {
+	if value == nil {
+		return errors.New("TeamsHandler cannot handle nil value")
+	}
+
+	event, ok := value.(*model.HookEvent)
+	if !ok || event == nil {
+		return errors.New("invalid notification teams event")
+	}
+
+	return t.process(ctx, event)
+}
+
+// IsStateful ...
+func (t *TeamsHandler) IsStateful() bool {
+	return false
+}
+
+func (t *TeamsHandler) process(ctx context.Context, event *model.HookEvent) error {
+	j := &models.JobData{
+		Metadata: &models.JobMetadata{
+			JobKind: job.KindGeneric,
+		},
+	}
+	// Create a teamsJob to send message to Microsoft Teams
+	j.Name = job.TeamsJob
+
+	// Convert payload to the Adaptive Card
+	payload, err := t.convert(event.Payload)
+	if err != nil {
+		return fmt.Errorf("convert payload to teams body failed: %v", err)
+	}
+
+	j.Parameters = map[string]interface{}{
+		"payload":          payload,
+		"address":          event.Target.Address,
+		"skip_cert_verify": event.Target.SkipCertVerify,
+	}
+	return notification.HookManager.StartHook(ctx, event, j)
+}
+
+func (t *TeamsHandler) convert(payload *model.Payload) (string, error) {
+	facts := []*adaptiveFact{
+		{Title: "Event type", Value: payload.Type},
+		{Title: "Occur at", Value: time.Unix(payload.OccurAt, 0).UTC().Format(time.RFC3339)},
+		{Title: "Operator", Value: payload.Operator},
+	}
+	if data := payload.EventData; data != nil {
+		if data.Repository != nil {
+			facts = append(facts, &adaptiveFact{Title: "Repository", Value: data.Repository.RepoFullName})
+		}
+		for _, res := range data.Resources {
+			facts = append(facts, &adaptiveFact{Title: "Resource", Value: res.ResourceURL})
+		}
+	}
+	body := []map[string]interface{}{
+		{
+			"type":   "TextBlock",
+			"size":   "Medium",
+			"weight": "Bolder",
+			"text":   "Harbor webhook events",
+		},
+		{
+			"type":  "FactSet",
+			"facts": facts,
+		},
+	}
+	if payload.EventData != nil {
+		eventData, err := json.MarshalIndent(payload.EventData, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal from eventData %v failed: %v", payload.EventData, err)
+		}
+		body = append(body, map[string]interface{}{
+			"type":     "TextBlock",
+			"wrap":     true,
+			"fontType": "Monospace",
+			"text":     string(eventData),
+		})
+	}
+
+	msg := &teamsMessage{
+		Type: "message",
+		Attachments: []*teamsAttachment{
+			{
+				ContentType: adaptiveCardContentType,
+				Content: &adaptiveCard{
+					Schema:  adaptiveCardSchema,
+					Type:    "AdaptiveCard",
+					Version: adaptiveCardVersion,
+					Body:    body,
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,54 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/goharbor/harbor/src/pkg/notification"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamsHandler_Handle(t *testing.T) {
+	hookMgr := notification.HookManager
+	defer func() {
+		notification.HookManager = hookMgr
+	}()
+	notification.HookManager = &fakedHookManager{}
+
+	handler := &TeamsHandler{}
+	assert.NotNil(t, handler.Handle(context.TODO(), nil))
+	assert.NotNil(t, handler.Handle(context.TODO(), &model.EventData{}))
+
+	event := newHookEvent("")
+	event.Target = &policy_model.EventTarget{
+		Type:    "teams",
+		Address: "http://127.0.0.1:8080",
+	}
+	assert.Nil(t, handler.Handle(context.TODO(), event))
+	assert.Equal(t, "Teams", handler.Name())
+	assert.False(t, handler.IsStateful())
+}
+
+func TestTeamsHandler_Convert(t *testing.T) {
+	handler := &TeamsHandler{}
+	payload, err := handler.convert(newHookEvent("").Payload)
+	require.Nil(t, err)
+
+	msg := &teamsMessage{}
+	require.Nil(t, json.Unmarshal([]byte(payload), msg))
+	assert.Equal(t, "message", msg.Type)
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", msg.Attachments[0].ContentType)
+	card := msg.Attachments[0].Content
+	assert.Equal(t, "AdaptiveCard", card.Type)
+	require.Len(t, card.Body, 3)
+	assert.Equal(t, "FactSet", card.Body[1]["type"])
+	facts, ok := card.Body[1]["facts"].([]interface{})
+	require.True(t, ok)
+	assert.Contains(t, facts, map[string]interface{}{"title": "Repository", "value": "library/nginx"})
+	assert.Contains(t, facts, map[string]interface{}{"title": "Resource", "value": "harbor.domain/library/nginx:latest"})
+}
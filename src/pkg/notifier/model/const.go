@@ -4,4 +4,13 @@ package model
 const (
 	NotifyTypeHTTP  = "http"
 	NotifyTypeSlack = "slack"
+	NotifyTypeTeams = "teams"
+	NotifyTypeEmail = "email"
+
+	// PayloadFormatDefault is the legacy payload format of the http notifications
+	PayloadFormatDefault = "Default"
+	// PayloadFormatCloudEvents sends the http notifications as CloudEvents in the structured content mode
+	PayloadFormatCloudEvents = "CloudEvents"
+	// PayloadFormatCloudEventsBinary sends the http notifications as CloudEvents in the binary content mode
+	PayloadFormatCloudEventsBinary = "CloudEventsBinary"
 )
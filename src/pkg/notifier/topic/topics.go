@@ -12,6 +12,8 @@ func init() {
 	handlersMap := map[string][]notifier.NotificationHandler{
 		model.WebhookTopic: {&notification.HTTPHandler{}},
 		model.SlackTopic:   {&notification.SlackHandler{}},
+		model.TeamsTopic:   {&notification.TeamsHandler{}},
+		model.EmailTopic:   {&notification.EmailHandler{}},
 	}
 
 	for t, handlers := range handlersMap {
@@ -16,10 +16,23 @@ package models
 
 // keys of project metadata and severity values
 const (
-	ProMetaPublic               = "public"
-	ProMetaEnableContentTrust   = "enable_content_trust"
-	ProMetaPreventVul           = "prevent_vul" // prevent vulnerable images from being pulled
-	ProMetaSeverity             = "severity"
-	ProMetaAutoScan             = "auto_scan"
-	ProMetaReuseSysCVEAllowlist = "reuse_sys_cve_allowlist"
+	ProMetaPublic                   = "public"
+	ProMetaEnableContentTrust       = "enable_content_trust"
+	ProMetaEnableContentTrustCosign = "enable_content_trust_cosign" // only the artifacts signed by cosign can be pulled
+	ProMetaPreventVul               = "prevent_vul"                 // prevent vulnerable images from being pulled
+	ProMetaSeverity                 = "severity"
+	ProMetaAutoScan                 = "auto_scan"
+	ProMetaReuseSysCVEAllowlist     = "reuse_sys_cve_allowlist"
+	ProMetaProxyCacheServeStale     = "proxy_cache_serve_stale"     // serve the cached manifest when the upstream is unavailable
+	ProMetaProxyCacheStaleWindow    = "proxy_cache_stale_window"    // in minutes, the stale cache is served without limit if not set
+	ProMetaProxyCacheTagTTL         = "proxy_cache_tag_ttl"         // in minutes, the tag isn't re-checked with the upstream within the TTL
+	ProMetaProxyCacheEvictThreshold = "proxy_cache_evict_threshold" // in percent of the storage quota, the least recently pulled artifacts are evicted when reached
+	ProMetaScanGating               = "scan_gating"                 // the push waits for the scan to complete and the vulnerable artifacts are rejected or quarantined
+	ProMetaScanGatingTimeout        = "scan_gating_timeout"         // in seconds, how long the push waits for the scan to complete
+)
+
+// the modes of the scan gating
+const (
+	ScanGatingReject     = "reject"     // the vulnerable artifacts are deleted
+	ScanGatingQuarantine = "quarantine" // the vulnerable artifacts are kept but cannot be pulled
 )
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/astaxie/beego/orm"
+	"github.com/goharbor/harbor/src/common"
 	allowlist "github.com/goharbor/harbor/src/pkg/allowlist/models"
 	"github.com/lib/pq"
 	"strconv"
@@ -32,6 +33,9 @@ const (
 	ProjectPublic = "public"
 	// ProjectPrivate means project is private
 	ProjectPrivate = "private"
+
+	// the default duration that the push waits for the scan to complete
+	defaultScanGatingTimeout = 5 * time.Minute
 )
 
 func init() {
@@ -103,6 +107,15 @@ func (p *Project) ContentTrustEnabled() bool {
 	return isTrue(enabled)
 }
 
+// ContentTrustCosignEnabled ...
+func (p *Project) ContentTrustCosignEnabled() bool {
+	enabled, exist := p.GetMetadata(ProMetaEnableContentTrustCosign)
+	if !exist {
+		return false
+	}
+	return isTrue(enabled)
+}
+
 // VulPrevented ...
 func (p *Project) VulPrevented() bool {
 	prevent, exist := p.GetMetadata(ProMetaPreventVul)
@@ -139,6 +152,71 @@ func (p *Project) AutoScan() bool {
 	return isTrue(auto)
 }
 
+// ProxyCacheServeStale returns true when the cached manifest of the proxy cache project can be served
+// if the upstream registry is unavailable
+func (p *Project) ProxyCacheServeStale() bool {
+	serve, exist := p.GetMetadata(ProMetaProxyCacheServeStale)
+	if !exist {
+		return false
+	}
+	return isTrue(serve)
+}
+
+// ProxyCacheStaleWindow returns how long the cached manifest can be served after the last time it was
+// confirmed with the upstream registry, zero means no limit
+func (p *Project) ProxyCacheStaleWindow() time.Duration {
+	return time.Duration(p.getMetadataInt(ProMetaProxyCacheStaleWindow)) * time.Minute
+}
+
+// ProxyCacheTagTTL returns how long the cached tag is used without checking with the upstream registry,
+// zero means the tag is always checked
+func (p *Project) ProxyCacheTagTTL() time.Duration {
+	return time.Duration(p.getMetadataInt(ProMetaProxyCacheTagTTL)) * time.Minute
+}
+
+// ProxyCacheEvictThreshold returns the percent of the storage quota that the least recently pulled artifacts
+// of the proxy cache project are evicted when the usage reaches, zero means the eviction is disabled
+func (p *Project) ProxyCacheEvictThreshold() int {
+	threshold := p.getMetadataInt(ProMetaProxyCacheEvictThreshold)
+	if threshold > 100 {
+		return 0
+	}
+	return int(threshold)
+}
+
+// ScanGating returns the mode of the scan gating, empty string means the scan gating is disabled
+func (p *Project) ScanGating() string {
+	mode, _ := p.GetMetadata(ProMetaScanGating)
+	switch mode {
+	case ScanGatingReject, ScanGatingQuarantine:
+		return mode
+	default:
+		return ""
+	}
+}
+
+// ScanGatingTimeout returns how long the push waits for the scan to complete when the scan gating is enabled
+func (p *Project) ScanGatingTimeout() time.Duration {
+	timeout := p.getMetadataInt(ProMetaScanGatingTimeout)
+	if timeout == 0 {
+		return defaultScanGatingTimeout
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// getMetadataInt returns the non-negative integer value of the metadata, zero is returned if it is invalid
+func (p *Project) getMetadataInt(key string) int64 {
+	value, exist := p.GetMetadata(key)
+	if !exist {
+		return 0
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || i < 0 {
+		return 0
+	}
+	return i
+}
+
 // FilterByPublic returns orm.QuerySeter with public filter
 func (p *Project) FilterByPublic(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
 	subQuery := `SELECT project_id FROM project_metadata WHERE name = 'public' AND value = '%s'`
@@ -188,6 +266,18 @@ func (p *Project) FilterByMember(ctx context.Context, qs orm.QuerySeter, key str
 	return qs.FilterRaw("project_id", fmt.Sprintf("IN (%s)", subQuery))
 }
 
+// FilterByAdminGroup returns orm.QuerySeter with the filter of the user group which is the project admin
+func (p *Project) FilterByAdminGroup(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	groupID, ok := value.(int)
+	if !ok {
+		return qs
+	}
+
+	subQuery := fmt.Sprintf(`SELECT project_id FROM project_member WHERE entity_id = %d AND entity_type = 'g' AND role = %d`,
+		groupID, common.RoleProjectAdmin)
+	return qs.FilterRaw("project_id", fmt.Sprintf("IN (%s)", subQuery))
+}
+
 // FilterByNames returns orm.QuerySeter with name filter
 func (p *Project) FilterByNames(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
 	query, ok := value.(*NamesQuery)
@@ -43,6 +43,17 @@ type Driver interface {
 	CalculateUsage(ctx context.Context, key string) (types.ResourceList, error)
 }
 
+// Aggregator is the optional interface of the driver whose reference object aggregates the usage of
+// the reference objects of another driver, e.g. the user group aggregates the usage of its projects
+type Aggregator interface {
+	// AggregatedReference returns the reference of the aggregated objects
+	AggregatedReference() string
+	// ListAggregated returns the keys of the objects aggregated by the object of the key
+	ListAggregated(ctx context.Context, key string) ([]string, error)
+	// ListAggregators returns the keys of the objects which aggregate the object of the aggregated key
+	ListAggregators(ctx context.Context, aggregatedKey string) ([]string, error)
+}
+
 // Register register quota driver
 func Register(name string, driver Driver) {
 	driversMu.Lock()
@@ -62,3 +73,15 @@ func Get(name string) (Driver, bool) {
 	driver, ok := drivers[name]
 	return driver, ok
 }
+
+// List returns all the registered quota drivers by name
+func List() map[string]Driver {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	result := make(map[string]Driver, len(drivers))
+	for name, driver := range drivers {
+		result[name] = driver
+	}
+	return result
+}
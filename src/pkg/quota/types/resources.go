@@ -27,6 +27,12 @@ const (
 
 	// ResourceStorage storage size, in bytes
 	ResourceStorage ResourceName = "storage"
+
+	// ResourceCount artifact count
+	ResourceCount ResourceName = "count"
+
+	// ResourceRepository repository count
+	ResourceRepository ResourceName = "repository"
 )
 
 // ResourceName is the name identifying various resources in a ResourceList.
@@ -144,7 +150,7 @@ func IsNegative(a ResourceList) []ResourceName {
 // IsValidResource returns true when resource was supported
 func IsValidResource(resource ResourceName) bool {
 	switch resource {
-	case ResourceStorage:
+	case ResourceStorage, ResourceCount, ResourceRepository:
 		return true
 	default:
 		return false
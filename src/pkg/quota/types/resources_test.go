@@ -75,6 +75,13 @@ func (suite *ResourcesSuite) TestIsNegative() {
 	suite.Contains(IsNegative(ResourceList{ResourceStorage: -100}), ResourceStorage)
 }
 
+func (suite *ResourcesSuite) TestIsValidResource() {
+	suite.True(IsValidResource(ResourceStorage))
+	suite.True(IsValidResource(ResourceCount))
+	suite.True(IsValidResource(ResourceRepository))
+	suite.False(IsValidResource(ResourceName("cpu")))
+}
+
 func TestRunResourcesSuite(t *testing.T) {
 	suite.Run(t, new(ResourcesSuite))
 }
@@ -32,7 +32,8 @@ func IsSafe(hardLimits types.ResourceList, currentUsed types.ResourceList, newUs
 			continue
 		}
 
-		if hardLimit == types.UNLIMITED || value == currentUsed[resource] {
+		// the usage not increased is always safe, e.g. the resources are released from the usage exceeded
+		if hardLimit == types.UNLIMITED || value <= currentUsed[resource] {
 			continue
 		}
 
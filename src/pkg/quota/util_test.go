@@ -62,6 +62,16 @@ func TestIsSafe(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"decreased over the hard limit",
+			args{
+				types.ResourceList{types.ResourceStorage: 100},
+				types.ResourceList{types.ResourceStorage: 300},
+				types.ResourceList{types.ResourceStorage: 200},
+				false,
+			},
+			false,
+		},
 		{
 			"ignore limitation",
 			args{
@@ -29,6 +29,21 @@ type DAO interface {
 
 	// GetPermissionsByRole ...
 	GetPermissionsByRole(ctx context.Context, roleType string, roleID int64) ([]*model.UniversalRolePermission, error)
+
+	// CreateRole creates the project role
+	CreateRole(ctx context.Context, role *model.Role) (int64, error)
+	// UpdateRole updates the project role
+	UpdateRole(ctx context.Context, role *model.Role, props ...string) error
+	// DeleteRole deletes the project role specified by ID
+	DeleteRole(ctx context.Context, id int64) error
+	// GetRole gets the project role specified by ID
+	GetRole(ctx context.Context, id int64) (*model.Role, error)
+	// CountRoles returns the total count of project roles according to the query
+	CountRoles(ctx context.Context, query *q.Query) (int64, error)
+	// ListRoles lists the project roles according to the query
+	ListRoles(ctx context.Context, query *q.Query) ([]*model.Role, error)
+	// CountRoleMembers returns the count of the project members the role is assigned to
+	CountRoleMembers(ctx context.Context, id int64) (int64, error)
 }
 
 // New returns an instance of the default DAO
@@ -147,3 +162,93 @@ func (d *dao) GetPermissionsByRole(ctx context.Context, roleType string, roleID
 
 	return rps, nil
 }
+
+func (d *dao) CreateRole(ctx context.Context, role *model.Role) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(role)
+	if err != nil {
+		return 0, orm.WrapConflictError(err, "role %s already exists", role.Name)
+	}
+	return id, nil
+}
+
+func (d *dao) UpdateRole(ctx context.Context, role *model.Role, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Update(role, props...)
+	if err != nil {
+		return orm.WrapConflictError(err, "role %s already exists", role.Name)
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("role %d not found", role.ID)
+	}
+	return nil
+}
+
+func (d *dao) DeleteRole(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Delete(&model.Role{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("role %d not found", id)
+	}
+	return nil
+}
+
+func (d *dao) GetRole(ctx context.Context, id int64) (*model.Role, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role := &model.Role{
+		ID: id,
+	}
+	if err := ormer.Read(role); err != nil {
+		return nil, orm.WrapNotFoundError(err, "role %d not found", id)
+	}
+	return role, nil
+}
+
+func (d *dao) CountRoles(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Role{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Count()
+}
+
+func (d *dao) ListRoles(ctx context.Context, query *q.Query) ([]*model.Role, error) {
+	roles := []*model.Role{}
+	qs, err := orm.QuerySetter(ctx, &model.Role{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.All(&roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (d *dao) CountRoleMembers(ctx context.Context, id int64) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err = ormer.Raw("SELECT count(1) FROM project_member WHERE role = ?", id).QueryRow(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -211,6 +211,50 @@ func (suite *DaoTestSuite) TestGetPermissionsByRole() {
 	suite.Equal("/system", rpes[0].Scope)
 }
 
+func (suite *DaoTestSuite) TestRole() {
+	ctx := orm.Context()
+	id, err := suite.dao.CreateRole(ctx, &model.Role{
+		Name:        "release-manager",
+		Description: "push and label the artifacts",
+	})
+	suite.Require().Nil(err)
+	defer suite.dao.DeleteRole(ctx, id)
+	suite.True(id > model.MaxBuiltInRoleID)
+
+	// conflict
+	_, err = suite.dao.CreateRole(ctx, &model.Role{Name: "release-manager"})
+	suite.True(errors.IsConflictErr(err))
+
+	role, err := suite.dao.GetRole(ctx, id)
+	suite.Require().Nil(err)
+	suite.Equal("release-manager", role.Name)
+	suite.False(role.IsBuiltIn())
+
+	role.Description = "push, label and scan the artifacts"
+	suite.Require().Nil(suite.dao.UpdateRole(ctx, role, "Description"))
+	role, err = suite.dao.GetRole(ctx, id)
+	suite.Require().Nil(err)
+	suite.Equal("push, label and scan the artifacts", role.Description)
+
+	// the built-in roles are listed as well
+	total, err := suite.dao.CountRoles(ctx, nil)
+	suite.Require().Nil(err)
+	suite.Equal(int64(model.MaxBuiltInRoleID+1), total)
+	roles, err := suite.dao.ListRoles(ctx, q.New(q.KeyWords{"Name": "release-manager"}))
+	suite.Require().Nil(err)
+	suite.Require().Len(roles, 1)
+	suite.Equal(id, roles[0].ID)
+
+	count, err := suite.dao.CountRoleMembers(ctx, id)
+	suite.Require().Nil(err)
+	suite.Equal(int64(0), count)
+
+	suite.Require().Nil(suite.dao.DeleteRole(ctx, id))
+	_, err = suite.dao.GetRole(ctx, id)
+	suite.True(errors.IsNotFoundErr(err))
+	suite.True(errors.IsNotFoundErr(suite.dao.DeleteRole(ctx, id)))
+}
+
 func TestDaoTestSuite(t *testing.T) {
 	suite.Run(t, &DaoTestSuite{})
 }
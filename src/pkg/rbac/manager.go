@@ -31,6 +31,21 @@ type Manager interface {
 	ListRbacPolicies(ctx context.Context, query *q.Query) ([]*model.PermissionPolicy, error)
 	// GetPermissionsByRole ...
 	GetPermissionsByRole(ctx context.Context, roleType string, roleID int64) ([]*model.UniversalRolePermission, error)
+
+	// CreateRole creates the project role
+	CreateRole(ctx context.Context, role *model.Role) (int64, error)
+	// UpdateRole updates the project role, only the specified properties are updated if provided
+	UpdateRole(ctx context.Context, role *model.Role, props ...string) error
+	// DeleteRole deletes the project role specified by ID
+	DeleteRole(ctx context.Context, id int64) error
+	// GetRole gets the project role specified by ID
+	GetRole(ctx context.Context, id int64) (*model.Role, error)
+	// CountRoles returns the total count of project roles according to the query
+	CountRoles(ctx context.Context, query *q.Query) (int64, error)
+	// ListRoles lists the project roles according to the query
+	ListRoles(ctx context.Context, query *q.Query) ([]*model.Role, error)
+	// CountRoleMembers returns the count of the project members the role is assigned to
+	CountRoleMembers(ctx context.Context, id int64) (int64, error)
 }
 
 // NewManager returns an instance of the default manager
@@ -77,3 +92,31 @@ func (m *manager) ListRbacPolicies(ctx context.Context, query *q.Query) ([]*mode
 func (m *manager) GetPermissionsByRole(ctx context.Context, roleType string, roleID int64) ([]*model.UniversalRolePermission, error) {
 	return m.dao.GetPermissionsByRole(ctx, roleType, roleID)
 }
+
+func (m *manager) CreateRole(ctx context.Context, role *model.Role) (int64, error) {
+	return m.dao.CreateRole(ctx, role)
+}
+
+func (m *manager) UpdateRole(ctx context.Context, role *model.Role, props ...string) error {
+	return m.dao.UpdateRole(ctx, role, props...)
+}
+
+func (m *manager) DeleteRole(ctx context.Context, id int64) error {
+	return m.dao.DeleteRole(ctx, id)
+}
+
+func (m *manager) GetRole(ctx context.Context, id int64) (*model.Role, error) {
+	return m.dao.GetRole(ctx, id)
+}
+
+func (m *manager) CountRoles(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.CountRoles(ctx, query)
+}
+
+func (m *manager) ListRoles(ctx context.Context, query *q.Query) ([]*model.Role, error) {
+	return m.dao.ListRoles(ctx, query)
+}
+
+func (m *manager) CountRoleMembers(ctx context.Context, id int64) (int64, error) {
+	return m.dao.CountRoleMembers(ctx, id)
+}
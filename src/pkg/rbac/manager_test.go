@@ -103,6 +103,35 @@ func (m *managerTestSuite) TestGetPermissionsByRole() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestCreateRole() {
+	m.dao.On("CreateRole", mock.Anything, mock.Anything).Return(int64(6), nil)
+	id, err := m.mgr.CreateRole(context.Background(), &model.Role{Name: "release-manager"})
+	m.Require().Nil(err)
+	m.Equal(int64(6), id)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestUpdateRole() {
+	m.dao.On("UpdateRole", mock.Anything, mock.Anything, "Description").Return(nil)
+	err := m.mgr.UpdateRole(context.Background(), &model.Role{ID: 6}, "Description")
+	m.Require().Nil(err)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestListRoles() {
+	m.dao.On("ListRoles", mock.Anything, mock.Anything).Return([]*model.Role{
+		{
+			ID:   6,
+			Name: "release-manager",
+		},
+	}, nil)
+	roles, err := m.mgr.ListRoles(context.Background(), nil)
+	m.Require().Nil(err)
+	m.Require().Len(roles, 1)
+	m.Equal("release-manager", roles[0].Name)
+	m.dao.AssertExpectations(m.T())
+}
+
 func TestManager(t *testing.T) {
 	suite.Run(t, &managerTestSuite{})
 }
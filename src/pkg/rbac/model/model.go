@@ -8,8 +8,19 @@ import (
 func init() {
 	orm.RegisterModel(&RolePermission{})
 	orm.RegisterModel(&PermissionPolicy{})
+	orm.RegisterModel(&Role{})
 }
 
+const (
+	// ProjectRoleType is the role type of the custom project roles in the role permission
+	ProjectRoleType = "projectrole"
+	// ProjectRoleScope is the scope of the permission policies of the custom project roles,
+	// the policies apply to all the projects the role is assigned in
+	ProjectRoleScope = "/project/*"
+	// MaxBuiltInRoleID is the max ID of the built-in project roles, the IDs of the custom roles are greater than it
+	MaxBuiltInRoleID = 5
+)
+
 // RolePermission records the relations of role and permission
 type RolePermission struct {
 	ID                 int64     `orm:"pk;auto;column(id)"`
@@ -48,3 +59,23 @@ type UniversalRolePermission struct {
 	Action   string `orm:"column(action)"`
 	Effect   string `orm:"column(effect)"`
 }
+
+// Role is the project role assigned to the project members, the built-in roles are
+// inserted by the migration and the custom roles are defined by the system admin
+type Role struct {
+	ID           int64     `orm:"pk;auto;column(role_id)" json:"id"`
+	Name         string    `orm:"column(name)" json:"name"`
+	Description  string    `orm:"column(description)" json:"description"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName for role
+func (r *Role) TableName() string {
+	return "role"
+}
+
+// IsBuiltIn returns whether the role is one of the built-in project roles
+func (r *Role) IsBuiltIn() bool {
+	return r.ID <= MaxBuiltInRoleID
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/reg/adapter/harbor/base"
 	"github.com/goharbor/harbor/src/pkg/reg/filter"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
 var _ adp.Adapter = &adapter{}
@@ -48,6 +49,28 @@ func (a *adapter) Info() (*model.RegistryInfo, error) {
 		return nil, err
 	}
 	info.SupportedResourceTypes = append(info.SupportedResourceTypes, model.ResourceTypeArtifact)
+	// the annotations and the scan overview of artifacts are only returned by the artifact API of Harbor 2.x
+	info.SupportedResourceFilters = append(info.SupportedResourceFilters,
+		&model.FilterStyle{
+			Type:  model.FilterTypeArtifactType,
+			Style: model.FilterStyleTypeText,
+		},
+		&model.FilterStyle{
+			Type:  model.FilterTypeAnnotation,
+			Style: model.FilterStyleTypeText,
+		},
+		&model.FilterStyle{
+			Type:  model.FilterTypeSeverity,
+			Style: model.FilterStyleTypeRadio,
+			Values: []string{
+				vuln.None.String(),
+				vuln.Negligible.String(),
+				vuln.Low.String(),
+				vuln.Medium.String(),
+				vuln.High.String(),
+				vuln.Critical.String(),
+			},
+		})
 	return info, err
 }
 
@@ -124,11 +147,21 @@ func (a *adapter) listRepositories(project *base.Project, filters []*model.Filte
 }
 
 func (a *adapter) listArtifacts(repository string, filters []*model.Filter) ([]*model.Artifact, error) {
-	artifacts, err := a.client.listArtifacts(repository)
+	artifacts, accessories, err := a.client.listArtifacts(repository)
 	if err != nil {
 		return nil, err
 	}
-	return filter.DoFilterArtifacts(artifacts, filters)
+	artifacts, err = filter.DoFilterArtifacts(artifacts, filters)
+	if err != nil {
+		return nil, err
+	}
+	// the accessories are replicated along with their subject artifacts
+	var arts []*model.Artifact
+	for _, art := range artifacts {
+		arts = append(arts, art)
+		arts = append(arts, accessories[art.Digest]...)
+	}
+	return arts, nil
 }
 
 func (a *adapter) CanBeMount(digest string) (bool, string, error) {
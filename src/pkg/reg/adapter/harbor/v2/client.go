@@ -19,16 +19,43 @@ import (
 
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/image"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/encode/repository"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/accessory/model/cosign"
 	"github.com/goharbor/harbor/src/pkg/reg/adapter/harbor/base"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 	repomodel "github.com/goharbor/harbor/src/pkg/repository/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
 type client struct {
 	*base.Client
 }
 
+// artifactWithAccessories is used to decode the artifacts returned by the artifact API
+// with "with_accessory=true" and "with_scan_overview=true"
+type artifactWithAccessories struct {
+	artifact.Artifact
+	Accessories  []*accessorymodel.AccessoryData      `json:"accessories"`
+	ScanOverview map[string]*vuln.NativeReportSummary `json:"scan_overview"`
+}
+
+// severity returns the highest severity among the successful scan reports, empty if the artifact isn't scanned
+func (a *artifactWithAccessories) severity() string {
+	var severity vuln.Severity
+	for _, summary := range a.ScanOverview {
+		if summary == nil || summary.ScanStatus != job.SuccessStatus.String() {
+			continue
+		}
+		if len(severity) == 0 || summary.Severity.Code() > severity.Code() {
+			severity = summary.Severity
+		}
+	}
+	return severity.String()
+}
+
 func (c *client) listRepositories(project *base.Project) ([]*model.Repository, error) {
 	repositories := []*repomodel.RepoRecord{}
 	url := fmt.Sprintf("%s/projects/%s/repositories", c.BasePath(), project.Name)
@@ -45,20 +72,25 @@ func (c *client) listRepositories(project *base.Project) ([]*model.Repository, e
 	return repos, nil
 }
 
-func (c *client) listArtifacts(repo string) ([]*model.Artifact, error) {
+// listArtifacts returns the artifacts under the repository and the accessories of them,
+// the accessories are grouped by the digest of their subject artifacts
+func (c *client) listArtifacts(repo string) ([]*model.Artifact, map[string][]*model.Artifact, error) {
 	project, repo := utils.ParseRepository(repo)
 	repo = repository.Encode(repo)
-	url := fmt.Sprintf("%s/projects/%s/repositories/%s/artifacts?with_label=true",
+	url := fmt.Sprintf("%s/projects/%s/repositories/%s/artifacts?with_label=true&with_accessory=true&with_scan_overview=true",
 		c.BasePath(), project, repo)
-	artifacts := []*artifact.Artifact{}
+	artifacts := []*artifactWithAccessories{}
 	if err := c.C.GetAndIteratePagination(url, &artifacts); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	var arts []*model.Artifact
+	accessories := map[string][]*model.Artifact{}
 	for _, artifact := range artifacts {
 		art := &model.Artifact{
-			Type:   artifact.Type,
-			Digest: artifact.Digest,
+			Type:        artifact.Type,
+			Digest:      artifact.Digest,
+			Annotations: artifact.Annotations,
+			Severity:    artifact.severity(),
 		}
 		for _, label := range artifact.Labels {
 			art.Labels = append(art.Labels, label.Name)
@@ -67,8 +99,20 @@ func (c *client) listArtifacts(repo string) ([]*model.Artifact, error) {
 			art.Tags = append(art.Tags, tag.Name)
 		}
 		arts = append(arts, art)
+
+		for _, acc := range artifact.Accessories {
+			accessory := &model.Artifact{
+				Digest: acc.Digest,
+			}
+			// cosign finds the signature by the tag derived from the digest of the subject artifact
+			if acc.Type == accessorymodel.TypeCosignSignature {
+				accessory.Type = image.ArtifactTypeImage
+				accessory.Tags = []string{cosign.SignatureTag(artifact.Digest)}
+			}
+			accessories[artifact.Digest] = append(accessories[artifact.Digest], accessory)
+		}
 	}
-	return arts, nil
+	return arts, accessories, nil
 }
 
 func (c *client) deleteTag(repo, tag string) error {
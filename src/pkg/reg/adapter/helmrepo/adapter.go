@@ -0,0 +1,310 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrepo
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/docker/distribution"
+	// register the OCI manifest schema so that the converted manifests can be unmarshalled
+	_ "github.com/docker/distribution/manifest/ocischema"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	adp "github.com/goharbor/harbor/src/pkg/reg/adapter"
+	"github.com/goharbor/harbor/src/pkg/reg/filter"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// the artifact type of the charts, keep it same with the one defined by the chart processor
+const artifactTypeChart = "CHART"
+
+func init() {
+	if err := adp.RegisterFactory(model.RegistryTypeHelmRepository, new(factory)); err != nil {
+		log.Errorf("failed to register factory for %s: %v", model.RegistryTypeHelmRepository, err)
+		return
+	}
+	log.Infof("the factory for adapter %s registered", model.RegistryTypeHelmRepository)
+}
+
+type factory struct{}
+
+// Create ...
+func (f *factory) Create(r *model.Registry) (adp.Adapter, error) {
+	return newAdapter(r), nil
+}
+
+// AdapterPattern ...
+func (f *factory) AdapterPattern() *model.AdapterPattern {
+	return nil
+}
+
+var (
+	_ adp.Adapter          = (*adapter)(nil)
+	_ adp.ArtifactRegistry = (*adapter)(nil)
+)
+
+// adapter for the classic Helm chart repositories which serve the "index.yaml" over HTTP.
+// The charts in the repository are exposed as the repositories named by the chart names
+// and the chart versions as the tags. The chart packages are converted into OCI chart
+// artifacts when pulling, so that they can be cached by the proxy cache projects and
+// replicated into Harbor
+type adapter struct {
+	registry *model.Registry
+	client   *client
+}
+
+func newAdapter(registry *model.Registry) *adapter {
+	return &adapter{
+		registry: registry,
+		client:   newClient(registry),
+	}
+}
+
+// Info returns the basic information about the adapter
+func (a *adapter) Info() (*model.RegistryInfo, error) {
+	return &model.RegistryInfo{
+		Type: model.RegistryTypeHelmRepository,
+		SupportedResourceTypes: []string{
+			model.ResourceTypeArtifact,
+		},
+		SupportedResourceFilters: []*model.FilterStyle{
+			{
+				Type:  model.FilterTypeName,
+				Style: model.FilterStyleTypeText,
+			},
+			{
+				Type:  model.FilterTypeTag,
+				Style: model.FilterStyleTypeText,
+			},
+		},
+		SupportedTriggers: []string{
+			model.TriggerTypeManual,
+			model.TriggerTypeScheduled,
+		},
+	}, nil
+}
+
+// PrepareForPush isn't supported as the Helm chart repository is read only
+func (a *adapter) PrepareForPush(resources []*model.Resource) error {
+	return errors.New(nil).WithCode(errors.MethodNotAllowedCode).WithMessage("not supported")
+}
+
+// HealthCheck checks whether the index file can be fetched
+func (a *adapter) HealthCheck() (string, error) {
+	if _, err := a.client.getIndex(); err != nil {
+		log.Errorf("failed to get the index file of Helm chart repository %s: %v", a.registry.URL, err)
+		return model.Unhealthy, nil
+	}
+	return model.Healthy, nil
+}
+
+// FetchArtifacts lists the charts in the index file as the artifacts
+func (a *adapter) FetchArtifacts(filters []*model.Filter) ([]*model.Resource, error) {
+	index, err := a.client.getIndex()
+	if err != nil {
+		return nil, err
+	}
+	var repositories []*model.Repository
+	for name := range index.Entries {
+		repositories = append(repositories, &model.Repository{
+			Name: name,
+		})
+	}
+	repositories, err = filter.DoFilterRepositories(repositories, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*model.Resource
+	for _, repository := range repositories {
+		var artifacts []*model.Artifact
+		for _, cv := range index.Entries[repository.Name] {
+			artifacts = append(artifacts, &model.Artifact{
+				Type: artifactTypeChart,
+				Tags: []string{versionToTag(cv.Version)},
+			})
+		}
+		artifacts, err = filter.DoFilterArtifacts(artifacts, filters)
+		if err != nil {
+			return nil, err
+		}
+		if len(artifacts) == 0 {
+			continue
+		}
+		resources = append(resources, &model.Resource{
+			Type:     model.ResourceTypeArtifact,
+			Registry: a.registry,
+			Metadata: &model.ResourceMetadata{
+				Repository: repository,
+				Artifacts:  artifacts,
+			},
+		})
+	}
+	return resources, nil
+}
+
+// ManifestExist checks the existence of the chart, the chart package is downloaded to calculate the digest
+func (a *adapter) ManifestExist(repository, reference string) (bool, *distribution.Descriptor, error) {
+	art, err := a.getChart(repository, reference)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, &distribution.Descriptor{
+		MediaType: v1.MediaTypeImageManifest,
+		Digest:    art.digest,
+		Size:      int64(len(art.manifest)),
+	}, nil
+}
+
+// PullManifest returns the manifest of the chart converted into the OCI artifact
+func (a *adapter) PullManifest(repository, reference string, acceptedMediaTypes ...string) (distribution.Manifest, string, error) {
+	art, err := a.getChart(repository, reference)
+	if err != nil {
+		return nil, "", err
+	}
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, art.manifest)
+	if err != nil {
+		return nil, "", err
+	}
+	return manifest, art.digest.String(), nil
+}
+
+// BlobExist checks the existence of the config or the chart package
+func (a *adapter) BlobExist(repository, digest string) (bool, error) {
+	if _, err := a.getBlob(repository, digest); err != nil {
+		if errors.IsNotFoundErr(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// PullBlob returns the config or the chart package
+func (a *adapter) PullBlob(repository, digest string) (int64, io.ReadCloser, error) {
+	blob, err := a.getBlob(repository, digest)
+	if err != nil {
+		return 0, nil, err
+	}
+	return int64(len(blob)), ioutil.NopCloser(bytes.NewReader(blob)), nil
+}
+
+// PushManifest isn't supported
+func (a *adapter) PushManifest(repository, reference, mediaType string, payload []byte) (string, error) {
+	return "", errors.New(nil).WithCode(errors.MethodNotAllowedCode).WithMessage("not supported")
+}
+
+// DeleteManifest isn't supported
+func (a *adapter) DeleteManifest(repository, reference string) error {
+	return errors.New(nil).WithCode(errors.MethodNotAllowedCode).WithMessage("not supported")
+}
+
+// PushBlob isn't supported
+func (a *adapter) PushBlob(repository, digest string, size int64, blob io.Reader) error {
+	return errors.New(nil).WithCode(errors.MethodNotAllowedCode).WithMessage("not supported")
+}
+
+// MountBlob isn't supported
+func (a *adapter) MountBlob(srcRepository, digest, dstRepository string) error {
+	return errors.New(nil).WithCode(errors.MethodNotAllowedCode).WithMessage("not supported")
+}
+
+// CanBeMount always returns false as the blobs cannot be mounted
+func (a *adapter) CanBeMount(digest string) (bool, string, error) {
+	return false, "", nil
+}
+
+// DeleteTag isn't supported
+func (a *adapter) DeleteTag(repository, tag string) error {
+	return errors.New(nil).WithCode(errors.MethodNotAllowedCode).WithMessage("not supported")
+}
+
+// getChart returns the converted chart by the tag or the manifest digest
+func (a *adapter) getChart(repository, reference string) (*chartArtifact, error) {
+	if art := charts.get(a.cacheKey(reference)); art != nil && art.name == repository {
+		return art, nil
+	}
+	if art := charts.get(a.cacheKey(repository + ":" + reference)); art != nil {
+		return art, nil
+	}
+	index, err := a.client.getIndex()
+	if err != nil {
+		return nil, err
+	}
+	// the manifest digest isn't recorded in the index, so the charts pulled by digest
+	// can only be served when they have been converted before
+	if _, err := digest.Parse(reference); err == nil {
+		return nil, errors.NotFoundError(nil).WithMessage("chart %s@%s not found", repository, reference)
+	}
+	cv, err := getChartVersion(index, repository, reference)
+	if err != nil {
+		return nil, err
+	}
+	if len(cv.URLs) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessage("no download URL for chart %s:%s", repository, reference)
+	}
+	content, err := a.client.download(cv.URLs[0])
+	if err != nil {
+		return nil, err
+	}
+	art, err := convert(cv, content)
+	if err != nil {
+		return nil, err
+	}
+	config, layer := digest.FromBytes(art.config).String(), digest.FromBytes(art.content).String()
+	charts.set(art, a.cacheKey(repository+":"+reference), a.cacheKey(art.digest.String()),
+		a.cacheKey(config), a.cacheKey(layer))
+	return art, nil
+}
+
+// getBlob returns the blob from the converted charts, the chart is converted again
+// by the digest recorded in the index if the cache is expired
+func (a *adapter) getBlob(repository, dgt string) ([]byte, error) {
+	if art := charts.get(a.cacheKey(dgt)); art != nil && art.name == repository {
+		if blob, ok := art.blob(dgt); ok {
+			return blob, nil
+		}
+	}
+	index, err := a.client.getIndex()
+	if err != nil {
+		return nil, err
+	}
+	for _, cv := range index.Entries[repository] {
+		if len(cv.Digest) == 0 || digest.NewDigestFromEncoded(digest.SHA256, strings.TrimPrefix(cv.Digest, "sha256:")).String() != dgt {
+			continue
+		}
+		art, err := a.getChart(repository, versionToTag(cv.Version))
+		if err != nil {
+			return nil, err
+		}
+		if blob, ok := art.blob(dgt); ok {
+			return blob, nil
+		}
+	}
+	return nil, errors.NotFoundError(nil).WithMessage("blob %s of chart %s not found", dgt, repository)
+}
+
+// the converted charts are shared among the adapters, so the key is prefixed with the repository URL
+func (a *adapter) cacheKey(key string) string {
+	return a.client.url + "/" + key
+}
@@ -0,0 +1,193 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrepo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const chartContent = "chart package"
+
+func newTestServer() *httptest.Server {
+	index := fmt.Sprintf(`apiVersion: v1
+entries:
+  nginx:
+  - apiVersion: v2
+    name: nginx
+    version: 1.0.0+build
+    description: nginx chart
+    digest: %s
+    urls:
+    - charts/nginx-1.0.0+build.tgz
+`, digest.FromString(chartContent).Encoded())
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.yaml":
+			w.Write([]byte(index))
+		case "/charts/nginx-1.0.0+build.tgz":
+			w.Write([]byte(chartContent))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestHealthCheck(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	status, err := newAdapter(&model.Registry{URL: server.URL}).HealthCheck()
+	require.Nil(t, err)
+	assert.Equal(t, model.Healthy, status)
+
+	status, err = newAdapter(&model.Registry{URL: server.URL + "/not-exist"}).HealthCheck()
+	require.Nil(t, err)
+	assert.Equal(t, model.Unhealthy, status)
+}
+
+func TestFetchArtifacts(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	a := newAdapter(&model.Registry{URL: server.URL})
+	resources, err := a.FetchArtifacts(nil)
+	require.Nil(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, model.ResourceTypeArtifact, resources[0].Type)
+	assert.Equal(t, "nginx", resources[0].Metadata.Repository.Name)
+	require.Len(t, resources[0].Metadata.Artifacts, 1)
+	assert.Equal(t, []string{"1.0.0_build"}, resources[0].Metadata.Artifacts[0].Tags)
+
+	resources, err = a.FetchArtifacts([]*model.Filter{
+		{
+			Type:  model.FilterTypeName,
+			Value: "redis",
+		},
+	})
+	require.Nil(t, err)
+	assert.Len(t, resources, 0)
+}
+
+func TestPullChart(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	a := newAdapter(&model.Registry{URL: server.URL})
+
+	// not found
+	exist, _, err := a.ManifestExist("nginx", "2.0.0")
+	require.Nil(t, err)
+	assert.False(t, exist)
+
+	exist, desc, err := a.ManifestExist("nginx", "1.0.0_build")
+	require.Nil(t, err)
+	require.True(t, exist)
+	assert.Equal(t, v1.MediaTypeImageManifest, desc.MediaType)
+
+	manifest, dgt, err := a.PullManifest("nginx", "1.0.0_build")
+	require.Nil(t, err)
+	assert.Equal(t, desc.Digest.String(), dgt)
+	mediaType, payload, err := manifest.Payload()
+	require.Nil(t, err)
+	assert.Equal(t, v1.MediaTypeImageManifest, mediaType)
+	mani := &v1.Manifest{}
+	require.Nil(t, json.Unmarshal(payload, mani))
+	assert.Equal(t, configMediaType, mani.Config.MediaType)
+	require.Len(t, mani.Layers, 1)
+	assert.Equal(t, layerMediaType, mani.Layers[0].MediaType)
+	assert.Equal(t, digest.FromString(chartContent), mani.Layers[0].Digest)
+	assert.Equal(t, "1.0.0+build", mani.Annotations[v1.AnnotationVersion])
+
+	// pull by digest
+	_, d, err := a.PullManifest("nginx", dgt)
+	require.Nil(t, err)
+	assert.Equal(t, dgt, d)
+
+	// the config
+	_, blob, err := a.PullBlob("nginx", mani.Config.Digest.String())
+	require.Nil(t, err)
+	data, err := ioutil.ReadAll(blob)
+	require.Nil(t, err)
+	config := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(data, &config))
+	assert.Equal(t, "nginx", config["name"])
+
+	// the chart package is resolved by the digest in the index even if the cache is missing
+	charts.lock.Lock()
+	charts.entries = map[string]*chartArtifact{}
+	charts.lock.Unlock()
+	size, blob, err := a.PullBlob("nginx", digest.FromString(chartContent).String())
+	require.Nil(t, err)
+	data, err = ioutil.ReadAll(blob)
+	require.Nil(t, err)
+	assert.Equal(t, int64(len(chartContent)), size)
+	assert.Equal(t, chartContent, string(data))
+
+	exist, err = a.BlobExist("nginx", digest.FromString("not-exist").String())
+	require.Nil(t, err)
+	assert.False(t, exist)
+}
+
+func TestClientCredential(t *testing.T) {
+	authorized := map[string]bool{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_, _, ok := r.BasicAuth()
+		authorized[r.Host] = ok
+		w.Write([]byte(chartContent))
+	}
+	repository := httptest.NewServer(http.HandlerFunc(handler))
+	defer repository.Close()
+	thirdParty := httptest.NewServer(http.HandlerFunc(handler))
+	defer thirdParty.Close()
+
+	c := newClient(&model.Registry{
+		URL: repository.URL,
+		Credential: &model.Credential{
+			AccessKey:    "admin",
+			AccessSecret: "password",
+		},
+	})
+	_, err := c.download("charts/nginx-1.0.0.tgz")
+	require.Nil(t, err)
+	_, err = c.download(thirdParty.URL + "/charts/nginx-1.0.0.tgz")
+	require.Nil(t, err)
+	assert.True(t, authorized[strings.TrimPrefix(repository.URL, "http://")])
+	assert.False(t, authorized[strings.TrimPrefix(thirdParty.URL, "http://")])
+}
+
+func TestClientSizeLimit(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	c := newClient(&model.Registry{URL: server.URL})
+	data, err := c.get(server.URL+"/charts/nginx-1.0.0+build.tgz", int64(len(chartContent)))
+	require.Nil(t, err)
+	assert.Equal(t, chartContent, string(data))
+
+	_, err = c.get(server.URL+"/charts/nginx-1.0.0+build.tgz", int64(len(chartContent)-1))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,184 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrepo
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+const (
+	// the media types defined by Helm for the charts stored in the OCI registries
+	configMediaType = "application/vnd.cncf.helm.config.v1+json"
+	layerMediaType  = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+
+	// the converted charts are cached for a while, so that the manifest and the blobs
+	// can be served without downloading the chart package again
+	chartCacheInterval = 10 * time.Minute
+	chartCacheSize     = 128
+)
+
+var charts = &chartCache{
+	entries: map[string]*chartArtifact{},
+}
+
+// chartArtifact is the chart package converted into an OCI artifact
+type chartArtifact struct {
+	name     string
+	version  string
+	manifest []byte
+	digest   digest.Digest
+	config   []byte
+	content  []byte
+	expires  time.Time
+}
+
+// blob returns the content of the config or the layer by the digest
+func (c *chartArtifact) blob(dgt string) ([]byte, bool) {
+	switch dgt {
+	case digest.FromBytes(c.config).String():
+		return c.config, true
+	case digest.FromBytes(c.content).String():
+		return c.content, true
+	default:
+		return nil, false
+	}
+}
+
+// chartCache caches the converted charts by the "name:version", the manifest digest and the blob digests
+type chartCache struct {
+	lock    sync.Mutex
+	entries map[string]*chartArtifact
+}
+
+func (c *chartCache) get(key string) *chartArtifact {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	art, exist := c.entries[key]
+	if !exist {
+		return nil
+	}
+	if time.Now().After(art.expires) {
+		delete(c.entries, key)
+		return nil
+	}
+	return art
+}
+
+func (c *chartCache) set(art *chartArtifact, keys ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := time.Now()
+	if len(c.entries)+len(keys) > chartCacheSize {
+		for key, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, key)
+			}
+		}
+	}
+	// drop the entries randomly if the cache is still full
+	for key := range c.entries {
+		if len(c.entries)+len(keys) <= chartCacheSize {
+			break
+		}
+		delete(c.entries, key)
+	}
+	art.expires = now.Add(chartCacheInterval)
+	for _, key := range keys {
+		c.entries[key] = art
+	}
+}
+
+// the tag of the chart in OCI registry is the version with "+" replaced by "_" as "+" isn't allowed in tags
+func versionToTag(version string) string {
+	return strings.ReplaceAll(version, "+", "_")
+}
+
+func tagToVersion(tag string) string {
+	return strings.ReplaceAll(tag, "_", "+")
+}
+
+// getChartVersion returns the chart version in the index by the tag
+func getChartVersion(index *repo.IndexFile, name, tag string) (*repo.ChartVersion, error) {
+	for _, cv := range index.Entries[name] {
+		if cv.Version == tagToVersion(tag) || cv.Version == tag {
+			return cv, nil
+		}
+	}
+	return nil, errors.NotFoundError(nil).WithMessage("chart %s:%s not found", name, tag)
+}
+
+// convert the chart package into an OCI artifact in the same layout as pushed by Helm
+func convert(cv *repo.ChartVersion, content []byte) (*chartArtifact, error) {
+	// verify the package if the digest is provided in the index
+	if len(cv.Digest) > 0 && strings.TrimPrefix(cv.Digest, "sha256:") != digest.FromBytes(content).Encoded() {
+		return nil, errors.Errorf("the digest of chart %s:%s mismatches with the one in the index", cv.Name, cv.Version)
+	}
+	config, err := json.Marshal(cv.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	annotations := map[string]string{
+		v1.AnnotationTitle:   cv.Name,
+		v1.AnnotationVersion: cv.Version,
+	}
+	if len(cv.Description) > 0 {
+		annotations[v1.AnnotationDescription] = cv.Description
+	}
+	if len(cv.Home) > 0 {
+		annotations[v1.AnnotationURL] = cv.Home
+	}
+	if !cv.Created.IsZero() {
+		annotations[v1.AnnotationCreated] = cv.Created.UTC().Format(time.RFC3339)
+	}
+	manifest, err := json.Marshal(&v1.Manifest{
+		Versioned: specs.Versioned{
+			SchemaVersion: 2,
+		},
+		MediaType: v1.MediaTypeImageManifest,
+		Config: v1.Descriptor{
+			MediaType: configMediaType,
+			Digest:    digest.FromBytes(config),
+			Size:      int64(len(config)),
+		},
+		Layers: []v1.Descriptor{
+			{
+				MediaType: layerMediaType,
+				Digest:    digest.FromBytes(content),
+				Size:      int64(len(content)),
+			},
+		},
+		Annotations: annotations,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &chartArtifact{
+		name:     cv.Name,
+		version:  cv.Version,
+		manifest: manifest,
+		digest:   digest.FromBytes(manifest),
+		config:   config,
+		content:  content,
+	}, nil
+}
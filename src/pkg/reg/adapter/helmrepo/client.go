@@ -0,0 +1,182 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrepo
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+// the parsed index files are cached for a while as they may be large and
+// are consulted by every request proxied to the same repository
+const indexCacheInterval = 5 * time.Minute
+
+// the index file and the chart packages are read into memory, limit their sizes so
+// that a misbehaving repository can't exhaust the memory
+const (
+	maxIndexSize = 64 << 20
+	maxChartSize = 20 << 20
+)
+
+var indexes = &indexCache{
+	entries: map[string]*indexCacheEntry{},
+}
+
+type indexCacheEntry struct {
+	index   *repo.IndexFile
+	expires time.Time
+}
+
+type indexCache struct {
+	lock    sync.Mutex
+	entries map[string]*indexCacheEntry
+}
+
+func (i *indexCache) get(key string) *repo.IndexFile {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	entry, exist := i.entries[key]
+	if !exist {
+		return nil
+	}
+	if time.Now().After(entry.expires) {
+		delete(i.entries, key)
+		return nil
+	}
+	return entry.index
+}
+
+func (i *indexCache) set(key string, index *repo.IndexFile) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.entries[key] = &indexCacheEntry{
+		index:   index,
+		expires: time.Now().Add(indexCacheInterval),
+	}
+}
+
+// client talks with the classic Helm chart repository which serves the "index.yaml"
+// and the chart packages over HTTP
+type client struct {
+	url        string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+func newClient(registry *model.Registry) *client {
+	c := &client{
+		url: strings.TrimSuffix(registry.URL, "/"),
+		httpClient: &http.Client{
+			Transport: commonhttp.GetHTTPTransport(commonhttp.WithInsecure(registry.Insecure)),
+		},
+	}
+	if registry.Credential != nil {
+		c.username = registry.Credential.AccessKey
+		c.password = registry.Credential.AccessSecret
+	}
+	return c
+}
+
+// getIndex returns the index file of the repository, the cached one is returned if it isn't expired
+func (c *client) getIndex() (*repo.IndexFile, error) {
+	key := c.username + "@" + c.url
+	if index := indexes.get(key); index != nil {
+		return index, nil
+	}
+	data, err := c.get(c.url+"/index.yaml", maxIndexSize)
+	if err != nil {
+		return nil, err
+	}
+	index := &repo.IndexFile{}
+	if err = yaml.Unmarshal(data, index); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the index.yaml")
+	}
+	index.SortEntries()
+	indexes.set(key, index)
+	return index, nil
+}
+
+// download the chart package, the URL is resolved against the repository URL if it is relative
+func (c *client) download(chartURL string) ([]byte, error) {
+	base, err := url.Parse(c.url + "/")
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(chartURL)
+	if err != nil {
+		return nil, err
+	}
+	return c.get(base.ResolveReference(u).String(), maxChartSize)
+}
+
+// get the content of the URL whose size is limited to maxSize, the credential is only sent
+// to the host of the repository as the charts may be served by the third party hosts
+func (c *client) get(u string, maxSize int64) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.username) > 0 && c.isRepositoryHost(req.URL) {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, errors.New(nil).WithCode(errors.GeneralCode).
+			WithMessage("the size of %s exceeds the limit %d bytes", u, maxSize)
+	}
+	if resp.StatusCode != http.StatusOK {
+		e := errors.New(nil).WithMessage("failed to get %s: %d %s", u, resp.StatusCode, string(body))
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			e.WithCode(errors.NotFoundCode)
+		case http.StatusUnauthorized:
+			e.WithCode(errors.UnAuthorizedCode)
+		case http.StatusForbidden:
+			e.WithCode(errors.ForbiddenCode)
+		default:
+			e.WithCode(errors.GeneralCode)
+		}
+		return nil, e
+	}
+	return body, nil
+}
+
+func (c *client) isRepositoryHost(u *url.URL) bool {
+	repository, err := url.Parse(c.url)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, repository.Scheme) && strings.EqualFold(u.Host, repository.Host)
+}
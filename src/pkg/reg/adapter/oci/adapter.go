@@ -0,0 +1,333 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/artifact/processor"
+	// register the processor of images so that the images and indexes are typed as IMAGE
+	_ "github.com/goharbor/harbor/src/controller/artifact/processor/image"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	adp "github.com/goharbor/harbor/src/pkg/reg/adapter"
+	"github.com/goharbor/harbor/src/pkg/reg/adapter/native"
+	"github.com/goharbor/harbor/src/pkg/reg/filter"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/reg/util"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// the manifest media types that can be pulled from the OCI registries
+var accepts = []string{
+	v1.MediaTypeImageIndex,
+	manifestlist.MediaTypeManifestList,
+	v1.MediaTypeImageManifest,
+	registry.MediaTypeArtifactManifest,
+	schema2.MediaTypeManifest,
+	schema1.MediaTypeSignedManifest,
+	schema1.MediaTypeManifest,
+}
+
+func init() {
+	if err := adp.RegisterFactory(model.RegistryTypeOCIDistribution, new(factory)); err != nil {
+		log.Errorf("failed to register factory for %s: %v", model.RegistryTypeOCIDistribution, err)
+		return
+	}
+	log.Infof("the factory for adapter %s registered", model.RegistryTypeOCIDistribution)
+}
+
+type factory struct{}
+
+// Create ...
+func (f *factory) Create(r *model.Registry) (adp.Adapter, error) {
+	return newAdapter(r), nil
+}
+
+// AdapterPattern ...
+func (f *factory) AdapterPattern() *model.AdapterPattern {
+	return nil
+}
+
+var (
+	_ adp.Adapter          = (*adapter)(nil)
+	_ adp.ArtifactRegistry = (*adapter)(nil)
+)
+
+// adapter for the registries that implement the OCI distribution spec, e.g. Zot, Distribution.
+// Different with the native adapter, it doesn't require the catalog API when the repositories
+// are specified, and it resolves the digest, type and annotations of the artifacts from their
+// manifests. The auth scheme(bearer/basic) is negotiated by the "WWW-Authenticate" header
+// returned by the registry
+type adapter struct {
+	*native.Adapter
+	registry *model.Registry
+}
+
+func newAdapter(registry *model.Registry) *adapter {
+	return &adapter{
+		Adapter:  native.NewAdapter(registry),
+		registry: registry,
+	}
+}
+
+// Info returns the basic information about the adapter
+func (a *adapter) Info() (*model.RegistryInfo, error) {
+	return &model.RegistryInfo{
+		Type: model.RegistryTypeOCIDistribution,
+		SupportedResourceTypes: []string{
+			model.ResourceTypeImage,
+		},
+		SupportedResourceFilters: []*model.FilterStyle{
+			{
+				Type:  model.FilterTypeName,
+				Style: model.FilterStyleTypeText,
+			},
+			{
+				Type:  model.FilterTypeTag,
+				Style: model.FilterStyleTypeText,
+			},
+			{
+				Type:  model.FilterTypeArtifactType,
+				Style: model.FilterStyleTypeText,
+			},
+			{
+				Type:  model.FilterTypeAnnotation,
+				Style: model.FilterStyleTypeText,
+			},
+		},
+		SupportedTriggers: []string{
+			model.TriggerTypeManual,
+			model.TriggerTypeScheduled,
+		},
+	}, nil
+}
+
+// FetchArtifacts ...
+func (a *adapter) FetchArtifacts(filters []*model.Filter) ([]*model.Resource, error) {
+	repositories, err := a.listRepositories(filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(repositories) == 0 {
+		return nil, nil
+	}
+
+	var rawResources = make([]*model.Resource, len(repositories))
+	runner := utils.NewLimitedConcurrentRunner(adp.MaxConcurrency)
+
+	for i, r := range repositories {
+		index := i
+		repo := r
+		runner.AddTask(func() error {
+			artifacts, err := a.listArtifacts(repo.Name, filters)
+			if err != nil {
+				return fmt.Errorf("failed to list artifacts of repository %s: %v", repo.Name, err)
+			}
+			if len(artifacts) == 0 {
+				return nil
+			}
+			rawResources[index] = &model.Resource{
+				Type:     model.ResourceTypeImage,
+				Registry: a.registry,
+				Metadata: &model.ResourceMetadata{
+					Repository: &model.Repository{
+						Name: repo.Name,
+					},
+					Artifacts: artifacts,
+				},
+			}
+			return nil
+		})
+	}
+	if err = runner.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to fetch artifacts: %v", err)
+	}
+
+	var resources []*model.Resource
+	for _, r := range rawResources {
+		if r != nil {
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+// PullManifest pulls the manifest with the OCI artifact manifest accepted as well
+func (a *adapter) PullManifest(repository, reference string, acceptedMediaTypes ...string) (distribution.Manifest, string, error) {
+	if len(acceptedMediaTypes) == 0 {
+		acceptedMediaTypes = accepts
+	}
+	return a.Adapter.PullManifest(repository, reference, acceptedMediaTypes...)
+}
+
+// list the repositories specified in the name filter, or discover them via the catalog API
+// if the name filter isn't a specific repository list
+func (a *adapter) listRepositories(filters []*model.Filter) ([]*model.Repository, error) {
+	pattern := ""
+	for _, filter := range filters {
+		if filter.Type == model.FilterTypeName {
+			pattern = filter.Value.(string)
+			break
+		}
+	}
+	var repositories []string
+	// if the pattern of repository name filter is a specific repository name, just returns
+	// the parsed repositories and will check the existence later when listing the tags
+	if paths, ok := util.IsSpecificPath(pattern); ok {
+		repositories = paths
+	} else {
+		// the catalog API is optional in the OCI distribution spec
+		var err error
+		repositories, err = a.Catalog()
+		if err != nil {
+			if errors.IsErr(err, errors.NotFoundCode) || errors.IsErr(err, errors.UnAuthorizedCode) ||
+				errors.IsErr(err, errors.ForbiddenCode) {
+				return nil, errors.New(err).WithCode(errors.BadRequestCode).
+					WithMessage("the catalog API isn't available on the registry %s, specify the repositories "+
+						"in the name filter, e.g. \"library/{hello-world,alpine}\": %v", a.registry.URL, err)
+			}
+			return nil, err
+		}
+	}
+
+	var result []*model.Repository
+	for _, repository := range repositories {
+		result = append(result, &model.Repository{
+			Name: repository,
+		})
+	}
+	return filter.DoFilterRepositories(result, filters)
+}
+
+// list the artifacts under the repository, the tags referring to the same manifest are grouped into one artifact
+func (a *adapter) listArtifacts(repository string, filters []*model.Filter) ([]*model.Artifact, error) {
+	tags, err := a.ListTags(repository)
+	if err != nil {
+		// the specified repository doesn't exist
+		if errors.IsErr(err, errors.NotFoundCode) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// apply the tag filters before pulling the manifests, so that only the manifests of the
+	// matched tags are pulled as pulling the manifest of every tag is expensive
+	tags, err = filterTags(tags, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []*model.Artifact
+	arts := map[string]*model.Artifact{}
+	for _, tag := range tags {
+		art, err := a.getArtifact(repository, tag)
+		if err != nil {
+			return nil, err
+		}
+		if existing, exist := arts[art.Digest]; exist {
+			existing.Tags = append(existing.Tags, tag)
+			continue
+		}
+		art.Tags = []string{tag}
+		arts[art.Digest] = art
+		artifacts = append(artifacts, art)
+	}
+	return filter.DoFilterArtifacts(artifacts, filters)
+}
+
+// filterTags returns the tags matching the tag filters, the tag filter matches each tag
+// of the artifact separately, so it's the same as applying it after the grouping
+func filterTags(tags []string, filters []*model.Filter) ([]string, error) {
+	var tagFilters []*model.Filter
+	for _, f := range filters {
+		if f.Type == model.FilterTypeTag {
+			tagFilters = append(tagFilters, f)
+		}
+	}
+	if len(tagFilters) == 0 {
+		return tags, nil
+	}
+	var artifacts []*model.Artifact
+	for _, tag := range tags {
+		artifacts = append(artifacts, &model.Artifact{
+			Tags: []string{tag},
+		})
+	}
+	artifacts, err := filter.DoFilterArtifacts(artifacts, tagFilters)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, art := range artifacts {
+		result = append(result, art.Tags...)
+	}
+	return result, nil
+}
+
+// get the digest, type and annotations of the artifact from its manifest
+func (a *adapter) getArtifact(repository, reference string) (*model.Artifact, error) {
+	manifest, dgt, err := a.PullManifest(repository, reference)
+	if err != nil {
+		return nil, err
+	}
+	mediaType, payload, err := manifest.Payload()
+	if err != nil {
+		return nil, err
+	}
+	// the "Docker-Content-Digest" header is optional in the OCI distribution spec
+	if len(dgt) == 0 {
+		dgt = digest.FromBytes(payload).String()
+	}
+	art := &model.Artifact{
+		Digest: dgt,
+	}
+	mani := &struct {
+		ArtifactType string            `json:"artifactType"`
+		Config       *v1.Descriptor    `json:"config"`
+		Annotations  map[string]string `json:"annotations"`
+	}{}
+	// the schema1 manifest doesn't contain the config and annotations
+	if mediaType != schema1.MediaTypeSignedManifest && mediaType != schema1.MediaTypeManifest {
+		if err = json.Unmarshal(payload, mani); err != nil {
+			return nil, err
+		}
+		art.Annotations = mani.Annotations
+	}
+
+	// the type of artifact is determined by the media type of the config for manifest, by the
+	// artifact type for artifact manifest and by the manifest media type for index and schema1 manifest
+	artMediaType := mediaType
+	switch {
+	case mediaType == registry.MediaTypeArtifactManifest:
+		artMediaType = mani.ArtifactType
+	case mani.Config != nil && len(mani.Config.MediaType) > 0:
+		artMediaType = mani.Config.MediaType
+	}
+	art.Type = processor.Get(artMediaType).GetArtifactType(context.Background(), &artifact.Artifact{
+		MediaType:         artMediaType,
+		ManifestMediaType: mediaType,
+	})
+	return art, nil
+}
@@ -0,0 +1,272 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	token        = "token"
+	catalogLimit = 2
+)
+
+var (
+	imageManifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},"layers":[],"annotations":{"org.opencontainers.image.vendor":"goharbor"}}`
+	index         = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2}]}`
+	artManifest   = `{"mediaType":"application/vnd.oci.artifact.manifest.v1+json","artifactType":"application/vnd.goharbor.demo.config.v1+json","blobs":[{"mediaType":"application/octet-stream","digest":"sha256:1b930d010525941c1d56ec53b97bd057a67ae1865eebf042686d2a2d18271ced","size":1}]}`
+)
+
+type manifest struct {
+	mediaType string
+	content   string
+}
+
+// registryStandIn is an in-process stand-in of the registries that implement the OCI distribution spec
+// with the bearer token auth, the catalog API is paginated by the "Link" header or disabled
+type registryStandIn struct {
+	catalogDisabled bool
+	// repository -> tag -> manifest
+	repositories map[string]map[string]*manifest
+	// the references of the pulled manifests
+	lock   sync.Mutex
+	pulled []string
+}
+
+func (r *registryStandIn) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		w.Write([]byte(fmt.Sprintf(`{"token":"%s"}`, token)))
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer "+token {
+		w.Header().Set("WWW-Authenticate",
+			fmt.Sprintf(`Bearer realm="http://%s/token",service="stand-in"`, req.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case len(path) == 0:
+		return
+	case path == "_catalog":
+		r.catalog(w, req)
+	case strings.HasSuffix(path, "/tags/list"):
+		repository := strings.TrimSuffix(path, "/tags/list")
+		tags, exist := r.repositories[repository]
+		if !exist {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		result := struct {
+			Name string   `json:"name"`
+			Tags []string `json:"tags"`
+		}{Name: repository}
+		for tag := range tags {
+			result.Tags = append(result.Tags, tag)
+		}
+		sort.Strings(result.Tags)
+		json.NewEncoder(w).Encode(result)
+	case strings.Contains(path, "/manifests/"):
+		strs := strings.SplitN(path, "/manifests/", 2)
+		r.lock.Lock()
+		r.pulled = append(r.pulled, strs[0]+":"+strs[1])
+		r.lock.Unlock()
+		m := r.repositories[strs[0]][strs[1]]
+		if m == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// the "Docker-Content-Digest" header is optional
+		w.Header().Set("Content-Type", m.mediaType)
+		w.Write([]byte(m.content))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *registryStandIn) catalog(w http.ResponseWriter, req *http.Request) {
+	if r.catalogDisabled {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var repositories []string
+	for repository := range r.repositories {
+		repositories = append(repositories, repository)
+	}
+	sort.Strings(repositories)
+	// ignore the requested page size to make sure the result is paginated
+	last := req.URL.Query().Get("last")
+	start := 0
+	if len(last) > 0 {
+		start = sort.SearchStrings(repositories, last) + 1
+	}
+	end := start + catalogLimit
+	if end < len(repositories) {
+		w.Header().Set("Link", fmt.Sprintf(`</v2/_catalog?last=%s&n=%s>; rel="next"`,
+			repositories[end-1], strconv.Itoa(catalogLimit)))
+	} else {
+		end = len(repositories)
+	}
+	json.NewEncoder(w).Encode(struct {
+		Repositories []string `json:"repositories"`
+	}{Repositories: repositories[start:end]})
+}
+
+type adapterTestSuite struct {
+	suite.Suite
+	standIn *registryStandIn
+	server  *httptest.Server
+	adapter *adapter
+}
+
+func (a *adapterTestSuite) SetupTest() {
+	a.standIn = &registryStandIn{
+		repositories: map[string]map[string]*manifest{
+			"library/hello-world": {
+				"latest": {mediaType: v1.MediaTypeImageManifest, content: imageManifest},
+				"v1":     {mediaType: v1.MediaTypeImageManifest, content: imageManifest},
+			},
+			"library/multi-arch": {
+				"latest": {mediaType: v1.MediaTypeImageIndex, content: index},
+			},
+			"library/demo": {
+				"latest": {mediaType: registry.MediaTypeArtifactManifest, content: artManifest},
+			},
+		},
+	}
+	a.server = httptest.NewServer(a.standIn)
+	a.adapter = newAdapter(&model.Registry{
+		Type: model.RegistryTypeOCIDistribution,
+		URL:  a.server.URL,
+		Credential: &model.Credential{
+			AccessKey:    "user",
+			AccessSecret: "password",
+		},
+	})
+}
+
+func (a *adapterTestSuite) TearDownTest() {
+	a.server.Close()
+}
+
+func (a *adapterTestSuite) TestInfo() {
+	info, err := a.adapter.Info()
+	a.Require().Nil(err)
+	a.Equal(model.RegistryTypeOCIDistribution, info.Type)
+	a.Len(info.SupportedResourceFilters, 4)
+}
+
+func (a *adapterTestSuite) TestHealthCheck() {
+	status, err := a.adapter.HealthCheck()
+	a.Require().Nil(err)
+	a.Equal(model.Healthy, status)
+}
+
+func (a *adapterTestSuite) TestFetchArtifactsViaCatalog() {
+	resources, err := a.adapter.FetchArtifacts(nil)
+	a.Require().Nil(err)
+	a.Require().Len(resources, 3)
+
+	artifacts := map[string]*model.Artifact{}
+	for _, resource := range resources {
+		a.Require().Len(resource.Metadata.Artifacts, 1)
+		artifacts[resource.Metadata.Repository.Name] = resource.Metadata.Artifacts[0]
+	}
+
+	// the tags referring to the same manifest are grouped
+	art := artifacts["library/hello-world"]
+	a.Require().NotNil(art)
+	a.Equal(digest.FromString(imageManifest).String(), art.Digest)
+	a.Equal("IMAGE", art.Type)
+	a.Equal([]string{"latest", "v1"}, art.Tags)
+	a.Equal("goharbor", art.Annotations["org.opencontainers.image.vendor"])
+
+	art = artifacts["library/multi-arch"]
+	a.Require().NotNil(art)
+	a.Equal("IMAGE", art.Type)
+
+	art = artifacts["library/demo"]
+	a.Require().NotNil(art)
+	a.Equal("DEMO", art.Type)
+}
+
+func (a *adapterTestSuite) TestFetchArtifactsWithCatalogDisabled() {
+	a.standIn.catalogDisabled = true
+
+	_, err := a.adapter.FetchArtifacts(nil)
+	a.Require().NotNil(err)
+	a.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// the repositories are specified in the name filter
+	resources, err := a.adapter.FetchArtifacts([]*model.Filter{
+		{
+			Type:  model.FilterTypeName,
+			Value: "library/{hello-world,not-exist}",
+		},
+		{
+			Type:  model.FilterTypeTag,
+			Value: "v1",
+		},
+	})
+	a.Require().Nil(err)
+	a.Require().Len(resources, 1)
+	a.Equal("library/hello-world", resources[0].Metadata.Repository.Name)
+	a.Require().Len(resources[0].Metadata.Artifacts, 1)
+	a.Equal([]string{"v1"}, resources[0].Metadata.Artifacts[0].Tags)
+	// only the manifest of the matched tag is pulled
+	a.Equal([]string{"library/hello-world:v1"}, a.standIn.pulled)
+}
+
+func (a *adapterTestSuite) TestFetchArtifactsWithTypeFilter() {
+	resources, err := a.adapter.FetchArtifacts([]*model.Filter{
+		{
+			Type:  model.FilterTypeArtifactType,
+			Value: "DEMO",
+		},
+	})
+	a.Require().Nil(err)
+	a.Require().Len(resources, 1)
+	a.Equal("library/demo", resources[0].Metadata.Repository.Name)
+}
+
+func (a *adapterTestSuite) TestPullArtifactManifest() {
+	manifest, _, err := a.adapter.PullManifest("library/demo", "latest")
+	a.Require().Nil(err)
+	mediaType, payload, err := manifest.Payload()
+	a.Require().Nil(err)
+	a.Equal(registry.MediaTypeArtifactManifest, mediaType)
+	a.Equal(artManifest, string(payload))
+	a.Len(manifest.References(), 1)
+}
+
+func TestAdapterTestSuite(t *testing.T) {
+	suite.Run(t, &adapterTestSuite{})
+}
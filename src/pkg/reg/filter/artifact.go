@@ -15,9 +15,11 @@
 package filter
 
 import (
+	"strings"
+
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 	"github.com/goharbor/harbor/src/pkg/reg/util"
-	"strings"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
 // DoFilterArtifacts filter the artifacts according to the filters
@@ -52,6 +54,22 @@ func BuildArtifactFilters(filters []*model.Filter) (ArtifactFilters, error) {
 					types: []string{v},
 				}
 			}
+		case model.FilterTypeArtifactType:
+			f = &artifactTypeFilter{
+				types:      []string{filter.Value.(string)},
+				decoration: filter.Decoration,
+			}
+		case model.FilterTypeAnnotation:
+			key, pattern := model.ParseAnnotationFilter(filter.Value.(string))
+			f = &artifactAnnotationFilter{
+				key:        key,
+				pattern:    pattern,
+				decoration: filter.Decoration,
+			}
+		case model.FilterTypeSeverity:
+			f = &artifactSeverityFilter{
+				severity: vuln.Severity(filter.Value.(string)),
+			}
 		}
 		if f != nil {
 			fs = append(fs, f)
@@ -82,6 +100,8 @@ func (a ArtifactFilters) Filter(artifacts []*model.Artifact) ([]*model.Artifact,
 
 type artifactTypeFilter struct {
 	types []string
+	// "matches", "excludes"
+	decoration string
 }
 
 func (a *artifactTypeFilter) Filter(artifacts []*model.Artifact) ([]*model.Artifact, error) {
@@ -90,11 +110,80 @@ func (a *artifactTypeFilter) Filter(artifacts []*model.Artifact) ([]*model.Artif
 	}
 	var result []*model.Artifact
 	for _, artifact := range artifacts {
+		match := false
 		for _, t := range a.types {
-			if strings.ToLower(artifact.Type) == strings.ToLower(t) {
+			if strings.EqualFold(artifact.Type, t) {
+				match = true
+				break
+			}
+		}
+		if a.decoration == model.Excludes {
+			if !match {
 				result = append(result, artifact)
-				continue
 			}
+		} else {
+			if match {
+				result = append(result, artifact)
+			}
+		}
+	}
+	return result, nil
+}
+
+// filter the artifacts according to the annotation. The artifact matches if it contains the
+// annotation whose value matches the pattern, or contains the annotation only if no pattern defined
+type artifactAnnotationFilter struct {
+	key     string
+	pattern string
+	// "matches", "excludes"
+	decoration string
+}
+
+func (a *artifactAnnotationFilter) Filter(artifacts []*model.Artifact) ([]*model.Artifact, error) {
+	if len(a.key) == 0 {
+		return artifacts, nil
+	}
+	var result []*model.Artifact
+	for _, artifact := range artifacts {
+		value, exist := artifact.Annotations[a.key]
+		match := exist
+		if exist && len(a.pattern) > 0 {
+			var err error
+			match, err = util.Match(a.pattern, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if a.decoration == model.Excludes {
+			if !match {
+				result = append(result, artifact)
+			}
+		} else {
+			if match {
+				result = append(result, artifact)
+			}
+		}
+	}
+	return result, nil
+}
+
+// filter the artifacts whose overall severity of the latest scan summary is at or below
+// the specified one, the artifacts that haven't been scanned are filtered out
+type artifactSeverityFilter struct {
+	severity vuln.Severity
+}
+
+func (a *artifactSeverityFilter) Filter(artifacts []*model.Artifact) ([]*model.Artifact, error) {
+	if len(a.severity) == 0 {
+		return artifacts, nil
+	}
+	var result []*model.Artifact
+	for _, artifact := range artifacts {
+		if len(artifact.Severity) == 0 {
+			continue
+		}
+		if vuln.Severity(artifact.Severity).Code() <= a.severity.Code() {
+			result = append(result, artifact)
 		}
 	}
 	return result, nil
@@ -207,10 +296,12 @@ func (a *artifactTagFilter) Filter(artifacts []*model.Artifact) ([]*model.Artifa
 		}
 		// copy a new artifact here to avoid changing the original one
 		result = append(result, &model.Artifact{
-			Type:   artifact.Type,
-			Digest: artifact.Digest,
-			Labels: artifact.Labels,
-			Tags:   tags,
+			Type:        artifact.Type,
+			Digest:      artifact.Digest,
+			Labels:      artifact.Labels,
+			Tags:        tags,
+			Annotations: artifact.Annotations,
+			Severity:    artifact.Severity,
 		})
 	}
 	return result, nil
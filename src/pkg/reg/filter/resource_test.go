@@ -159,3 +159,158 @@ func TestArtifactLabelFilters(t *testing.T) {
 	require.EqualValues(t, "ddddd", arts[1].Digest)
 	require.Nil(t, arts[1].Labels)
 }
+
+func TestArtifactTypeFilters(t *testing.T) {
+	var artifacts = []*model.Artifact{
+		{
+			Type:   "IMAGE",
+			Digest: "aaaaa",
+		},
+		{
+			Type:   "CNAB",
+			Digest: "bbbbb",
+		},
+	}
+
+	var filters = []*model.Filter{
+		{
+			Type:  model.FilterTypeArtifactType,
+			Value: "image",
+		},
+	}
+
+	artFilters, err := BuildArtifactFilters(filters)
+	require.Nil(t, err)
+
+	arts, err := artFilters.Filter(artifacts)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(arts))
+	require.EqualValues(t, "aaaaa", arts[0].Digest)
+
+	filters = []*model.Filter{
+		{
+			Type:       model.FilterTypeArtifactType,
+			Value:      "IMAGE",
+			Decoration: model.Excludes,
+		},
+	}
+
+	artFilters, err = BuildArtifactFilters(filters)
+	require.Nil(t, err)
+
+	arts, err = artFilters.Filter(artifacts)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(arts))
+	require.EqualValues(t, "bbbbb", arts[0].Digest)
+}
+
+func TestArtifactAnnotationFilters(t *testing.T) {
+	var artifacts = []*model.Artifact{
+		{
+			Type:   model.ResourceTypeArtifact,
+			Digest: "aaaaa",
+			Annotations: map[string]string{
+				"org.opencontainers.image.vendor": "goharbor",
+			},
+		},
+		{
+			Type:   model.ResourceTypeArtifact,
+			Digest: "bbbbb",
+			Annotations: map[string]string{
+				"org.opencontainers.image.vendor": "others",
+			},
+		},
+		{
+			Type:   model.ResourceTypeArtifact,
+			Digest: "ccccc",
+		},
+	}
+
+	var filters = []*model.Filter{
+		{
+			Type:  model.FilterTypeAnnotation,
+			Value: "org.opencontainers.image.vendor=go*",
+		},
+	}
+
+	artFilters, err := BuildArtifactFilters(filters)
+	require.Nil(t, err)
+
+	arts, err := artFilters.Filter(artifacts)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(arts))
+	require.EqualValues(t, "aaaaa", arts[0].Digest)
+
+	filters = []*model.Filter{
+		{
+			Type:  model.FilterTypeAnnotation,
+			Value: "org.opencontainers.image.vendor",
+		},
+	}
+
+	artFilters, err = BuildArtifactFilters(filters)
+	require.Nil(t, err)
+
+	arts, err = artFilters.Filter(artifacts)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(arts))
+	require.EqualValues(t, "aaaaa", arts[0].Digest)
+	require.EqualValues(t, "bbbbb", arts[1].Digest)
+
+	filters = []*model.Filter{
+		{
+			Type:       model.FilterTypeAnnotation,
+			Value:      "org.opencontainers.image.vendor=go*",
+			Decoration: model.Excludes,
+		},
+	}
+
+	artFilters, err = BuildArtifactFilters(filters)
+	require.Nil(t, err)
+
+	arts, err = artFilters.Filter(artifacts)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(arts))
+	require.EqualValues(t, "bbbbb", arts[0].Digest)
+	require.EqualValues(t, "ccccc", arts[1].Digest)
+}
+
+func TestArtifactSeverityFilters(t *testing.T) {
+	var artifacts = []*model.Artifact{
+		{
+			Type:     model.ResourceTypeArtifact,
+			Digest:   "aaaaa",
+			Severity: "Low",
+		},
+		{
+			Type:     model.ResourceTypeArtifact,
+			Digest:   "bbbbb",
+			Severity: "Critical",
+		},
+		{
+			Type:     model.ResourceTypeArtifact,
+			Digest:   "ccccc",
+			Severity: "None",
+		},
+		{
+			Type:   model.ResourceTypeArtifact,
+			Digest: "ddddd",
+		},
+	}
+
+	var filters = []*model.Filter{
+		{
+			Type:  model.FilterTypeSeverity,
+			Value: "Medium",
+		},
+	}
+
+	artFilters, err := BuildArtifactFilters(filters)
+	require.Nil(t, err)
+
+	arts, err := artFilters.Filter(artifacts)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(arts))
+	require.EqualValues(t, "aaaaa", arts[0].Digest)
+	require.EqualValues(t, "ccccc", arts[1].Digest)
+}
@@ -51,6 +51,10 @@ import (
 	_ "github.com/goharbor/harbor/src/pkg/reg/adapter/tencentcr"
 	// register the Github Container Registry adapter
 	_ "github.com/goharbor/harbor/src/pkg/reg/adapter/githubcr"
+	// register the OCI distribution adapter
+	_ "github.com/goharbor/harbor/src/pkg/reg/adapter/oci"
+	// register the Helm chart repository adapter
+	_ "github.com/goharbor/harbor/src/pkg/reg/adapter/helmrepo"
 
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -14,7 +14,12 @@
 
 package model
 
-import "github.com/goharbor/harbor/src/lib/errors"
+import (
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+)
 
 // const definition
 const (
@@ -22,6 +27,17 @@ const (
 	FilterTypeName     = "name"
 	FilterTypeTag      = "tag"
 	FilterTypeLabel    = "label"
+	// FilterTypeArtifactType filters the artifacts by their types, e.g. IMAGE, CHART, CNAB
+	FilterTypeArtifactType = "artifact_type"
+	// FilterTypeAnnotation filters the artifacts by their annotations, the value is in
+	// the format "key=pattern", the artifact matches if it has the annotation "key" whose
+	// value matches the pattern. If only the key is specified, the artifact matches if it
+	// has the annotation whatever the value is
+	FilterTypeAnnotation = "annotation"
+	// FilterTypeSeverity filters the artifacts whose overall severity of the latest
+	// scan summary is at or below the specified one, the unscanned artifacts never match.
+	// The event based policies with this filter are triggered when the scan completes
+	FilterTypeSeverity = "severity"
 
 	TriggerTypeManual     = "manual"
 	TriggerTypeScheduled  = "scheduled"
@@ -58,7 +74,35 @@ func (f *Filter) Validate() error {
 		if f.Type == FilterTypeName || f.Type == FilterTypeResource {
 			if f.Decoration != "" {
 				return errors.New(nil).WithCode(errors.BadRequestCode).
-					WithMessage("only tag and label filter support decoration")
+					WithMessage("only tag, label, artifact type and annotation filter support decoration")
+			}
+		}
+	case FilterTypeArtifactType, FilterTypeAnnotation, FilterTypeSeverity:
+		value, ok := f.Value.(string)
+		if !ok {
+			return errors.New(nil).WithCode(errors.BadRequestCode).
+				WithMessage("the type of filter value isn't string")
+		}
+		switch f.Type {
+		case FilterTypeArtifactType:
+			if len(value) == 0 {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessage("the artifact type filter cannot be empty")
+			}
+		case FilterTypeAnnotation:
+			key, _ := ParseAnnotationFilter(value)
+			if len(key) == 0 {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessage("invalid annotation filter: %s", value)
+			}
+		case FilterTypeSeverity:
+			if !isValidSeverity(value) {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessage("invalid severity filter: %s", value)
+			}
+			if f.Decoration != "" {
+				return errors.New(nil).WithCode(errors.BadRequestCode).
+					WithMessage("only tag, label, artifact type and annotation filter support decoration")
 			}
 		}
 	case FilterTypeLabel:
@@ -87,6 +131,26 @@ func (f *Filter) Validate() error {
 	return nil
 }
 
+// ParseAnnotationFilter parses the value of the annotation filter into the annotation key and the pattern
+// of the annotation value. The pattern is empty if only the key is specified
+func ParseAnnotationFilter(value string) (string, string) {
+	strs := strings.SplitN(value, "=", 2)
+	key := strings.TrimSpace(strs[0])
+	if len(strs) == 1 {
+		return key, ""
+	}
+	return key, strs[1]
+}
+
+func isValidSeverity(severity string) bool {
+	switch vuln.Severity(severity) {
+	case vuln.None, vuln.Unknown, vuln.Negligible, vuln.Low, vuln.Medium, vuln.High, vuln.Critical:
+		return true
+	default:
+		return false
+	}
+}
+
 // Trigger holds info for a trigger
 type Trigger struct {
 	Type     string           `json:"type"`
@@ -34,9 +34,13 @@ const (
 	RegistryTypeDTR              = "dtr"
 	RegistryTypeTencentTcr       = "tencent-tcr"
 	RegistryTypeGithubCR         = "github-ghcr"
+	// RegistryTypeOCIDistribution is the registry that implements the OCI distribution spec
+	RegistryTypeOCIDistribution = "oci-distribution"
 
 	RegistryTypeHelmHub     = "helm-hub"
 	RegistryTypeArtifactHub = "artifact-hub"
+	// RegistryTypeHelmRepository is the classic Helm chart repository which serves the "index.yaml"
+	RegistryTypeHelmRepository = "helm-repository"
 
 	FilterStyleTypeText  = "input"
 	FilterStyleTypeRadio = "radio"
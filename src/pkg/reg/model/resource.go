@@ -50,8 +50,11 @@ type Repository struct {
 
 // Artifact is the individual unit that can be replicated
 type Artifact struct {
-	Type   string   `json:"type"`
-	Digest string   `json:"digest"`
-	Labels []string `json:"labels"`
-	Tags   []string `json:"tags"`
+	Type        string            `json:"type"`
+	Digest      string            `json:"digest"`
+	Labels      []string          `json:"labels"`
+	Tags        []string          `json:"tags"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// the overall severity of the latest scan summary, empty if the artifact isn't scanned
+	Severity string `json:"severity,omitempty"`
 }
@@ -0,0 +1,95 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/docker/distribution"
+	"github.com/opencontainers/go-digest"
+)
+
+// MediaTypeArtifactManifest is the media type of the OCI artifact manifest
+const MediaTypeArtifactManifest = "application/vnd.oci.artifact.manifest.v1+json"
+
+func init() {
+	unmarshal := func(b []byte) (distribution.Manifest, distribution.Descriptor, error) {
+		m := &ArtifactManifest{}
+		if err := m.UnmarshalJSON(b); err != nil {
+			return nil, distribution.Descriptor{}, err
+		}
+		if m.MediaType != MediaTypeArtifactManifest {
+			return nil, distribution.Descriptor{}, fmt.Errorf("mediaType in manifest should be '%s' not '%s'",
+				MediaTypeArtifactManifest, m.MediaType)
+		}
+		return m, distribution.Descriptor{
+			Digest:    digest.FromBytes(b),
+			Size:      int64(len(b)),
+			MediaType: MediaTypeArtifactManifest,
+		}, nil
+	}
+	if err := distribution.RegisterManifestSchema(MediaTypeArtifactManifest, unmarshal); err != nil {
+		panic(fmt.Sprintf("unable to register manifest: %s", err))
+	}
+}
+
+// ArtifactManifest is the OCI artifact manifest which references the blobs of the artifact
+// directly without the config, see https://github.com/opencontainers/image-spec/blob/v1.1.0-rc2/artifact.md
+type ArtifactManifest struct {
+	MediaType    string                    `json:"mediaType"`
+	ArtifactType string                    `json:"artifactType"`
+	Blobs        []distribution.Descriptor `json:"blobs,omitempty"`
+	Subject      *distribution.Descriptor  `json:"subject,omitempty"`
+	Annotations  map[string]string         `json:"annotations,omitempty"`
+
+	// the raw content of the manifest, returned as the payload to keep the digest unchanged
+	canonical []byte
+}
+
+// UnmarshalJSON populates the manifest from the raw content
+func (m *ArtifactManifest) UnmarshalJSON(b []byte) error {
+	m.canonical = make([]byte, len(b))
+	copy(m.canonical, b)
+
+	// use an alias type to avoid the infinite recursion
+	type manifest ArtifactManifest
+	mani := manifest{}
+	if err := json.Unmarshal(m.canonical, &mani); err != nil {
+		return err
+	}
+	mani.canonical = m.canonical
+	*m = ArtifactManifest(mani)
+	return nil
+}
+
+// MarshalJSON returns the raw content of the manifest
+func (m *ArtifactManifest) MarshalJSON() ([]byte, error) {
+	if len(m.canonical) > 0 {
+		return m.canonical, nil
+	}
+	return nil, fmt.Errorf("the artifact manifest isn't initialized by unmarshalling")
+}
+
+// References returns the blobs of the artifact, the subject isn't included as it
+// is an individual artifact that the artifact manifest refers to
+func (m *ArtifactManifest) References() []distribution.Descriptor {
+	return m.Blobs
+}
+
+// Payload returns the media type and the raw content of the manifest
+func (m *ArtifactManifest) Payload() (string, []byte, error) {
+	return MediaTypeArtifactManifest, m.canonical, nil
+}
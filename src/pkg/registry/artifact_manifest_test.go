@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalArtifactManifest(t *testing.T) {
+	data := `{
+  "mediaType": "application/vnd.oci.artifact.manifest.v1+json",
+  "artifactType": "application/vnd.example.sbom.v1",
+  "blobs": [
+    {
+      "mediaType": "application/gzip",
+      "size": 123,
+      "digest": "sha256:87923725d74f4bfb94c9e86d64170f7521aad8221a5de834851470ca142da630"
+    }
+  ],
+  "subject": {
+    "mediaType": "application/vnd.oci.image.manifest.v1+json",
+    "size": 1234,
+    "digest": "sha256:cc06a2839488b8bd2a2b99dcdc03d5cfd818eed72ad08ef3cc197aac64c0d0a0"
+  }
+}`
+	manifest, desc, err := distribution.UnmarshalManifest(MediaTypeArtifactManifest, []byte(data))
+	require.Nil(t, err)
+	assert.Equal(t, digest.FromString(data), desc.Digest)
+	assert.Equal(t, MediaTypeArtifactManifest, desc.MediaType)
+
+	// the subject isn't included in the references
+	references := manifest.References()
+	require.Len(t, references, 1)
+	assert.Equal(t, "sha256:87923725d74f4bfb94c9e86d64170f7521aad8221a5de834851470ca142da630", references[0].Digest.String())
+
+	mediaType, payload, err := manifest.Payload()
+	require.Nil(t, err)
+	assert.Equal(t, MediaTypeArtifactManifest, mediaType)
+	assert.Equal(t, data, string(payload))
+
+	artifactManifest, ok := manifest.(*ArtifactManifest)
+	require.True(t, ok)
+	assert.Equal(t, "application/vnd.example.sbom.v1", artifactManifest.ArtifactType)
+	require.NotNil(t, artifactManifest.Subject)
+
+	// mismatched media type
+	_, _, err = distribution.UnmarshalManifest(MediaTypeArtifactManifest, []byte(`{"mediaType":"application/vnd.oci.image.manifest.v1+json"}`))
+	assert.NotNil(t, err)
+}
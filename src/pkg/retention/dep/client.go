@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"github.com/goharbor/harbor/src/common/http/modifier/auth"
 	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/selector"
 	"github.com/goharbor/harbor/src/pkg/clients/core"
 	"net/http"
@@ -119,16 +120,19 @@ func (bc *basicClient) GetCandidates(repository *selector.Repository) ([]*select
 				}
 			}
 			candidate := &selector.Candidate{
-				Kind:         selector.Image,
-				NamespaceID:  repository.NamespaceID,
-				Namespace:    repository.Namespace,
-				Repository:   repository.Name,
-				Tags:         tags,
-				Digest:       art.Digest,
-				Labels:       labels,
-				CreationTime: art.PushTime.Unix(),
-				PulledTime:   lastPulledTime.Unix(),
-				PushedTime:   lastPushedTime.Unix(),
+				Kind:                  selector.Image,
+				NamespaceID:           repository.NamespaceID,
+				Namespace:             repository.Namespace,
+				Repository:            repository.Name,
+				Tags:                  tags,
+				Digest:                art.Digest,
+				Labels:                labels,
+				ArtifactType:          art.Type,
+				Size:                  art.Size,
+				VulnerabilitySeverity: vulnerabilitySeverity(art),
+				CreationTime:          art.PushTime.Unix(),
+				PulledTime:            lastPulledTime.Unix(),
+				PushedTime:            lastPushedTime.Unix(),
 			}
 			candidates = append(candidates, candidate)
 		}
@@ -195,3 +199,19 @@ func (bc *basicClient) Delete(candidate *selector.Candidate) error {
 		return fmt.Errorf("unsupported candidate kind: %s", candidate.Kind)
 	}
 }
+
+// vulnerabilitySeverity returns the severity code of the artifact from the successful scan reports,
+// the artifacts which are not scanned successfully are treated as no vulnerabilities to avoid
+// being cleaned up by the retention unexpectedly
+func vulnerabilitySeverity(art *core.Artifact) uint {
+	var code int
+	for _, summary := range art.ScanOverview {
+		if summary == nil || summary.ScanStatus != job.SuccessStatus.String() {
+			continue
+		}
+		if c := summary.Severity.Code(); c > code {
+			code = c
+		}
+	}
+	return uint(code)
+}
@@ -25,10 +25,12 @@ import (
 
 	"github.com/goharbor/harbor/src/chartserver"
 	jmodels "github.com/goharbor/harbor/src/common/job/models"
-	modelsv2 "github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/tag"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/pkg/clients/core"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	model_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
 	"github.com/goharbor/harbor/src/testing/clients"
 )
@@ -37,9 +39,21 @@ type fakeCoreClient struct {
 	clients.DumbCoreClient
 }
 
-func (f *fakeCoreClient) ListAllArtifacts(project, repository string) ([]*modelsv2.Artifact, error) {
-	image := &modelsv2.Artifact{}
+func (f *fakeCoreClient) ListAllArtifacts(project, repository string) ([]*core.Artifact, error) {
+	image := &core.Artifact{}
 	image.Digest = "sha256:123456"
+	image.Type = "IMAGE"
+	image.Size = 1024
+	image.ScanOverview = map[string]*vuln.NativeReportSummary{
+		v1.MimeTypeNativeReport: {
+			ScanStatus: job.SuccessStatus.String(),
+			Severity:   vuln.High,
+		},
+		v1.MimeTypeGenericVulnerabilityReport: {
+			ScanStatus: job.ErrorStatus.String(),
+			Severity:   vuln.Critical,
+		},
+	}
 	image.Tags = []*tag.Tag{
 		{
 			Tag: model_tag.Tag{
@@ -47,7 +61,7 @@ func (f *fakeCoreClient) ListAllArtifacts(project, repository string) ([]*models
 			},
 		},
 	}
-	return []*modelsv2.Artifact{image}, nil
+	return []*core.Artifact{image}, nil
 }
 
 func (f *fakeCoreClient) ListAllCharts(project, repository string) ([]*chartserver.ChartVersion, error) {
@@ -100,6 +114,9 @@ func (c *clientTestSuite) TestGetCandidates() {
 	assert.Equal(c.T(), "library", candidates[0].Namespace)
 	assert.Equal(c.T(), "hello-world", candidates[0].Repository)
 	assert.Equal(c.T(), "latest", candidates[0].Tags[0])
+	assert.Equal(c.T(), "IMAGE", candidates[0].ArtifactType)
+	assert.Equal(c.T(), int64(1024), candidates[0].Size)
+	assert.Equal(c.T(), uint(vuln.High.Code()), candidates[0].VulnerabilitySeverity)
 
 	/*
 		// chart repository
@@ -188,6 +188,22 @@ func (l *launcher) Launch(ctx context.Context, ply *policy.Metadata, executionID
 		}
 	}
 
+	// the artifacts of the repositories which aren't matched by all the rules cannot be
+	// matched by the AND algorithm, skip them to avoid broadening the policy
+	if ply.Algorithm == policy.AlgorithmAND {
+		enabled := 0
+		for _, rule := range ply.Rules {
+			if !rule.Disabled {
+				enabled++
+			}
+		}
+		for reposit, metadata := range repositoryRules {
+			if len(metadata.Rules) < enabled {
+				delete(repositoryRules, reposit)
+			}
+		}
+	}
+
 	// create job data list
 	jobDatas, err := createJobs(repositoryRules, isDryRun)
 	if err != nil {
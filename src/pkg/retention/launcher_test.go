@@ -258,6 +258,67 @@ func (l *launchTestSuite) TestLaunch() {
 	assert.Equal(l.T(), int64(1), n)
 }
 
+func (l *launchTestSuite) TestLaunchAND() {
+	l.execMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	l.taskMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
+	l.repositoryMgr.On("List", mock.Anything, mock.Anything).Return([]*model.RepoRecord{
+		{
+			RepositoryID: 1,
+			ProjectID:    1,
+			Name:         "library/image",
+		},
+		{
+			RepositoryID: 2,
+			ProjectID:    1,
+			Name:         "library/other",
+		},
+	}, nil)
+
+	launcher := &launcher{
+		projectMgr:       l.projectMgr,
+		repositoryMgr:    l.repositoryMgr,
+		retentionMgr:     l.retentionMgr,
+		execMgr:          l.execMgr,
+		taskMgr:          l.taskMgr,
+		jobserviceClient: l.jobserviceClient,
+	}
+
+	newRule := func(pattern string) rule.Metadata {
+		return rule.Metadata{
+			ScopeSelectors: map[string][]*rule.Selector{
+				"repository": {
+					{
+						Kind:       "doublestar",
+						Decoration: "repoMatches",
+						Pattern:    pattern,
+					},
+				},
+			},
+		}
+	}
+	ply := &policy.Metadata{
+		Algorithm: policy.AlgorithmAND,
+		Scope: &policy.Scope{
+			Level:     "project",
+			Reference: 1,
+		},
+		Rules: []rule.Metadata{
+			newRule("**"),
+			newRule("image"),
+		},
+	}
+	// only the repository matched by all the rules is handled
+	n, err := launcher.Launch(orm.Context(), ply, 1, false)
+	require.Nil(l.T(), err)
+	assert.Equal(l.T(), int64(1), n)
+
+	// all the repositories are handled by the OR algorithm
+	ply.Algorithm = policy.AlgorithmOR
+	n, err = launcher.Launch(orm.Context(), ply, 1, false)
+	require.Nil(l.T(), err)
+	assert.Equal(l.T(), int64(2), n)
+}
+
 func (l *launchTestSuite) TestStop() {
 	t := l.T()
 	l.execMgr.On("Stop", mock.Anything, mock.Anything).Return(nil)
@@ -27,6 +27,8 @@ var index sync.Map
 func init() {
 	// Register retain action
 	Register(action.Retain, action.NewRetainAction)
+	// Register delete action
+	Register(action.Delete, action.NewDeleteAction)
 }
 
 // Register the performer with the corresponding action
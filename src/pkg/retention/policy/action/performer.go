@@ -26,6 +26,8 @@ import (
 const (
 	// Retain artifacts
 	Retain = "retain"
+	// Delete artifacts
+	Delete = "delete"
 )
 
 // Performer performs the related actions targeting the candidates
@@ -92,6 +94,31 @@ func (ra *retainAction) Perform(ctx context.Context, candidates []*selector.Cand
 	return
 }
 
+// deleteAction make sure all the candidates will be cleared and others will be retained
+type deleteAction struct {
+	// Indicate if it is a dry run
+	isDryRun bool
+}
+
+// Perform the action
+func (da *deleteAction) Perform(ctx context.Context, candidates []*selector.Candidate) (results []*selector.Result, err error) {
+	for _, c := range candidates {
+		result := &selector.Result{
+			Target: c,
+		}
+		if isImmutable(ctx, c) {
+			result.Error = &selector.ImmutableError{}
+		} else if !da.isDryRun {
+			if err := dep.DefaultClient.Delete(c); err != nil {
+				result.Error = err
+			}
+		}
+		results = append(results, result)
+	}
+
+	return
+}
+
 func isImmutable(ctx context.Context, c *selector.Candidate) bool {
 	projectID := c.NamespaceID
 	repo := c.Repository
@@ -124,3 +151,10 @@ func NewRetainAction(params interface{}, isDryRun bool) Performer {
 		isDryRun: isDryRun,
 	}
 }
+
+// NewDeleteAction is factory method for DeleteAction
+func NewDeleteAction(params interface{}, isDryRun bool) Performer {
+	return &deleteAction{
+		isDryRun: isDryRun,
+	}
+}
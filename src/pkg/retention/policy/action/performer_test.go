@@ -102,6 +102,18 @@ func (suite *TestPerformerSuite) TestPerform() {
 	assert.Equal(suite.T(), "dev", results[0].Target.Tags[0])
 }
 
+// TestPerformDelete tests Perform of delete action
+func (suite *TestPerformerSuite) TestPerformDelete() {
+	p := NewDeleteAction(nil, false)
+
+	results, err := p.Perform(orm.Context(), suite.all[1:])
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, len(results))
+	require.NotNil(suite.T(), results[0].Target)
+	assert.NoError(suite.T(), results[0].Error)
+	assert.Equal(suite.T(), "dev", results[0].Target.Tags[0])
+}
+
 // TestPerform tests Perform action
 func (suite *TestPerformerSuite) TestPerformImmutable() {
 	all := []*selector.Candidate{
@@ -0,0 +1,131 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package and
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/alg"
+)
+
+// processor to handle the rules with AND mapping ways, only the candidates matched
+// by all the rules are performed with the action of the rules
+type processor struct {
+	// the rules with the evaluator, selectors and performer
+	// attentions here, the selectors can be empty/nil, that means match all "**"
+	parameters []*alg.Parameter
+}
+
+// New processor
+func New(parameters []*alg.Parameter) alg.Processor {
+	p := &processor{}
+
+	for _, param := range parameters {
+		if param != nil && param.Evaluator != nil {
+			p.parameters = append(p.parameters, param)
+		}
+	}
+
+	return p
+}
+
+// Process the candidates with the rules
+func (p *processor) Process(ctx context.Context, artifacts []*selector.Candidate) ([]*selector.Result, error) {
+	if len(artifacts) == 0 || len(p.parameters) == 0 {
+		log.Debug("no artifacts to retention")
+		return make([]*selector.Result, 0), nil
+	}
+
+	var (
+		act       = p.parameters[0].Evaluator.Action()
+		performer action.Performer
+		// the candidates matched by all the rules
+		matched cHash
+	)
+
+	for _, param := range p.parameters {
+		if param.Evaluator.Action() != act {
+			return nil, errors.Errorf("rules with different actions %s and %s cannot be combined in AND processor",
+				act, param.Evaluator.Action())
+		}
+		if performer == nil {
+			performer = param.Performer
+		}
+
+		// each rule is evaluated against all the candidates
+		// pass array copy to the selector
+		processed := append([]*selector.Candidate{}, artifacts...)
+		var err error
+		for _, s := range param.Selectors {
+			if processed, err = s.Select(processed); err != nil {
+				return nil, errors.Wrap(err, "artifact processing error")
+			}
+		}
+		if processed, err = param.Evaluator.Process(processed); err != nil {
+			return nil, errors.Wrap(err, "artifact processing error")
+		}
+
+		current := make(cHash)
+		for _, c := range processed {
+			if matched == nil {
+				current[c.Hash()] = c
+				continue
+			}
+			if _, ok := matched[c.Hash()]; ok {
+				current[c.Hash()] = c
+			}
+		}
+		matched = current
+	}
+
+	cl := matched.toList()
+	if performer == nil {
+		return errorResults(cl, errors.Errorf("no performer added for action %s in AND processor", act)), nil
+	}
+
+	results, err := performer.Perform(ctx, cl)
+	if err != nil {
+		return errorResults(cl, err), nil
+	}
+
+	return results, nil
+}
+
+func errorResults(candidates []*selector.Candidate, err error) []*selector.Result {
+	results := make([]*selector.Result, 0)
+	for _, c := range candidates {
+		results = append(results, &selector.Result{
+			Target: c,
+			Error:  err,
+		})
+	}
+	return results
+}
+
+type cHash map[string]*selector.Candidate
+
+func (ch cHash) toList() []*selector.Candidate {
+	l := make([]*selector.Candidate, 0)
+
+	for _, v := range ch {
+		l = append(l, v)
+	}
+
+	return l
+}
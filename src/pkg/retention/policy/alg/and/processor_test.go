@@ -0,0 +1,181 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package and
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/lib/selector/selectors/doublestar"
+	"github.com/goharbor/harbor/src/pkg/retention/dep"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/alg"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/lastx"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/notpulled"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/pushedbefore"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/untagged"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// ProcessorTestSuite is suite for testing processor
+type ProcessorTestSuite struct {
+	suite.Suite
+
+	all []*selector.Candidate
+
+	oldClient dep.Client
+}
+
+// TestProcessor is entrance for ProcessorTestSuite
+func TestProcessor(t *testing.T) {
+	suite.Run(t, new(ProcessorTestSuite))
+}
+
+// SetupSuite ...
+func (suite *ProcessorTestSuite) SetupSuite() {
+	dao.PrepareTestForPostgresSQL()
+	old := time.Now().Add(-100 * 24 * time.Hour).Unix()
+	suite.all = []*selector.Candidate{
+		{
+			Namespace:  "library",
+			Repository: "harbor",
+			Kind:       "image",
+			Digest:     "old-untagged",
+			PushedTime: old,
+			PulledTime: old,
+		},
+		{
+			Namespace:  "library",
+			Repository: "harbor",
+			Kind:       "image",
+			Tags:       []string{"old"},
+			Digest:     "old-tagged",
+			PushedTime: old,
+			PulledTime: old,
+		},
+		{
+			Namespace:  "library",
+			Repository: "harbor",
+			Kind:       "image",
+			Digest:     "new-untagged",
+			PushedTime: time.Now().Unix(),
+			PulledTime: time.Now().Unix(),
+		},
+	}
+
+	suite.oldClient = dep.DefaultClient
+	dep.DefaultClient = &fakeRetentionClient{}
+}
+
+// TearDownSuite ...
+func (suite *ProcessorTestSuite) TearDownSuite() {
+	dep.DefaultClient = suite.oldClient
+}
+
+// TestProcess tests process method
+func (suite *ProcessorTestSuite) TestProcess() {
+	perf := action.NewDeleteAction(nil, false)
+	selectors := []selector.Selector{
+		doublestar.New(doublestar.Matches, "**", ""),
+	}
+
+	params := []*alg.Parameter{
+		{
+			Evaluator: pushedbefore.New(rule.Parameters{pushedbefore.ParameterN: 90}),
+			Selectors: selectors,
+			Performer: perf,
+		},
+		{
+			Evaluator: untagged.New(rule.Parameters{}),
+			Performer: perf,
+		},
+		{
+			Evaluator: notpulled.New(rule.Parameters{notpulled.ParameterN: 30}),
+			Performer: perf,
+		},
+	}
+
+	results, err := New(params).Process(orm.Context(), suite.all)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, len(results))
+	assert.Nil(suite.T(), results[0].Error)
+	assert.Equal(suite.T(), "old-untagged", results[0].Target.Digest)
+}
+
+// TestProcessDryRun tests process method with dry run
+func (suite *ProcessorTestSuite) TestProcessDryRun() {
+	dep.DefaultClient = &fakeRetentionClient{err: errors.New("should not be called")}
+	defer func() {
+		dep.DefaultClient = &fakeRetentionClient{}
+	}()
+
+	perf := action.NewDeleteAction(nil, true)
+	params := []*alg.Parameter{
+		{
+			Evaluator: pushedbefore.New(rule.Parameters{pushedbefore.ParameterN: 90}),
+			Performer: perf,
+		},
+	}
+
+	results, err := New(params).Process(orm.Context(), suite.all)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 2, len(results))
+	for _, r := range results {
+		assert.Nil(suite.T(), r.Error)
+	}
+}
+
+// TestProcessMixedActions tests the rules with different actions
+func (suite *ProcessorTestSuite) TestProcessMixedActions() {
+	params := []*alg.Parameter{
+		{
+			Evaluator: untagged.New(rule.Parameters{}),
+			Performer: action.NewDeleteAction(nil, false),
+		},
+		{
+			Evaluator: lastx.New(rule.Parameters{lastx.ParameterX: 10}),
+			Performer: action.NewRetainAction(suite.all, false),
+		},
+	}
+
+	_, err := New(params).Process(orm.Context(), suite.all)
+	require.Error(suite.T(), err)
+}
+
+type fakeRetentionClient struct {
+	err error
+}
+
+// GetCandidates ...
+func (frc *fakeRetentionClient) GetCandidates(repo *selector.Repository) ([]*selector.Candidate, error) {
+	return nil, errors.New("not implemented")
+}
+
+// Delete ...
+func (frc *fakeRetentionClient) Delete(candidate *selector.Candidate) error {
+	return frc.err
+}
+
+// DeleteRepository ...
+func (frc *fakeRetentionClient) DeleteRepository(repo *selector.Repository) error {
+	panic("implement me")
+}
@@ -19,12 +19,15 @@ import (
 
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/alg"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/alg/and"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/alg/or"
 )
 
 const (
 	// AlgorithmOR for || algorithm
 	AlgorithmOR = "or"
+	// AlgorithmAND for && algorithm
+	AlgorithmAND = "and"
 )
 
 // index for keeping the mapping between algorithm and its processor
@@ -33,6 +36,8 @@ var index sync.Map
 func init() {
 	// Register or
 	Register(AlgorithmOR, or.New)
+	// Register and
+	Register(AlgorithmAND, and.New)
 }
 
 // Register processor with the algorithm
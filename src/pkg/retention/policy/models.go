@@ -25,6 +25,9 @@ const (
 	// AlgorithmOR for OR algorithm
 	AlgorithmOR = "or"
 
+	// AlgorithmAND for AND algorithm
+	AlgorithmAND = "and"
+
 	// TriggerKindSchedule Schedule
 	TriggerKindSchedule = "Schedule"
 
@@ -41,8 +44,8 @@ type Metadata struct {
 	ID int64 `json:"id"`
 
 	// Algorithm applied to the rules
-	// "OR" / "AND"
-	Algorithm string `json:"algorithm" valid:"Required;Match(or)"`
+	// "or" / "and"
+	Algorithm string `json:"algorithm" valid:"Required;Match(/^(or|and)$/)"`
 
 	// Rule collection
 	Rules []rule.Metadata `json:"rules"`
@@ -79,6 +82,15 @@ func (m *Metadata) Valid(v *validation.Validation) {
 				_ = v.SetError("Parameters", err.Error())
 				return
 			}
+			if err := index.ValidAction(r.Template, r.Action); err != nil {
+				_ = v.SetError("Action", err.Error())
+				return
+			}
+			// the candidates retained by some rules cannot be deleted by the others
+			if r.Action != m.Rules[0].Action {
+				_ = v.SetError("Action", "the rules with different actions cannot be mixed in one policy")
+				return
+			}
 			if ok, _ := v.Valid(&r); !ok {
 				return
 			}
@@ -181,3 +181,77 @@ func TestParamValid(t *testing.T) {
 	require.True(t, v.HasErrors())
 	require.EqualValues(t, "Parameters", v.Errors[0].Field)
 }
+
+func TestActionValid(t *testing.T) {
+	newRule := func(action, template string, params rule.Parameters) rule.Metadata {
+		return rule.Metadata{
+			ID:         1,
+			Priority:   1,
+			Action:     action,
+			Template:   template,
+			Parameters: params,
+			TagSelectors: []*rule.Selector{
+				{
+					Kind:       "doublestar",
+					Decoration: "matches",
+					Pattern:    "**",
+				},
+			},
+			ScopeSelectors: map[string][]*rule.Selector{
+				"repository": {
+					{
+						Kind:       "doublestar",
+						Decoration: "repoMatches",
+						Pattern:    "**",
+					},
+				},
+			},
+		}
+	}
+	p := &Metadata{
+		Algorithm: "and",
+		Rules: []rule.Metadata{
+			newRule("delete", "pushedBeforeNDays", rule.Parameters{"pushedBeforeNDays": 90}),
+			newRule("delete", "untagged", rule.Parameters{}),
+			newRule("delete", "notPulledInNDays", rule.Parameters{"notPulledInNDays": 30}),
+		},
+		Trigger: &Trigger{
+			Kind: "Schedule",
+			Settings: map[string]interface{}{
+				"cron": "* 22 11 * * *",
+			},
+		},
+		Scope: &Scope{
+			Level:     "project",
+			Reference: 1,
+		},
+	}
+	v := &validation.Validation{}
+	ok, err := v.Valid(p)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	// the action isn't supported by the template
+	p.Rules[0].Action = "retain"
+	v = &validation.Validation{}
+	ok, err = v.Valid(p)
+	require.Nil(t, err)
+	require.False(t, ok)
+	require.EqualValues(t, "Action", v.Errors[0].Field)
+
+	// the actions of the rules are mixed
+	p.Rules[0] = newRule("retain", "latestPushedK", rule.Parameters{"latestPushedK": 10})
+	v = &validation.Validation{}
+	ok, err = v.Valid(p)
+	require.Nil(t, err)
+	require.False(t, ok)
+	require.EqualValues(t, "Action", v.Errors[0].Field)
+
+	// unknown algorithm
+	p.Algorithm = "xor"
+	v = &validation.Validation{}
+	ok, err = v.Valid(p)
+	require.Nil(t, err)
+	require.False(t, ok)
+	require.EqualValues(t, "Algorithm.Match", v.Errors[0].Key)
+}
@@ -0,0 +1,116 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arttype
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+)
+
+const (
+	// TemplateID of the rule
+	TemplateID = "artifactTypes"
+
+	// ParameterTypes is the name of the metadata parameter for the artifact types
+	ParameterTypes = TemplateID
+)
+
+// DefaultTypes are the default artifact types to retain
+var DefaultTypes = []string{"IMAGE"}
+
+// evaluator for retaining the artifacts of the specified types
+type evaluator struct {
+	types []string
+}
+
+// Process the candidates based on the rule definition
+func (e *evaluator) Process(artifacts []*selector.Candidate) (result []*selector.Candidate, err error) {
+	for _, a := range artifacts {
+		for _, t := range e.types {
+			if strings.EqualFold(a.ArtifactType, t) {
+				result = append(result, a)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// Specify what action is performed to the candidates processed by this evaluator
+func (e *evaluator) Action() string {
+	return action.Retain
+}
+
+// New a Evaluator
+func New(params rule.Parameters) rule.Evaluator {
+	if params != nil {
+		if p, ok := params[ParameterTypes]; ok {
+			if types, ok := parse(p); ok {
+				return &evaluator{types: types}
+			}
+		}
+	}
+
+	log.Debugf("default parameter %v used for rule %s", DefaultTypes, TemplateID)
+
+	return &evaluator{types: DefaultTypes}
+}
+
+// Valid ...
+func Valid(params rule.Parameters) error {
+	if params != nil {
+		if p, ok := params[ParameterTypes]; ok {
+			if _, ok := parse(p); !ok {
+				return fmt.Errorf("%s should be a non-empty list of artifact types", ParameterTypes)
+			}
+		}
+	}
+	return nil
+}
+
+// parse the types from the JSON array or the comma separated string
+func parse(p rule.Parameter) ([]string, bool) {
+	var items []string
+	switch v := p.(type) {
+	case string:
+		items = strings.Split(v, ",")
+	case []string:
+		items = v
+	case []interface{}:
+		for _, i := range v {
+			s, ok := i.(string)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, s)
+		}
+	default:
+		return nil, false
+	}
+
+	var types []string
+	for _, i := range items {
+		if t := strings.TrimSpace(i); len(t) > 0 {
+			types = append(types, strings.ToUpper(t))
+		}
+	}
+	return types, len(types) > 0
+}
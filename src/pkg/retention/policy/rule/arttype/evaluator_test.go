@@ -0,0 +1,92 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arttype
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type EvaluatorTestSuite struct {
+	suite.Suite
+}
+
+func (e *EvaluatorTestSuite) TestNew() {
+	tests := []struct {
+		Name     string
+		args     rule.Parameters
+		expected []string
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterTypes: []interface{}{"image", "CHART"}}, expected: []string{"IMAGE", "CHART"}},
+		{Name: "Comma Separated", args: map[string]rule.Parameter{ParameterTypes: "image, cnab"}, expected: []string{"IMAGE", "CNAB"}},
+		{Name: "Default If Not Set", args: map[string]rule.Parameter{}, expected: DefaultTypes},
+		{Name: "Default If Empty", args: map[string]rule.Parameter{ParameterTypes: []interface{}{}}, expected: DefaultTypes},
+		{Name: "Default If Wrong Type", args: map[string]rule.Parameter{ParameterTypes: float64(1)}, expected: DefaultTypes},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			e := New(tt.args).(*evaluator)
+
+			require.Equal(t, tt.expected, e.types)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestProcess() {
+	data := []*selector.Candidate{
+		{Digest: "image", ArtifactType: "IMAGE"},
+		{Digest: "chart", ArtifactType: "CHART"},
+		{Digest: "cnab", ArtifactType: "CNAB"},
+	}
+
+	tests := []struct {
+		Name     string
+		types    []interface{}
+		expected int
+	}{
+		{Name: "Image", types: []interface{}{"IMAGE"}, expected: 1},
+		{Name: "Image And Chart", types: []interface{}{"IMAGE", "chart"}, expected: 2},
+		{Name: "Not Exist", types: []interface{}{"UNKNOWN"}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			sut := New(map[string]rule.Parameter{ParameterTypes: tt.types})
+
+			result, err := sut.Process(data)
+
+			require.NoError(t, err)
+			require.Len(t, result, tt.expected)
+			require.Equal(t, action.Retain, sut.Action())
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestValid() {
+	require.NoError(e.T(), Valid(rule.Parameters{ParameterTypes: []interface{}{"IMAGE"}}))
+	require.Error(e.T(), Valid(rule.Parameters{ParameterTypes: []interface{}{}}))
+	require.Error(e.T(), Valid(rule.Parameters{ParameterTypes: []interface{}{1}}))
+	require.Error(e.T(), Valid(rule.Parameters{ParameterTypes: float64(1)}))
+}
+
+func TestEvaluatorSuite(t *testing.T) {
+	suite.Run(t, &EvaluatorTestSuite{})
+}
@@ -21,12 +21,19 @@ import (
 	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/always"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/arttype"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/dayspl"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/daysps"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/largestn"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/lastx"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestk"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestpl"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestps"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/notpulled"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/pushedbefore"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/severitybelow"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/smallestn"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/untagged"
 )
 
 // index for keeping the mapping between template ID and evaluator
@@ -37,7 +44,7 @@ type Metadata struct {
 	TemplateID string `json:"rule_template"`
 
 	// Action of the rule performs
-	// "retain"
+	// "retain" or "delete"
 	Action string `json:"action"`
 
 	Parameters []*IndexedParam `json:"params"`
@@ -163,6 +170,97 @@ func init() {
 			},
 		},
 	}, daysps.New, daysps.Valid)
+
+	// Register severity below
+	Register(&Metadata{
+		TemplateID: severitybelow.TemplateID,
+		Action:     action.Retain,
+		Parameters: []*IndexedParam{
+			{
+				Name:     severitybelow.ParameterSeverity,
+				Type:     "string",
+				Unit:     "severity",
+				Required: true,
+			},
+		},
+	}, severitybelow.New, severitybelow.Valid)
+
+	// Register largest n
+	Register(&Metadata{
+		TemplateID: largestn.TemplateID,
+		Action:     action.Retain,
+		Parameters: []*IndexedParam{
+			{
+				Name:     largestn.ParameterN,
+				Type:     "int",
+				Unit:     "count",
+				Required: true,
+			},
+		},
+	}, largestn.New, largestn.Valid)
+
+	// Register smallest n
+	Register(&Metadata{
+		TemplateID: smallestn.TemplateID,
+		Action:     action.Retain,
+		Parameters: []*IndexedParam{
+			{
+				Name:     smallestn.ParameterN,
+				Type:     "int",
+				Unit:     "count",
+				Required: true,
+			},
+		},
+	}, smallestn.New, smallestn.Valid)
+
+	// Register artifact types
+	Register(&Metadata{
+		TemplateID: arttype.TemplateID,
+		Action:     action.Retain,
+		Parameters: []*IndexedParam{
+			{
+				Name:     arttype.ParameterTypes,
+				Type:     "[]string",
+				Unit:     "type",
+				Required: true,
+			},
+		},
+	}, arttype.New, arttype.Valid)
+
+	// Register pushed before
+	Register(&Metadata{
+		TemplateID: pushedbefore.TemplateID,
+		Action:     action.Delete,
+		Parameters: []*IndexedParam{
+			{
+				Name:     pushedbefore.ParameterN,
+				Type:     "int",
+				Unit:     "days",
+				Required: true,
+			},
+		},
+	}, pushedbefore.New, pushedbefore.Valid)
+
+	// Register not pulled
+	Register(&Metadata{
+		TemplateID: notpulled.TemplateID,
+		Action:     action.Delete,
+		Parameters: []*IndexedParam{
+			{
+				Name:     notpulled.ParameterN,
+				Type:     "int",
+				Unit:     "days",
+				Required: true,
+			},
+		},
+	}, notpulled.New, notpulled.Valid)
+
+	// Register untagged
+	Register(&Metadata{
+		TemplateID: untagged.TemplateID,
+		Action:     action.Delete,
+		Parameters: []*IndexedParam{},
+	}, untagged.New)
 }
 
 // Register the rule evaluator with the corresponding rule template
@@ -216,6 +314,20 @@ func Valid(templateID string, parameters rule.Parameters) error {
 	return nil
 }
 
+// ValidAction checks whether the action is the one performed by the rule template
+func ValidAction(templateID string, act string) error {
+	v, ok := index.Load(templateID)
+	if !ok {
+		return errors.Errorf("rule evaluator %s is not registered", templateID)
+	}
+
+	item := v.(*indexedItem)
+	if item.Meta.Action != act {
+		return errors.Errorf("action %s is not supported by rule %s, it should be %s", act, templateID, item.Meta.Action)
+	}
+	return nil
+}
+
 // Get rule evaluator with the provided template ID
 func Get(templateID string, parameters rule.Parameters) (rule.Evaluator, error) {
 	if len(templateID) == 0 {
@@ -81,10 +81,17 @@ func (suite *IndexTestSuite) TestGet() {
 	})
 }
 
+// TestValidAction tests ValidAction
+func (suite *IndexTestSuite) TestValidAction() {
+	assert.NoError(suite.T(), ValidAction("fakeEvaluator", "retain"))
+	assert.Error(suite.T(), ValidAction("fakeEvaluator", "delete"))
+	assert.Error(suite.T(), ValidAction("notRegistered", "retain"))
+}
+
 // TestIndex tests Index
 func (suite *IndexTestSuite) TestIndex() {
 	metas := Index()
-	require.Equal(suite.T(), 8, len(metas))
+	require.Equal(suite.T(), 15, len(metas))
 	assert.Condition(suite.T(), func() bool {
 		for _, m := range metas {
 			if m.TemplateID == "fakeEvaluator" &&
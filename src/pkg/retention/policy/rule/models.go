@@ -30,7 +30,7 @@ type Metadata struct {
 	Disabled bool `json:"disabled"`
 
 	// Action of the rule performs
-	// "retain"
+	// "retain" or "delete"
 	Action string `json:"action" valid:"Required"`
 
 	// Template ID
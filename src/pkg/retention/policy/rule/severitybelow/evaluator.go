@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package severitybelow
+
+import (
+	"fmt"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/lib/selector/selectors/severity"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+)
+
+const (
+	// TemplateID of the rule
+	TemplateID = "severityBelow"
+
+	// ParameterSeverity is the name of the metadata parameter for the severity
+	ParameterSeverity = TemplateID
+
+	// DefaultSeverity is the default severity, the artifacts with the vulnerabilities
+	// of the severity or higher are not retained
+	DefaultSeverity = vuln.Critical
+)
+
+// evaluator for retaining the artifacts without the vulnerabilities of the severity or higher
+type evaluator struct {
+	severity vuln.Severity
+}
+
+// Process the candidates based on the rule definition
+func (e *evaluator) Process(artifacts []*selector.Candidate) ([]*selector.Candidate, error) {
+	return severity.New(severity.Lt, e.severity.Code(), "").Select(artifacts)
+}
+
+// Specify what action is performed to the candidates processed by this evaluator
+func (e *evaluator) Action() string {
+	return action.Retain
+}
+
+// New a Evaluator
+func New(params rule.Parameters) rule.Evaluator {
+	if params != nil {
+		if p, ok := params[ParameterSeverity]; ok {
+			if s, ok := parse(p); ok {
+				return &evaluator{severity: s}
+			}
+		}
+	}
+
+	log.Debugf("default parameter %s used for rule %s", DefaultSeverity, TemplateID)
+
+	return &evaluator{severity: DefaultSeverity}
+}
+
+// Valid ...
+func Valid(params rule.Parameters) error {
+	if params != nil {
+		if p, ok := params[ParameterSeverity]; ok {
+			if _, ok := parse(p); !ok {
+				return fmt.Errorf("%s should be one of Low, Medium, High and Critical", ParameterSeverity)
+			}
+		}
+	}
+	return nil
+}
+
+func parse(p rule.Parameter) (vuln.Severity, bool) {
+	str, ok := p.(string)
+	if !ok {
+		return "", false
+	}
+	switch s := vuln.ParseSeverityVersion3(str); s {
+	case vuln.Low, vuln.Medium, vuln.High, vuln.Critical:
+		return s, true
+	default:
+		return "", false
+	}
+}
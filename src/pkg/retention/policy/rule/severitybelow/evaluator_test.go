@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package severitybelow
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type EvaluatorTestSuite struct {
+	suite.Suite
+}
+
+func (e *EvaluatorTestSuite) TestNew() {
+	tests := []struct {
+		Name     string
+		args     rule.Parameters
+		expected vuln.Severity
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterSeverity: "High"}, expected: vuln.High},
+		{Name: "Lower Case", args: map[string]rule.Parameter{ParameterSeverity: "medium"}, expected: vuln.Medium},
+		{Name: "Default If Not Set", args: map[string]rule.Parameter{}, expected: DefaultSeverity},
+		{Name: "Default If Unknown", args: map[string]rule.Parameter{ParameterSeverity: "foo"}, expected: DefaultSeverity},
+		{Name: "Default If Wrong Type", args: map[string]rule.Parameter{ParameterSeverity: float64(5)}, expected: DefaultSeverity},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			e := New(tt.args).(*evaluator)
+
+			require.Equal(t, tt.expected, e.severity)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestProcess() {
+	data := []*selector.Candidate{
+		{Digest: "none", VulnerabilitySeverity: uint(vuln.None.Code())},
+		{Digest: "low", VulnerabilitySeverity: uint(vuln.Low.Code())},
+		{Digest: "high", VulnerabilitySeverity: uint(vuln.High.Code())},
+		{Digest: "critical", VulnerabilitySeverity: uint(vuln.Critical.Code())},
+	}
+
+	tests := []struct {
+		severity string
+		expected int
+	}{
+		{severity: "Low", expected: 1},
+		{severity: "Medium", expected: 2},
+		{severity: "High", expected: 2},
+		{severity: "Critical", expected: 3},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.severity, func(t *testing.T) {
+			sut := New(map[string]rule.Parameter{ParameterSeverity: tt.severity})
+
+			result, err := sut.Process(data)
+
+			require.NoError(t, err)
+			require.Len(t, result, tt.expected)
+			require.Equal(t, action.Retain, sut.Action())
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestValid() {
+	require.NoError(e.T(), Valid(rule.Parameters{ParameterSeverity: "Critical"}))
+	require.Error(e.T(), Valid(rule.Parameters{ParameterSeverity: "None"}))
+	require.Error(e.T(), Valid(rule.Parameters{ParameterSeverity: float64(5)}))
+}
+
+func TestEvaluatorSuite(t *testing.T) {
+	suite.Run(t, &EvaluatorTestSuite{})
+}
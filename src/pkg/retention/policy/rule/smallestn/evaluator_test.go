@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smallestn
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type EvaluatorTestSuite struct {
+	suite.Suite
+}
+
+func (e *EvaluatorTestSuite) TestNew() {
+	tests := []struct {
+		Name      string
+		args      rule.Parameters
+		expectedN int
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterN: float64(5)}, expectedN: 5},
+		{Name: "Default If Negative", args: map[string]rule.Parameter{ParameterN: float64(-1)}, expectedN: DefaultN},
+		{Name: "Default If Not Set", args: map[string]rule.Parameter{}, expectedN: DefaultN},
+		{Name: "Default If Wrong Type", args: map[string]rule.Parameter{ParameterN: "foo"}, expectedN: DefaultN},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			e := New(tt.args).(*evaluator)
+
+			require.Equal(t, tt.expectedN, e.n)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestProcess() {
+	tests := []struct {
+		n        float64
+		expected []string
+	}{
+		{n: 0, expected: []string{}},
+		{n: 1, expected: []string{"small"}},
+		{n: 2, expected: []string{"small", "new"}},
+		{n: 10, expected: []string{"small", "new", "old", "large"}},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(fmt.Sprintf("%v", tt.n), func(t *testing.T) {
+			data := []*selector.Candidate{
+				{Digest: "small", Size: 1},
+				{Digest: "old", Size: 10, PushedTime: 1},
+				{Digest: "large", Size: 100},
+				{Digest: "new", Size: 10, PushedTime: 2},
+			}
+			sut := New(map[string]rule.Parameter{ParameterN: tt.n})
+
+			result, err := sut.Process(data)
+
+			require.NoError(t, err)
+			digests := []string{}
+			for _, r := range result {
+				digests = append(digests, r.Digest)
+			}
+			require.Equal(t, tt.expected, digests)
+			require.Equal(t, action.Retain, sut.Action())
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestValid() {
+	require.NoError(e.T(), Valid(rule.Parameters{ParameterN: float64(10)}))
+	require.Error(e.T(), Valid(rule.Parameters{ParameterN: float64(-1)}))
+	require.Error(e.T(), Valid(rule.Parameters{ParameterN: float64(math.MaxInt16)}))
+	require.Error(e.T(), Valid(rule.Parameters{ParameterN: "foo"}))
+}
+
+func TestEvaluatorSuite(t *testing.T) {
+	suite.Run(t, &EvaluatorTestSuite{})
+}
@@ -21,6 +21,7 @@ export const ADAPTERS_MAP = {
   "harbor": "Harbor",
   "helm-hub": "Helm Hub",
   "artifact-hub": "Artifact Hub",
+  "helm-repository": "Helm Repository",
   "huawei-SWR": "Huawei SWR",
   "jfrog-artifactory": "JFrog Artifactory",
   "quay": "Quay",
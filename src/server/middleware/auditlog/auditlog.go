@@ -0,0 +1,300 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/audit"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+// the resource types of the audit logs recorded by the middleware
+const (
+	resourceTypeUser          = "user"
+	resourceTypeConfiguration = "configuration"
+	resourceTypeRobot         = "robot"
+	resourceTypeMember        = "member"
+	resourceTypeAPI           = "api"
+)
+
+var (
+	loginPattern         = regexp.MustCompile(`^/c/login/?$`)
+	configurationPattern = regexp.MustCompile(`^/api/v2\.0/configurations/?$`)
+	robotPattern         = regexp.MustCompile(`^/api/v2\.0/(projects/([^/]+)/)?robots(/(\d+))?/?$`)
+	memberPattern        = regexp.MustCompile(`^/api/v2\.0/projects/([^/]+)/members(/(\d+))?/?$`)
+
+	auditMgr          = audit.Mgr
+	projectController = project.Ctl
+	// the audit log is written by a new ormer as the transaction of the request may be rolled back
+	cloneContext = orm.Clone
+	// the failed logins and denied requests can be sent by anyone, limit the audit logs written for
+	// them so that a flood of such requests can't flood the audit_log table
+	deniedLimiter = newLimiter(time.Minute, 10, 1000)
+)
+
+// Middleware records the audit logs for the operations which aren't covered by the events:
+// the failed login attempts, the permission denied requests, and the changes of the
+// configurations, robot accounts and project members whether they succeed or not.
+// The middleware must be placed after the security middlewares to get the actor of the request.
+func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		var body []byte
+		if isWrite(r.Method) && r.Body != nil && (loginPattern.MatchString(r.URL.Path) ||
+			configurationPattern.MatchString(r.URL.Path) || robotPattern.MatchString(r.URL.Path) ||
+			memberPattern.MatchString(r.URL.Path)) {
+			r = lib.NopCloseRequest(r)
+			data, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				log.Errorf("failed to read the request body: %v", err)
+			}
+			body = data
+		}
+
+		res := lib.NewResponseRecorder(w)
+		next.ServeHTTP(res, r)
+
+		statusCode := res.StatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+		auditLog := resolve(r, body, statusCode)
+		if auditLog == nil {
+			return
+		}
+		client := lib.GetClientInfo(r.Context())
+		if isDenied(auditLog) && !deniedLimiter.allow(client.IP+"|"+auditLog.Username) {
+			return
+		}
+		ctx := cloneContext(r.Context())
+		if auditLog.ProjectID == 0 {
+			auditLog.ProjectID = projectID(ctx, r)
+		}
+		auditLog.ClientIP = client.IP
+		auditLog.UserAgent = client.UserAgent
+		auditLog.AuthMethod = client.AuthMethod
+		auditLog.StatusCode = statusCode
+		auditLog.OpTime = time.Now()
+		if _, err := auditMgr.Create(ctx, auditLog); err != nil {
+			log.Errorf("failed to create the audit log for %s %s: %v", r.Method, r.URL.Path, err)
+		}
+	}, skippers...)
+}
+
+// resolve returns the audit log for the request, nil is returned if the request needn't be audited
+func resolve(r *http.Request, body []byte, statusCode int) *model.AuditLog {
+	path := r.URL.Path
+	// the login with a redirection to the OIDC provider responds 403, only 401 means the login is failed
+	if loginPattern.MatchString(path) {
+		if r.Method != http.MethodPost || statusCode != http.StatusUnauthorized {
+			return nil
+		}
+		form, _ := url.ParseQuery(string(body))
+		principal := form.Get("principal")
+		return &model.AuditLog{
+			Operation:    "login",
+			ResourceType: resourceTypeUser,
+			Resource:     principal,
+			Username:     principal,
+		}
+	}
+
+	auditLog := &model.AuditLog{
+		Operation: operation(r.Method),
+		Username:  username(r.Context()),
+	}
+	switch {
+	case isWrite(r.Method) && configurationPattern.MatchString(path):
+		auditLog.ResourceType = resourceTypeConfiguration
+		auditLog.Resource = strings.Join(bodyKeys(body), ",")
+	case isWrite(r.Method) && robotPattern.MatchString(path):
+		auditLog.ResourceType = resourceTypeRobot
+		auditLog.Resource = robotPattern.FindStringSubmatch(path)[4]
+		if len(auditLog.Resource) == 0 {
+			auditLog.Resource = bodyField(body, "name")
+		}
+	case isWrite(r.Method) && memberPattern.MatchString(path):
+		auditLog.ResourceType = resourceTypeMember
+		auditLog.Resource = memberPattern.FindStringSubmatch(path)[3]
+		if len(auditLog.Resource) == 0 {
+			auditLog.Resource = memberName(body)
+		}
+	case statusCode == http.StatusForbidden,
+		// the credential carried by the request is invalid
+		statusCode == http.StatusUnauthorized && len(r.Header.Get("Authorization")) > 0:
+		auditLog.ResourceType = resourceTypeAPI
+		auditLog.Resource = path
+	default:
+		return nil
+	}
+	return auditLog
+}
+
+// projectID returns the ID of the project which the robot account or member belongs to
+func projectID(ctx context.Context, r *http.Request) int64 {
+	var nameOrID string
+	if m := robotPattern.FindStringSubmatch(r.URL.Path); m != nil {
+		nameOrID = m[2]
+	} else if m := memberPattern.FindStringSubmatch(r.URL.Path); m != nil {
+		nameOrID = m[1]
+	}
+	if len(nameOrID) == 0 {
+		return 0
+	}
+	var projectNameOrID interface{} = nameOrID
+	if id, err := strconv.ParseInt(nameOrID, 10, 64); err == nil && r.Header.Get("X-Is-Resource-Name") != "true" {
+		projectNameOrID = id
+	}
+	p, err := projectController.Get(ctx, projectNameOrID, project.Metadata(false))
+	if err != nil {
+		log.Debugf("failed to get the project %s: %v", nameOrID, err)
+		return 0
+	}
+	return p.ProjectID
+}
+
+// isDenied returns whether the audit log is for the failed login or denied request
+func isDenied(auditLog *model.AuditLog) bool {
+	return auditLog.ResourceType == resourceTypeAPI || auditLog.Operation == "login"
+}
+
+func isWrite(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut ||
+		method == http.MethodPatch || method == http.MethodDelete
+}
+
+func operation(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "read"
+	}
+}
+
+func username(ctx context.Context) string {
+	if sc, ok := security.FromContext(ctx); ok && sc.IsAuthenticated() {
+		return sc.GetUsername()
+	}
+	return "anonymous"
+}
+
+// bodyKeys returns the sorted keys of the JSON body, the values aren't recorded as they may contain the secrets
+func bodyKeys(body []byte) []string {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil
+	}
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func bodyField(body []byte, field string) string {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(body, &m); err != nil {
+		return ""
+	}
+	value, _ := m[field].(string)
+	return value
+}
+
+func memberName(body []byte) string {
+	member := &struct {
+		MemberUser *struct {
+			Username string `json:"username"`
+		} `json:"member_user"`
+		MemberGroup *struct {
+			GroupName string `json:"group_name"`
+		} `json:"member_group"`
+	}{}
+	if err := json.Unmarshal(body, member); err != nil {
+		return ""
+	}
+	if member.MemberUser != nil {
+		return member.MemberUser.Username
+	}
+	if member.MemberGroup != nil {
+		return member.MemberGroup.GroupName
+	}
+	return ""
+}
+
+// limiter allows at most "limit" times for each key and "total" times for all the keys in every window
+type limiter struct {
+	sync.Mutex
+	window  time.Duration
+	limit   int
+	total   int
+	start   time.Time
+	counts  map[string]int
+	allowed int
+	dropped int
+}
+
+func newLimiter(window time.Duration, limit, total int) *limiter {
+	return &limiter{
+		window: window,
+		limit:  limit,
+		total:  total,
+		counts: map[string]int{},
+	}
+}
+
+func (l *limiter) allow(key string) bool {
+	l.Lock()
+	defer l.Unlock()
+	now := time.Now()
+	if now.Sub(l.start) >= l.window {
+		if l.dropped > 0 {
+			log.Warningf("%d audit logs of the failed logins and denied requests are dropped in the last %s", l.dropped, l.window)
+		}
+		l.start = now
+		l.counts = map[string]int{}
+		l.allowed = 0
+		l.dropped = 0
+	}
+	// the keys are only added when allowed, so the size of the map is bounded by the total
+	if l.allowed >= l.total || l.counts[key] >= l.limit {
+		l.dropped++
+		return false
+	}
+	l.counts[key]++
+	l.allowed++
+	return true
+}
@@ -0,0 +1,212 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/pkg/audit"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	audittesting "github.com/goharbor/harbor/src/testing/pkg/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+
+	originalAuditMgr audit.Manager
+	auditMgr         *audittesting.Manager
+
+	originalProjectController project.Controller
+	projectController         *projecttesting.Controller
+
+	originalCloneContext  func(context.Context) context.Context
+	originalDeniedLimiter *limiter
+
+	auditLog *model.AuditLog
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	suite.originalAuditMgr = auditMgr
+	suite.auditMgr = &audittesting.Manager{}
+	auditMgr = suite.auditMgr
+
+	suite.originalProjectController = projectController
+	suite.projectController = &projecttesting.Controller{}
+	projectController = suite.projectController
+
+	suite.originalCloneContext = cloneContext
+	cloneContext = func(ctx context.Context) context.Context { return ctx }
+
+	suite.originalDeniedLimiter = deniedLimiter
+	deniedLimiter = newLimiter(time.Minute, 10, 1000)
+
+	suite.auditLog = nil
+	mock.OnAnything(suite.auditMgr, "Create").Return(int64(1), nil).Run(func(args mock.Arguments) {
+		suite.auditLog = args.Get(1).(*model.AuditLog)
+	})
+}
+
+func (suite *MiddlewareTestSuite) TearDownTest() {
+	auditMgr = suite.originalAuditMgr
+	projectController = suite.originalProjectController
+	cloneContext = suite.originalCloneContext
+	deniedLimiter = suite.originalDeniedLimiter
+}
+
+func (suite *MiddlewareTestSuite) serve(method, path, contentType, body string, statusCode int) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the handler must be able to read the body again
+		data, err := ioutil.ReadAll(r.Body)
+		suite.Require().Nil(err)
+		suite.Equal(body, string(data))
+		w.WriteHeader(statusCode)
+	})
+	var reader io.Reader
+	if len(body) > 0 {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", contentType)
+	req = req.WithContext(lib.WithClientInfo(req.Context(), lib.ClientInfo{
+		IP:         "10.0.0.1",
+		UserAgent:  "harbor-cli",
+		AuthMethod: "basic",
+	}))
+	Middleware()(next).ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func (suite *MiddlewareTestSuite) TestFailedLogin() {
+	suite.serve(http.MethodPost, "/c/login", "application/x-www-form-urlencoded", "principal=admin&password=wrong", http.StatusUnauthorized)
+	suite.Require().NotNil(suite.auditLog)
+	suite.Equal("login", suite.auditLog.Operation)
+	suite.Equal("user", suite.auditLog.ResourceType)
+	suite.Equal("admin", suite.auditLog.Resource)
+	suite.Equal("admin", suite.auditLog.Username)
+	suite.Equal("10.0.0.1", suite.auditLog.ClientIP)
+	suite.Equal("harbor-cli", suite.auditLog.UserAgent)
+	suite.Equal("basic", suite.auditLog.AuthMethod)
+	suite.Equal(http.StatusUnauthorized, suite.auditLog.StatusCode)
+}
+
+func (suite *MiddlewareTestSuite) TestSucceededLogin() {
+	suite.serve(http.MethodPost, "/c/login", "application/x-www-form-urlencoded", "principal=admin&password=Harbor12345", http.StatusOK)
+	suite.Nil(suite.auditLog)
+}
+
+func (suite *MiddlewareTestSuite) TestPermissionDenied() {
+	suite.serve(http.MethodGet, "/api/v2.0/projects/1/repositories", "", "", http.StatusForbidden)
+	suite.Require().NotNil(suite.auditLog)
+	suite.Equal("read", suite.auditLog.Operation)
+	suite.Equal("api", suite.auditLog.ResourceType)
+	suite.Equal("/api/v2.0/projects/1/repositories", suite.auditLog.Resource)
+	suite.Equal("anonymous", suite.auditLog.Username)
+	suite.Equal(http.StatusForbidden, suite.auditLog.StatusCode)
+}
+
+func (suite *MiddlewareTestSuite) TestDeniedFlood() {
+	deniedLimiter = newLimiter(time.Minute, 2, 1000)
+	for i := 0; i < 5; i++ {
+		suite.serve(http.MethodGet, "/api/v2.0/projects/1/repositories", "", "", http.StatusForbidden)
+	}
+	suite.auditMgr.AssertNumberOfCalls(suite.T(), "Create", 2)
+
+	// the changes are always audited
+	suite.serve(http.MethodPut, "/api/v2.0/configurations", "application/json", `{"auth_mode":"ldap_auth"}`, http.StatusForbidden)
+	suite.auditMgr.AssertNumberOfCalls(suite.T(), "Create", 3)
+}
+
+func (suite *MiddlewareTestSuite) TestNotAudited() {
+	suite.serve(http.MethodGet, "/api/v2.0/projects", "", "", http.StatusOK)
+	suite.Nil(suite.auditLog)
+
+	// no credential carried
+	suite.serve(http.MethodGet, "/api/v2.0/users/current", "", "", http.StatusUnauthorized)
+	suite.Nil(suite.auditLog)
+}
+
+func (suite *MiddlewareTestSuite) TestConfigurationChanged() {
+	suite.serve(http.MethodPut, "/api/v2.0/configurations", "application/json", `{"ldap_search_password":"secret","auth_mode":"ldap_auth"}`, http.StatusOK)
+	suite.Require().NotNil(suite.auditLog)
+	suite.Equal("update", suite.auditLog.Operation)
+	suite.Equal("configuration", suite.auditLog.ResourceType)
+	suite.Equal("auth_mode,ldap_search_password", suite.auditLog.Resource)
+	suite.NotContains(suite.auditLog.Resource, "secret")
+}
+
+func (suite *MiddlewareTestSuite) TestRobotChanged() {
+	suite.serve(http.MethodPost, "/api/v2.0/robots", "application/json", `{"name":"ci"}`, http.StatusBadRequest)
+	suite.Require().NotNil(suite.auditLog)
+	suite.Equal("create", suite.auditLog.Operation)
+	suite.Equal("robot", suite.auditLog.ResourceType)
+	suite.Equal("ci", suite.auditLog.Resource)
+	suite.Equal(http.StatusBadRequest, suite.auditLog.StatusCode)
+
+	suite.serve(http.MethodDelete, "/api/v2.0/robots/3", "", "", http.StatusOK)
+	suite.Require().NotNil(suite.auditLog)
+	suite.Equal("delete", suite.auditLog.Operation)
+	suite.Equal("3", suite.auditLog.Resource)
+	suite.Equal(int64(0), suite.auditLog.ProjectID)
+}
+
+func (suite *MiddlewareTestSuite) TestMemberChanged() {
+	mock.OnAnything(suite.projectController, "Get").Return(&proModels.Project{ProjectID: 2, Name: "library"}, nil)
+
+	suite.serve(http.MethodPost, "/api/v2.0/projects/library/members", "application/json", `{"role_id":1,"member_user":{"username":"alice"}}`, http.StatusCreated)
+	suite.Require().NotNil(suite.auditLog)
+	suite.Equal("create", suite.auditLog.Operation)
+	suite.Equal("member", suite.auditLog.ResourceType)
+	suite.Equal("alice", suite.auditLog.Resource)
+	suite.Equal(int64(2), suite.auditLog.ProjectID)
+	suite.projectController.AssertCalled(suite.T(), "Get", mock.Anything, "library", mock.Anything)
+
+	suite.serve(http.MethodPut, "/api/v2.0/projects/2/members/5", "application/json", `{"role_id":2}`, http.StatusOK)
+	suite.Require().NotNil(suite.auditLog)
+	suite.Equal("update", suite.auditLog.Operation)
+	suite.Equal("5", suite.auditLog.Resource)
+	suite.projectController.AssertCalled(suite.T(), "Get", mock.Anything, int64(2), mock.Anything)
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, &MiddlewareTestSuite{})
+}
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(time.Minute, 2, 3)
+	assert.True(t, l.allow("a"))
+	assert.True(t, l.allow("a"))
+	assert.False(t, l.allow("a"))
+	assert.True(t, l.allow("b"))
+	// the total is reached
+	assert.False(t, l.allow("c"))
+
+	// a new window
+	l.start = time.Now().Add(-time.Minute)
+	assert.True(t, l.allow("a"))
+	assert.True(t, l.allow("c"))
+}
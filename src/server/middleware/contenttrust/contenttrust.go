@@ -1,6 +1,7 @@
 package contenttrust
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/goharbor/harbor/src/controller/artifact"
@@ -8,6 +9,9 @@ import (
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
 	"github.com/goharbor/harbor/src/pkg/signature"
 	"github.com/goharbor/harbor/src/server/middleware"
 	"github.com/goharbor/harbor/src/server/middleware/util"
@@ -26,6 +30,27 @@ var (
 		}
 		return checker.IsArtifactSigned(art.Digest), nil
 	}
+
+	// isArtifactSignedByCosign checks whether there is any cosign signature attached to the artifact
+	isArtifactSignedByCosign = func(ctx context.Context, art *artifact.Artifact) (bool, error) {
+		total, err := accessory.Mgr.Count(ctx, q.New(q.KeyWords{
+			"SubjectArtifactID": art.ID,
+			"Type":              model.TypeCosignSignature,
+		}))
+		if err != nil {
+			return false, err
+		}
+		return total > 0, nil
+	}
+
+	// isAccessory checks whether the artifact is the accessory of another artifact, e.g. the signature itself
+	isAccessory = func(ctx context.Context, art *artifact.Artifact) (bool, error) {
+		total, err := accessory.Mgr.Count(ctx, q.New(q.KeyWords{"ArtifactID": art.ID}))
+		if err != nil {
+			return false, err
+		}
+		return total > 0, nil
+	}
 )
 
 // Middleware handle docker pull content trust check
@@ -40,11 +65,13 @@ func Middleware() func(http.Handler) http.Handler {
 		if af == none {
 			return errors.New("artifactinfo middleware required before this middleware").WithCode(errors.NotFoundCode)
 		}
+		var art *artifact.Artifact
 		if len(af.Digest) == 0 {
-			art, err := artifact.Ctl.GetByReference(ctx, af.Repository, af.Reference, nil)
+			a, err := artifact.Ctl.GetByReference(ctx, af.Repository, af.Reference, nil)
 			if err != nil {
 				return err
 			}
+			art = a
 			af.Digest = art.Digest
 		}
 		pro, err := project.Ctl.GetByName(ctx, af.ProjectName)
@@ -58,6 +85,27 @@ func Middleware() func(http.Handler) http.Handler {
 			return nil
 		}
 
+		if !pro.ContentTrustEnabled() && !pro.ContentTrustCosignEnabled() {
+			return nil
+		}
+
+		if art == nil {
+			a, err := artifact.Ctl.GetByReference(ctx, af.Repository, af.Digest, nil)
+			if err != nil {
+				return err
+			}
+			art = a
+		}
+		// the accessories(e.g. the signatures) are pulled by the tools to verify the artifact, skip the checking
+		acc, err := isAccessory(ctx, art)
+		if err != nil {
+			return err
+		}
+		if acc {
+			logger.Debugf("artifact %s@%s is an accessory, skip the checking", af.Repository, af.Digest)
+			return nil
+		}
+
 		if pro.ContentTrustEnabled() {
 			match, err := isArtifactSigned(r, af)
 			if err != nil {
@@ -68,6 +116,17 @@ func Middleware() func(http.Handler) http.Handler {
 				return pkgE
 			}
 		}
+
+		if pro.ContentTrustCosignEnabled() {
+			signed, err := isArtifactSignedByCosign(ctx, art)
+			if err != nil {
+				return err
+			}
+			if !signed {
+				pkgE := errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The image is not signed in Cosign.")
+				return pkgE
+			}
+		}
 		return nil
 	})
 }
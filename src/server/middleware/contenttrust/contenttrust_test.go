@@ -15,6 +15,7 @@
 package contenttrust
 
 import (
+	"context"
 	"fmt"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"net/http"
@@ -45,8 +46,10 @@ type MiddlewareTestSuite struct {
 	artifact *artifact.Artifact
 	project  *proModels.Project
 
-	isArtifactSigned func(req *http.Request, art lib.ArtifactInfo) (bool, error)
-	next             http.Handler
+	isArtifactSigned         func(req *http.Request, art lib.ArtifactInfo) (bool, error)
+	isArtifactSignedByCosign func(ctx context.Context, art *artifact.Artifact) (bool, error)
+	isAccessory              func(ctx context.Context, art *artifact.Artifact) (bool, error)
+	next                     http.Handler
 }
 
 func (suite *MiddlewareTestSuite) SetupTest() {
@@ -59,6 +62,8 @@ func (suite *MiddlewareTestSuite) SetupTest() {
 	project.Ctl = suite.projectController
 
 	suite.isArtifactSigned = isArtifactSigned
+	suite.isArtifactSignedByCosign = isArtifactSignedByCosign
+	suite.isAccessory = isAccessory
 	suite.artifact = &artifact.Artifact{}
 	suite.artifact.Type = image.ArtifactTypeImage
 	suite.artifact.ProjectID = 1
@@ -80,11 +85,20 @@ func (suite *MiddlewareTestSuite) SetupTest() {
 	isArtifactSigned = func(req *http.Request, art lib.ArtifactInfo) (bool, error) {
 		return false, nil
 	}
+	isArtifactSignedByCosign = func(ctx context.Context, art *artifact.Artifact) (bool, error) {
+		return false, nil
+	}
+	isAccessory = func(ctx context.Context, art *artifact.Artifact) (bool, error) {
+		return false, nil
+	}
 }
 
 func (suite *MiddlewareTestSuite) TearDownTest() {
 	artifact.Ctl = suite.originalArtifactController
 	project.Ctl = suite.originalProjectController
+	isArtifactSigned = suite.isArtifactSigned
+	isArtifactSignedByCosign = suite.isArtifactSignedByCosign
+	isAccessory = suite.isAccessory
 }
 
 func (suite *MiddlewareTestSuite) makeRequest() *http.Request {
@@ -187,6 +201,51 @@ func (suite *MiddlewareTestSuite) TestUnAuthenticatedUserPulling() {
 	suite.Equal(rr.Code, http.StatusPreconditionFailed)
 }
 
+func (suite *MiddlewareTestSuite) TestCosignNotSigned() {
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	suite.project.Metadata[proModels.ProMetaEnableContentTrust] = "false"
+	suite.project.Metadata[proModels.ProMetaEnableContentTrustCosign] = "true"
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+
+	req := suite.makeRequest()
+	rr := httptest.NewRecorder()
+
+	Middleware()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(rr.Code, http.StatusPreconditionFailed)
+}
+
+func (suite *MiddlewareTestSuite) TestCosignSigned() {
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	suite.project.Metadata[proModels.ProMetaEnableContentTrust] = "false"
+	suite.project.Metadata[proModels.ProMetaEnableContentTrustCosign] = "true"
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	isArtifactSignedByCosign = func(ctx context.Context, art *artifact.Artifact) (bool, error) {
+		return true, nil
+	}
+
+	req := suite.makeRequest()
+	rr := httptest.NewRecorder()
+
+	Middleware()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(rr.Code, http.StatusOK)
+}
+
+// pull the signature itself when the content trust policy is enabled
+func (suite *MiddlewareTestSuite) TestAccessoryPulling() {
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	suite.project.Metadata[proModels.ProMetaEnableContentTrustCosign] = "true"
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	isAccessory = func(ctx context.Context, art *artifact.Artifact) (bool, error) {
+		return true, nil
+	}
+
+	req := suite.makeRequest()
+	rr := httptest.NewRecorder()
+
+	Middleware()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(rr.Code, http.StatusOK)
+}
+
 func TestMiddlewareTestSuite(t *testing.T) {
 	suite.Run(t, &MiddlewareTestSuite{})
 }
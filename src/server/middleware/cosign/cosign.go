@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/accessory/model/cosign"
+	"github.com/goharbor/harbor/src/pkg/distribution"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+// SignatureMiddleware middleware to record the linkage between the artifact and its cosign signature.
+// Cosign pushes the signature of the artifact with the tag sha256-<digest>.sig into the same repository,
+// after the signature manifest is pushed successfully, the signature is recorded as an accessory of the artifact.
+func SignatureMiddleware() func(http.Handler) http.Handler {
+	return middleware.AfterResponse(func(w http.ResponseWriter, r *http.Request, statusCode int) error {
+		if statusCode != http.StatusCreated {
+			return nil
+		}
+
+		ctx := r.Context()
+		logger := log.G(ctx).WithFields(log.Fields{"middleware": "cosign"})
+
+		none := lib.ArtifactInfo{}
+		info := lib.GetArtifactInfo(ctx)
+		if info == none {
+			return errors.New("artifactinfo middleware required before this middleware").WithCode(errors.NotFoundCode)
+		}
+		if len(info.Tag) == 0 || !cosign.IsSignatureTag(info.Tag) {
+			return nil
+		}
+
+		lib.NopCloseRequest(r) // make the r.Body re-readable
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+		contentType := r.Header.Get("Content-Type")
+		manifest, descriptor, err := distribution.UnmarshalManifest(contentType, body)
+		if err != nil {
+			logger.Errorf("unmarshal manifest failed, error: %v", err)
+			return err
+		}
+		signed := false
+		for _, reference := range manifest.References() {
+			if reference.MediaType == cosign.MediaTypeSimpleSigning {
+				signed = true
+				break
+			}
+		}
+		if !signed {
+			return nil
+		}
+
+		subject, err := artifact.Ctl.GetByReference(ctx, info.Repository, cosign.SubjectDigest(info.Tag), nil)
+		if err != nil {
+			// the subject artifact doesn't exist, the signature is kept as a normal artifact
+			if errors.IsNotFoundErr(err) {
+				logger.Warningf("the subject artifact of the signature %s:%s not found", info.Repository, info.Tag)
+				return nil
+			}
+			return err
+		}
+		signature, err := artifact.Ctl.GetByReference(ctx, info.Repository, descriptor.Digest.String(), nil)
+		if err != nil {
+			return err
+		}
+
+		if err := accessory.Mgr.Ensure(ctx, subject.Digest, info.Repository, subject.ID, signature.ID, descriptor.Size,
+			descriptor.Digest.String(), model.TypeCosignSignature); err != nil {
+			logger.Errorf("failed to link the signature %s to the artifact %s@%s, error: %v",
+				descriptor.Digest.String(), info.Repository, subject.Digest, err)
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,182 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	subjectDigest = "sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b"
+	signatureTag  = "sha256-6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b.sig"
+
+	manifestTemplate = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {
+    "mediaType": "application/vnd.oci.image.config.v1+json",
+    "size": 233,
+    "digest": "sha256:d4e6059ece7bea95266fd7766353130d4bf3dc21048b8a9a48c2a6d3f7c8ea9c"
+  },
+  "layers": [
+    {
+      "mediaType": "%s",
+      "size": 250,
+      "digest": "sha256:a0c4f7ba30b8dc6d4e6a5d8cf6a2fb6f5fa4d0b7d9d8f3ac0fcae2e2ab04e03b"
+    }
+  ]
+}`
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+
+	originalArtifactController artifact.Controller
+	artifactController         *artifacttesting.Controller
+
+	originalAccessoryMgr accessory.Manager
+	accessoryMgr         *accessorytesting.Manager
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	suite.originalArtifactController = artifact.Ctl
+	suite.originalAccessoryMgr = accessory.Mgr
+	suite.resetMocks()
+}
+
+func (suite *MiddlewareTestSuite) resetMocks() {
+	suite.artifactController = &artifacttesting.Controller{}
+	artifact.Ctl = suite.artifactController
+	suite.accessoryMgr = &accessorytesting.Manager{}
+	accessory.Mgr = suite.accessoryMgr
+}
+
+func (suite *MiddlewareTestSuite) TearDownTest() {
+	artifact.Ctl = suite.originalArtifactController
+	accessory.Mgr = suite.originalAccessoryMgr
+}
+
+func (suite *MiddlewareTestSuite) makeRequest(tag, layerMediaType string) *http.Request {
+	body := fmt.Sprintf(manifestTemplate, layerMediaType)
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/v2/library/photon/manifests/%s", tag), strings.NewReader(body))
+	req.Header.Set("Content-Type", v1.MediaTypeImageManifest)
+	info := lib.ArtifactInfo{
+		Repository: "library/photon",
+		Reference:  tag,
+		Tag:        tag,
+	}
+	return req.WithContext(lib.WithArtifactInfo(req.Context(), info))
+}
+
+func (suite *MiddlewareTestSuite) TestSignatureMiddleware() {
+	cases := []struct {
+		name           string
+		tag            string
+		layerMediaType string
+		statusCode     int
+		subjectErr     error
+		linked         bool
+	}{
+		{
+			name:           "signature manifest",
+			tag:            signatureTag,
+			layerMediaType: "application/vnd.dev.cosign.simplesigning.v1+json",
+			statusCode:     http.StatusCreated,
+			linked:         true,
+		},
+		{
+			name:           "ordinary manifest",
+			tag:            "2.0",
+			layerMediaType: v1.MediaTypeImageLayerGzip,
+			statusCode:     http.StatusCreated,
+		},
+		{
+			name:           "ordinary manifest with the signature tag",
+			tag:            signatureTag,
+			layerMediaType: v1.MediaTypeImageLayerGzip,
+			statusCode:     http.StatusCreated,
+		},
+		{
+			name:           "signature manifest failed to push",
+			tag:            signatureTag,
+			layerMediaType: "application/vnd.dev.cosign.simplesigning.v1+json",
+			statusCode:     http.StatusBadRequest,
+		},
+		{
+			name:           "signature manifest without subject",
+			tag:            signatureTag,
+			layerMediaType: "application/vnd.dev.cosign.simplesigning.v1+json",
+			statusCode:     http.StatusCreated,
+			subjectErr:     errors.NotFoundError(nil),
+		},
+	}
+
+	for _, c := range cases {
+		suite.resetMocks()
+
+		subject := &artifact.Artifact{}
+		subject.ID = 1
+		subject.Digest = subjectDigest
+		signature := &artifact.Artifact{}
+		signature.ID = 2
+		suite.artifactController.On("GetByReference", mock.Anything, "library/photon", subjectDigest, mock.Anything).Return(subject, c.subjectErr)
+		suite.artifactController.On("GetByReference", mock.Anything, "library/photon", mock.Anything, mock.Anything).Return(signature, nil)
+		mock.OnAnything(suite.accessoryMgr, "Ensure").Return(nil)
+
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.statusCode)
+		})
+		rr := httptest.NewRecorder()
+		SignatureMiddleware()(next).ServeHTTP(rr, suite.makeRequest(c.tag, c.layerMediaType))
+
+		suite.Equal(c.statusCode, rr.Code, c.name)
+		if c.linked {
+			suite.accessoryMgr.AssertCalled(suite.T(), "Ensure", mock.Anything, subjectDigest, "library/photon", int64(1), int64(2),
+				mock.Anything, mock.Anything, model.TypeCosignSignature)
+		} else {
+			suite.accessoryMgr.AssertNotCalled(suite.T(), "Ensure", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+				mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	}
+}
+
+func (suite *MiddlewareTestSuite) TestArtifactInfoRequired() {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
+	rr := httptest.NewRecorder()
+	SignatureMiddleware()(next).ServeHTTP(rr, req)
+	suite.Equal(http.StatusNotFound, rr.Code)
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, &MiddlewareTestSuite{})
+}
@@ -20,6 +20,8 @@ const (
 	CatalogOperationID = "v2_catalog"
 	// ListTagOperationID ...
 	ListTagOperationID = "v2_tags"
+	// ReferrersOperationID ...
+	ReferrersOperationID = "v2_referrers"
 	// ManifestOperationID ...
 	ManifestOperationID = "v2_manifest"
 	// BlobsOperationID ...
@@ -30,8 +30,16 @@ func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler
 		evc := notification.NewEventCtx()
 		next.ServeHTTP(res, r.WithContext(notification.NewContext(r.Context(), evc)))
 		if res.Success() || evc.MustNotify {
+			statusCode := res.StatusCode
+			if statusCode == 0 {
+				statusCode = http.StatusOK
+			}
+			client := &event.ClientMetadata{
+				Client:     evc.Client,
+				StatusCode: statusCode,
+			}
 			for e := evc.Events.Front(); e != nil; e = e.Next() {
-				event.BuildAndPublish(e.Value.(event.Metadata))
+				event.BuildAndPublish(e.Value.(event.Metadata), client)
 			}
 		}
 	}, skippers...)
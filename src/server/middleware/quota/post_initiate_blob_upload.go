@@ -15,12 +15,15 @@
 package quota
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 
 	"github.com/goharbor/harbor/src/controller/blob"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/distribution"
+	"github.com/goharbor/harbor/src/pkg/quota"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 )
 
@@ -54,6 +57,11 @@ func postInitiateBlobUploadResources(r *http.Request, reference, referenceID str
 		return nil, err
 	}
 
+	if err := checkRepositoryQuota(ctx, reference, referenceID, distribution.ParseName(r.URL.Path)); err != nil {
+		logger.Errorf("check repository quota for mounting blob %s failed, error: %v", blb.Digest, err)
+		return nil, err
+	}
+
 	projectID, _ := strconv.ParseInt(referenceID, 10, 64)
 
 	exist, err := blobController.Exist(ctx, blb.Digest, blob.IsAssociatedWithProject(projectID))
@@ -68,3 +76,34 @@ func postInitiateBlobUploadResources(r *http.Request, reference, referenceID str
 
 	return types.ResourceList{types.ResourceStorage: blb.Size}, nil
 }
+
+// checkRepositoryQuota denies mounting the blob to a new repository when the repository quota is exhausted,
+// the repository count isn't reserved here as the repository is created by the following manifest pushing
+func checkRepositoryQuota(ctx context.Context, reference, referenceID, repository string) error {
+	exist, err := repositoryExists(ctx, repository)
+	if err != nil || exist {
+		return err
+	}
+
+	q, err := quotaController.GetByRef(ctx, reference, referenceID)
+	if err != nil {
+		return err
+	}
+
+	hardLimits, err := q.GetHard()
+	if err != nil {
+		return err
+	}
+
+	used, err := q.GetUsed()
+	if err != nil {
+		return err
+	}
+
+	newUsed := types.ResourceList{types.ResourceRepository: used[types.ResourceRepository] + 1}
+	if err := quota.IsSafe(hardLimits, used, newUsed, false); err != nil {
+		return errors.DeniedError(err).WithMessage("Quota exceeded when processing the request of %v", err)
+	}
+
+	return nil
+}
@@ -39,6 +39,8 @@ func (suite *PostInitiateBlobUploadMiddlewareTestSuite) TestMiddleware() {
 
 	url := "/v2/library/photon/blobs/uploads?mount=sha256:57c2ec3bf82f09c94be2e5c5beb124b86fcbb42e76fb82c99066c054422010e4"
 
+	mock.OnAnything(suite.repositoryController, "Count").Return(int64(1), nil)
+
 	{
 		mock.OnAnything(suite.blobController, "Get").Return(&blob.Blob{Size: 100}, nil).Once()
 		mock.OnAnything(suite.blobController, "Exist").Return(true, nil).Once()
@@ -71,6 +73,31 @@ func (suite *PostInitiateBlobUploadMiddlewareTestSuite) TestMiddleware() {
 	}
 }
 
+func (suite *PostInitiateBlobUploadMiddlewareTestSuite) TestRepositoryExceeded() {
+	mock.OnAnything(suite.quotaController, "IsEnabled").Return(true, nil)
+	mock.OnAnything(suite.blobController, "Get").Return(&blob.Blob{Size: 100}, nil)
+	// mount the blob to a new repository
+	mock.OnAnything(suite.repositoryController, "Count").Return(int64(0), nil)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	url := "/v2/library/photon/blobs/uploads?mount=sha256:57c2ec3bf82f09c94be2e5c5beb124b86fcbb42e76fb82c99066c054422010e4"
+
+	q := &quota.Quota{}
+	q.SetHard(types.ResourceList{types.ResourceStorage: types.UNLIMITED, types.ResourceCount: types.UNLIMITED, types.ResourceRepository: 1})
+	q.SetUsed(types.ResourceList{types.ResourceStorage: 0, types.ResourceCount: 1, types.ResourceRepository: 1})
+	mock.OnAnything(suite.quotaController, "GetByRef").Return(q, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, url, nil)
+	rr := httptest.NewRecorder()
+
+	PostInitiateBlobUploadMiddleware()(next).ServeHTTP(rr, req)
+	suite.Equal(http.StatusForbidden, rr.Code)
+	suite.quotaController.AssertNotCalled(suite.T(), "Request", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestPostInitiateBlobUploadMiddlewareTestSuite(t *testing.T) {
 	suite.Run(t, &PostInitiateBlobUploadMiddlewareTestSuite{})
 }
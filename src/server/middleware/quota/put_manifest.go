@@ -21,11 +21,13 @@ import (
 	"github.com/goharbor/harbor/src/controller/blob"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/blob/models"
+	"github.com/goharbor/harbor/src/pkg/distribution"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 )
 
-// PutManifestMiddleware middleware to request count and storage resources for the project
+// PutManifestMiddleware middleware to request storage, artifact count and repository count resources for the project
 func PutManifestMiddleware() func(http.Handler) http.Handler {
 	return RequestMiddleware(RequestConfig{
 		ReferenceObject:   projectReferenceObject,
@@ -46,6 +48,12 @@ func putManifestResources(r *http.Request, reference, referenceID string) (types
 		return nil, errors.Wrap(err, "unmarshal manifest failed").WithCode(errors.MANIFESTINVALID)
 	}
 
+	resources, err := putManifestCountResources(r, descriptor.Digest.String())
+	if err != nil {
+		logger.Errorf("get the count resources of manifest %s failed, error: %v", descriptor.Digest.String(), err)
+		return nil, err
+	}
+
 	exist, err := blobController.Exist(r.Context(), descriptor.Digest.String(), blob.IsAssociatedWithProject(projectID))
 	if err != nil {
 		logger.Errorf("check manifest %s is associated with project failed, error: %v", descriptor.Digest.String(), err)
@@ -53,7 +61,7 @@ func putManifestResources(r *http.Request, reference, referenceID string) (types
 	}
 
 	if exist {
-		return nil, nil
+		return resources, nil
 	}
 
 	size := descriptor.Size
@@ -78,5 +86,35 @@ func putManifestResources(r *http.Request, reference, referenceID string) (types
 		}
 	}
 
-	return types.ResourceList{types.ResourceStorage: size}, nil
+	resources[types.ResourceStorage] = size
+
+	return resources, nil
+}
+
+// putManifestCountResources returns the artifact count and repository count requested by the manifest,
+// the artifact is new when it doesn't exist in the repository and the repository is new when it doesn't exist
+func putManifestCountResources(r *http.Request, digest string) (types.ResourceList, error) {
+	ctx := r.Context()
+	repository := distribution.ParseName(r.URL.Path)
+
+	exist, err := repositoryExists(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exist {
+		return types.ResourceList{types.ResourceCount: 1, types.ResourceRepository: 1}, nil
+	}
+
+	// the base filter is skipped to find the existing children of the indexes and the accessories
+	count, err := artifactController.Count(ctx, q.New(q.KeyWords{"RepositoryName": repository, "Digest": digest, "base": "*"}))
+	if err != nil {
+		return nil, err
+	}
+
+	if count > 0 {
+		return types.ResourceList{}, nil
+	}
+
+	return types.ResourceList{types.ResourceCount: 1}, nil
 }
@@ -25,6 +25,7 @@ import (
 
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/blob/models"
 	"github.com/goharbor/harbor/src/pkg/distribution"
 	"github.com/goharbor/harbor/src/pkg/notification"
@@ -68,6 +69,9 @@ func (suite *PutManifestMiddlewareTestSuite) TearDownTest() {
 
 func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 	mock.OnAnything(suite.quotaController, "IsEnabled").Return(true, nil)
+	// the artifact already exists in the repository
+	mock.OnAnything(suite.repositoryController, "Count").Return(int64(1), nil)
+	mock.OnAnything(suite.artifactController, "Count").Return(int64(1), nil)
 
 	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -183,8 +187,105 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 	}
 }
 
+func (suite *PutManifestMiddlewareTestSuite) TestCountResources() {
+	mock.OnAnything(suite.quotaController, "IsEnabled").Return(true, nil)
+	mock.OnAnything(suite.blobController, "Exist").Return(true, nil)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	{
+		// new artifact in the existing repository
+		mock.OnAnything(suite.repositoryController, "Count").Return(int64(1), nil).Once()
+		mock.OnAnything(suite.artifactController, "Count").Return(int64(0), nil).Once()
+		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
+			resources := args.Get(3).(types.ResourceList)
+			suite.Equal(types.ResourceList{types.ResourceCount: 1}, resources)
+
+			f := args.Get(4).(func() error)
+			f()
+		})
+		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
+		rr := httptest.NewRecorder()
+
+		PutManifestMiddleware()(next).ServeHTTP(rr, req)
+		suite.Equal(http.StatusOK, rr.Code)
+	}
+
+	{
+		// new artifact in the new repository
+		mock.OnAnything(suite.repositoryController, "Count").Return(int64(0), nil).Once()
+		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
+			resources := args.Get(3).(types.ResourceList)
+			suite.Equal(types.ResourceList{types.ResourceCount: 1, types.ResourceRepository: 1}, resources)
+
+			f := args.Get(4).(func() error)
+			f()
+		})
+		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
+		rr := httptest.NewRecorder()
+
+		PutManifestMiddleware()(next).ServeHTTP(rr, req)
+		suite.Equal(http.StatusOK, rr.Code)
+	}
+}
+
+func (suite *PutManifestMiddlewareTestSuite) TestCountIndexWithChildren() {
+	mock.OnAnything(suite.quotaController, "IsEnabled").Return(true, nil)
+	mock.OnAnything(suite.blobController, "Exist").Return(true, nil)
+	mock.OnAnything(suite.repositoryController, "Count").Return(int64(1), nil)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// push pushes the manifest and returns the count requested
+	push := func(reference string, exist bool) int64 {
+		count := int64(0)
+		if exist {
+			count = 1
+		}
+		mock.OnAnything(suite.artifactController, "Count").Return(count, nil).Once()
+		var requested int64
+		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
+			requested = args.Get(3).(types.ResourceList)[types.ResourceCount]
+
+			f := args.Get(4).(func() error)
+			f()
+		})
+		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/"+reference, nil)
+		rr := httptest.NewRecorder()
+
+		PutManifestMiddleware()(next).ServeHTTP(rr, req)
+		suite.Equal(http.StatusOK, rr.Code)
+		return requested
+	}
+
+	// the children are pushed by digest before the index, all of them are counted
+	suite.Equal(int64(1), push("sha256:96fef4a2b8e9bd8bb8b1c6bd9e4d3f6bbd3a3c1a1b8e4b1ef9b9c4d52f2e1b7a", false))
+	suite.Equal(int64(1), push("sha256:2c6c9d4fd0d0e6b6f4b6e9a1d3b1e0a4f5c4d3e2a1b0c9d8e7f6a5b4c3d2e1f0", false))
+	suite.Equal(int64(1), push("2.0", false))
+	// the existing untagged child isn't counted again when it's pushed by another index
+	suite.Equal(int64(0), push("sha256:96fef4a2b8e9bd8bb8b1c6bd9e4d3f6bbd3a3c1a1b8e4b1ef9b9c4d52f2e1b7a", true))
+
+	for _, call := range suite.artifactController.Calls {
+		if call.Method == "Count" {
+			suite.Equal("*", call.Arguments.Get(1).(*q.Query).Keywords["base"])
+		}
+	}
+}
+
 func (suite *PutManifestMiddlewareTestSuite) TestResourcesExceeded() {
 	mock.OnAnything(suite.quotaController, "IsEnabled").Return(true, nil)
+	mock.OnAnything(suite.repositoryController, "Count").Return(int64(1), nil)
+	mock.OnAnything(suite.artifactController, "Count").Return(int64(1), nil)
 	mock.OnAnything(suite.blobController, "Exist").Return(false, nil)
 	mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(nil, nil)
 	mock.OnAnything(suite.projectController, "Get").Return(&proModels.Project{}, nil)
@@ -228,6 +329,8 @@ func (suite *PutManifestMiddlewareTestSuite) TestResourcesExceeded() {
 
 func (suite *PutManifestMiddlewareTestSuite) TestResourcesWarning() {
 	mock.OnAnything(suite.quotaController, "IsEnabled").Return(true, nil)
+	mock.OnAnything(suite.repositoryController, "Count").Return(int64(1), nil)
+	mock.OnAnything(suite.artifactController, "Count").Return(int64(1), nil)
 	mock.OnAnything(suite.blobController, "Exist").Return(false, nil)
 	mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(nil, nil)
 
@@ -25,12 +25,14 @@ import (
 	"github.com/goharbor/harbor/src/controller/blob"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/controller/repository"
 	pquota "github.com/goharbor/harbor/src/pkg/quota"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
 	blobtesting "github.com/goharbor/harbor/src/testing/controller/blob"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 	quotatesting "github.com/goharbor/harbor/src/testing/controller/quota"
+	repositorytesting "github.com/goharbor/harbor/src/testing/controller/repository"
 	"github.com/goharbor/harbor/src/testing/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -49,6 +51,9 @@ type RequestMiddlewareTestSuite struct {
 
 	originallQuotaController quota.Controller
 	quotaController          *quotatesting.Controller
+
+	originalRepositoryController repository.Controller
+	repositoryController         *repositorytesting.Controller
 }
 
 func (suite *RequestMiddlewareTestSuite) SetupTest() {
@@ -69,6 +74,10 @@ func (suite *RequestMiddlewareTestSuite) SetupTest() {
 	suite.originallQuotaController = quotaController
 	suite.quotaController = &quotatesting.Controller{}
 	quotaController = suite.quotaController
+
+	suite.originalRepositoryController = repositoryController
+	suite.repositoryController = &repositorytesting.Controller{}
+	repositoryController = suite.repositoryController
 }
 
 func (suite *RequestMiddlewareTestSuite) TearDownTest() {
@@ -76,6 +85,7 @@ func (suite *RequestMiddlewareTestSuite) TearDownTest() {
 	blobController = suite.originalBlobController
 	projectController = suite.originalProjectController
 	quotaController = suite.originallQuotaController
+	repositoryController = suite.originalRepositoryController
 }
 
 func (suite *RequestMiddlewareTestSuite) makeRequestConfig(reference, referenceID string, resources types.ResourceList) RequestConfig {
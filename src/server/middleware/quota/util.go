@@ -15,6 +15,7 @@
 package quota
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/distribution"
 	"github.com/goharbor/harbor/src/pkg/notifier/event"
 	"github.com/goharbor/harbor/src/server/middleware/util"
@@ -45,6 +47,16 @@ func projectReferenceObject(r *http.Request) (string, string, error) {
 	return quota.ProjectReference, quota.ReferenceID(project.ProjectID), nil
 }
 
+// repositoryExists returns true when the repository exists
+func repositoryExists(ctx context.Context, repository string) (bool, error) {
+	count, err := repositoryController.Count(ctx, q.New(q.KeyWords{"Name": repository}))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 var (
 	unmarshalManifest = func(r *http.Request) (distribution.Manifest, distribution.Descriptor, error) {
 		lib.NopCloseRequest(r)
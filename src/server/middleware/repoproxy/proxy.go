@@ -30,6 +30,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	httpLib "github.com/goharbor/harbor/src/lib/http"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/metric"
 	"github.com/goharbor/harbor/src/lib/orm"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
@@ -43,8 +44,14 @@ const (
 	etag                = "Etag"
 	ensureTagInterval   = 10 * time.Second
 	ensureTagMaxRetry   = 60
+	warning             = "Warning"
+	// the warning to indicate the stale manifest is served, see https://tools.ietf.org/html/rfc7234#section-5.5.1
+	staleWarning = `110 - "Response is Stale"`
 )
 
+// the timeout to resolve the tag with the remote registry when the stale manifest can be served
+var remoteResolveTimeout = 10 * time.Second
+
 // BlobGetMiddleware handle get blob request
 func BlobGetMiddleware() func(http.Handler) http.Handler {
 	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
@@ -113,17 +120,31 @@ func handleManifest(w http.ResponseWriter, r *http.Request, next http.Handler) e
 		return err
 	}
 	if !canProxy(r.Context(), p) {
+		if p.RegistryID > 0 {
+			return serveStaleManifest(w, r, next, proxyCtl, p, art, errors.Errorf("the registry %v is unavailable", p.RegistryID))
+		}
+		next.ServeHTTP(w, r)
+		return nil
+	}
+	// the tag confirmed within the TTL isn't checked with the remote registry again
+	fresh, err := proxyCtl.FreshManifest(ctx, p, art)
+	if err != nil {
+		log.Warningf("failed to check whether the cached tag %v:%v is fresh, error: %v", art.Repository, art.Tag, err)
+	}
+	if fresh != nil {
 		next.ServeHTTP(w, r)
 		return nil
 	}
 	remote, err := proxy.NewRemoteHelper(r.Context(), p.RegistryID)
 	if err != nil {
-		return err
+		return serveStaleManifest(w, r, next, proxyCtl, p, art, err)
 	}
-	useLocal, man, err := proxyCtl.UseLocalManifest(ctx, art, remote)
-
+	useLocal, man, err := useLocalManifest(ctx, proxyCtl, p, art, remote)
 	if err != nil {
-		return err
+		if errors.IsNotFoundErr(err) {
+			return err
+		}
+		return serveStaleManifest(w, r, next, proxyCtl, p, art, err)
 	}
 	if useLocal {
 		if man != nil {
@@ -147,14 +168,68 @@ func handleManifest(w http.ResponseWriter, r *http.Request, next http.Handler) e
 		err = proxyManifestHead(ctx, w, proxyCtl, p, art, remote)
 	} else if r.Method == http.MethodGet {
 		err = proxyManifestGet(ctx, w, proxyCtl, p, art, remote)
+		if err == nil {
+			go func() {
+				// evict the least recently pulled artifacts if the new content makes the project near its quota
+				if err := proxyCtl.EvictArtifacts(orm.Context(), p); err != nil {
+					log.Errorf("failed to evict the artifacts of proxy cache project %v, error: %v", p.Name, err)
+				}
+			}()
+		}
 	}
 	if err != nil {
 		if errors.IsNotFoundErr(err) {
 			return err
 		}
-		log.Warningf("Proxy to remote failed, fallback to local repo, error: %v", err)
+		return serveStaleManifest(w, r, next, proxyCtl, p, art, err)
+	}
+	return nil
+}
+
+// useLocalManifest checks whether to use the local manifest, the remote registry is only waited for
+// remoteResolveTimeout when the stale manifest can be served
+func useLocalManifest(ctx context.Context, ctl proxy.Controller, p *proModels.Project, art lib.ArtifactInfo, remote proxy.RemoteInterface) (bool, *proxy.ManifestList, error) {
+	if !p.ProxyCacheServeStale() || len(art.Tag) == 0 {
+		return ctl.UseLocalManifest(ctx, art, remote)
+	}
+	type result struct {
+		useLocal bool
+		man      *proxy.ManifestList
+		err      error
+	}
+	ch := make(chan *result, 1)
+	go func() {
+		useLocal, man, err := ctl.UseLocalManifest(ctx, art, remote)
+		ch <- &result{useLocal: useLocal, man: man, err: err}
+	}()
+	select {
+	case r := <-ch:
+		return r.useLocal, r.man, r.err
+	case <-time.After(remoteResolveTimeout):
+		return false, nil, errors.Errorf("timeout to resolve the tag %v:%v with the remote registry", art.Repository, art.Tag)
+	}
+}
+
+// serveStaleManifest serves the local manifest with the stale warning when the remote registry is unavailable,
+// falls back to the local repo without the warning if serving the stale manifest isn't enabled
+func serveStaleManifest(w http.ResponseWriter, r *http.Request, next http.Handler, ctl proxy.Controller, p *proModels.Project, art lib.ArtifactInfo, cause error) error {
+	a, err := ctl.StaleManifest(r.Context(), p, art)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			log.Warningf("the remote registry is unavailable and the cached manifest isn't served, error: %v, cause: %v", err, cause)
+			return err
+		}
+		log.Errorf("failed to get the stale manifest, error: %v", err)
+	}
+	if a == nil {
+		log.Warningf("Proxy to remote failed, fallback to local repo, error: %v", cause)
 		next.ServeHTTP(w, r)
+		return nil
 	}
+	log.Warningf("the remote registry is unavailable, serve the cached manifest %v:%v, digest: %v, error: %v", art.Repository, art.Tag, a.Digest, cause)
+	metric.ProxyCacheStaleServeCnt.WithLabelValues(p.Name).Inc()
+	w.Header().Set(warning, staleWarning)
+	next.ServeHTTP(w, r)
 	return nil
 }
 
@@ -0,0 +1,143 @@
+//  Copyright Project Harbor Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package repoproxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/proxy"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+)
+
+// fakeProxyController blocks on resolving the tag with the remote registry until the context is done
+type fakeProxyController struct {
+	proxy.Controller
+	stale    *artifact.Artifact
+	staleErr error
+}
+
+func (f *fakeProxyController) UseLocalManifest(ctx context.Context, art lib.ArtifactInfo, remote proxy.RemoteInterface) (bool, *proxy.ManifestList, error) {
+	<-ctx.Done()
+	return false, nil, ctx.Err()
+}
+
+func (f *fakeProxyController) StaleManifest(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo) (*artifact.Artifact, error) {
+	return f.stale, f.staleErr
+}
+
+func staleProject(serveStale bool) *proModels.Project {
+	return &proModels.Project{
+		Name:       "proxy",
+		RegistryID: 1,
+		Metadata: map[string]string{
+			proModels.ProMetaProxyCacheServeStale: fmt.Sprintf("%t", serveStale),
+		},
+	}
+}
+
+func TestUseLocalManifestTimeout(t *testing.T) {
+	timeout := remoteResolveTimeout
+	remoteResolveTimeout = 10 * time.Millisecond
+	defer func() { remoteResolveTimeout = timeout }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	art := lib.ArtifactInfo{Repository: "proxy/hello-world", Tag: "latest"}
+
+	_, _, err := useLocalManifest(ctx, &fakeProxyController{}, staleProject(true), art, nil)
+	if err == nil || errors.IsNotFoundErr(err) {
+		t.Errorf("expected the timeout error, got %v", err)
+	}
+}
+
+func TestServeStaleManifest(t *testing.T) {
+	timeout := remoteResolveTimeout
+	remoteResolveTimeout = 10 * time.Millisecond
+	defer func() { remoteResolveTimeout = timeout }()
+
+	local := &artifact.Artifact{}
+	local.Digest = "sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b"
+
+	cases := []struct {
+		name       string
+		ctl        *fakeProxyController
+		serveStale bool
+		wantErr    bool
+		wantLocal  bool
+		wantHeader string
+	}{
+		{
+			name:       `serve the local artifact with the warning`,
+			ctl:        &fakeProxyController{stale: local},
+			serveStale: true,
+			wantLocal:  true,
+			wantHeader: staleWarning,
+		},
+		{
+			name:       `serving stale disabled`,
+			ctl:        &fakeProxyController{},
+			serveStale: false,
+			wantLocal:  true,
+		},
+		{
+			name:       `out of the stale window`,
+			ctl:        &fakeProxyController{staleErr: errors.NotFoundError(nil)},
+			serveStale: true,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// the deadline ends the blocked call when serving the stale manifest is disabled as it is not guarded by the timeout
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			p := staleProject(tt.serveStale)
+			art := lib.ArtifactInfo{Repository: "proxy/hello-world", Tag: "latest"}
+
+			// the remote registry doesn't respond in time
+			_, _, cause := useLocalManifest(ctx, tt.ctl, p, art, nil)
+			if cause == nil {
+				t.Fatalf("expected the timeout error")
+			}
+
+			served := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				served = true
+				w.WriteHeader(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/v2/proxy/hello-world/manifests/latest", nil)
+			rr := httptest.NewRecorder()
+			err := serveStaleManifest(rr, req, next, tt.ctl, p, art, cause)
+			if (err != nil) != tt.wantErr {
+				t.Errorf(`serveStaleManifest() error = %v; wantErr %v`, err, tt.wantErr)
+			}
+			if served != tt.wantLocal {
+				t.Errorf(`local artifact served = %v; want %v`, served, tt.wantLocal)
+			}
+			if got := rr.Header().Get(warning); got != tt.wantHeader {
+				t.Errorf(`header %s = %q; want %q`, warning, got, tt.wantHeader)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scangating
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/distribution"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/server/middleware"
+	"github.com/opencontainers/go-digest"
+)
+
+// the max count of the vulnerabilities listed in the error message
+const maxListedVulnerabilities = 20
+
+var (
+	// the intervals to check whether the scan is completed
+	pollInitialInterval = time.Second
+	pollMaxInterval     = 5 * time.Second
+)
+
+// Middleware makes the PUT /v2/<name>/manifests/<reference> API wait for the scan of the pushed artifact to complete
+// when the scan gating of the project is enabled, and rejects or quarantines the artifact if it has vulnerabilities
+// of the project severity or higher.
+// The middleware must be placed after the notification middleware and ahead of the transaction middleware: the scan
+// job can only pull the artifact after the transaction is committed, and the events of the push aren't sent when the
+// artifact is rejected. The push is only gated when it's prepared by the PrepareMiddleware placed after the security
+// middleware, so the requests not authenticated are never gated.
+func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
+	skippers = append(skippers, func(r *http.Request) bool {
+		return r.Method != http.MethodPut || !distribution.ManifestURLRegexp.MatchString(r.URL.Path)
+	})
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		ctx := r.Context()
+		g := &gating{}
+
+		res := lib.NewResponseBuffer(w)
+		defer func() {
+			if _, err := res.Flush(); err != nil {
+				log.Errorf("failed to flush: %v", err)
+			}
+		}()
+
+		next.ServeHTTP(res, r.WithContext(context.WithValue(ctx, gatingKey{}, g)))
+		if !res.Success() || g.before == nil {
+			return
+		}
+
+		warning, err := gate(ctx, g.project, g.before)
+		if err != nil {
+			if e := res.Reset(); e != nil {
+				log.Errorf("failed to reset the response: %v", e)
+				return
+			}
+			lib_http.SendError(res, err)
+			return
+		}
+		// the quarantined artifact is kept, so the push succeeds with the warning
+		if len(warning) > 0 {
+			res.Header().Set("Warning", fmt.Sprintf("299 - %q", warning))
+		}
+	}, skippers...)
+}
+
+// PrepareMiddleware records the project and the state of the repository before the push for the Middleware when the
+// scan gating of the project is enabled. It must be placed after the security middleware so that the manifest isn't
+// read and the repository isn't inspected for the requests not authenticated.
+func PrepareMiddleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
+	skippers = append(skippers, func(r *http.Request) bool {
+		return r.Method != http.MethodPut || !distribution.ManifestURLRegexp.MatchString(r.URL.Path)
+	})
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		ctx := r.Context()
+		logger := log.G(ctx).WithFields(log.Fields{"middleware": "scangating"})
+
+		g, ok := ctx.Value(gatingKey{}).(*gating)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// the push not authenticated is denied by the registry
+		if sc, ok := security.FromContext(ctx); !ok || !sc.IsAuthenticated() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		proj, err := projectController.Get(ctx, distribution.ParseProjectName(r.URL.Path), project.WithEffectCVEAllowlist())
+		if err != nil {
+			// let the registry handle the push to the nonexistent project
+			if errors.IsNotFoundErr(err) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			logger.Errorf("failed to get the project of %s: %v", r.URL.Path, err)
+			lib_http.SendError(w, err)
+			return
+		}
+		if proj.ScanGating() == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			lib_http.SendError(w, err)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+		before, err := snapshot(ctx, r.URL.Path, body)
+		if err != nil {
+			logger.Errorf("failed to get the artifact of %s before the push: %v", r.URL.Path, err)
+			lib_http.SendError(w, err)
+			return
+		}
+		g.project = proj
+		g.before = before
+
+		next.ServeHTTP(w, r)
+	}, skippers...)
+}
+
+type gatingKey struct{}
+
+// gating is shared by the Middleware and PrepareMiddleware through the context of the request
+type gating struct {
+	project *proModels.Project
+	before  *state
+}
+
+// state records the repository before the push, so that only the changes made by the push are reverted when
+// the artifact is rejected
+type state struct {
+	repository string
+	digest     string
+	tag        string
+	// whether the pushed artifact exists in the repository already
+	existed bool
+	// the artifact that the pushed tag is attached to, nil if the tag doesn't exist
+	tagged *artifact.Artifact
+}
+
+func snapshot(ctx context.Context, path string, body []byte) (*state, error) {
+	s := &state{
+		repository: distribution.ParseName(path),
+		digest:     digest.FromBytes(body).String(),
+	}
+	reference := distribution.ParseReference(path)
+	if _, err := digest.Parse(reference); err != nil {
+		s.tag = reference
+	} else {
+		s.digest = reference
+	}
+
+	_, err := artifactController.GetByReference(ctx, s.repository, s.digest, nil)
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return nil, err
+	}
+	s.existed = err == nil
+
+	if len(s.tag) > 0 {
+		tagged, err := artifactController.GetByReference(ctx, s.repository, s.tag, nil)
+		if err != nil && !errors.IsNotFoundErr(err) {
+			return nil, err
+		}
+		s.tagged = tagged
+	}
+	return s, nil
+}
+
+// gate waits for the scan of the pushed artifact, the artifact is reverted and an error is returned when it's rejected,
+// and the warning is returned when it's quarantined
+func gate(ctx context.Context, proj *proModels.Project, before *state) (string, error) {
+	logger := log.G(ctx).WithFields(log.Fields{"middleware": "scangating"})
+
+	art, err := artifactController.GetByReference(ctx, before.repository, before.digest, nil)
+	if err != nil {
+		logger.Errorf("failed to get the artifact %s@%s: %v", before.repository, before.digest, err)
+		return "", err
+	}
+
+	// the children of the index are gated when they are pushed
+	if art.IsImageIndex() {
+		logger.Debugf("artifact %s@%s is an image index, skip the scan gating", art.RepositoryName, art.Digest)
+		return "", nil
+	}
+	scannable, err := scanChecker().IsScannable(ctx, art)
+	if err != nil {
+		logger.Errorf("failed to check the scannable status of the artifact %s@%s: %v", art.RepositoryName, art.Digest, err)
+		return "", err
+	}
+	if !scannable {
+		logger.Debugf("artifact %s@%s isn't scannable, skip the scan gating", art.RepositoryName, art.Digest)
+		return "", nil
+	}
+
+	// the push event isn't sent before the gating completes, so the auto scan cannot be relied on
+	if err = triggerScan(ctx, art, before.tag); err != nil {
+		logger.Errorf("failed to scan the artifact %s@%s: %v", art.RepositoryName, art.Digest, err)
+		return "", err
+	}
+
+	severity := vuln.ParseSeverityVersion3(proj.Severity())
+	vulnerable, err := waitForScan(ctx, art, proj)
+	if err != nil {
+		logger.Errorf("failed to wait for the scan of the artifact %s@%s: %v", art.RepositoryName, art.Digest, err)
+		return "", err
+	}
+
+	var msg string
+	switch {
+	case vulnerable == nil:
+		msg = fmt.Sprintf(`the scan of current image isn't completed in %s`, proj.ScanGatingTimeout())
+	case !vulnerable.IsScanSuccess():
+		msg = fmt.Sprintf(`the scan of current image is "%s"`, vulnerable.ScanStatus)
+	default:
+		offending := filterVulnerabilities(vulnerable.Vulnerabilities, severity)
+		if len(offending) == 0 {
+			return "", nil
+		}
+		msg = fmt.Sprintf(`current image has %d vulnerabilities of severity "%s" or higher: %s`,
+			len(offending), severity, formatVulnerabilities(offending))
+	}
+
+	if proj.ScanGating() != proModels.ScanGatingReject {
+		msg += ", it is quarantined due to the scan gating policy of the project and cannot be pulled"
+		logger.Infof("artifact %s@%s is quarantined by the scan gating: %s", art.RepositoryName, art.Digest, msg)
+		return msg, nil
+	}
+
+	if err = revert(ctx, art, before); err != nil {
+		logger.Errorf("failed to revert the push of the artifact %s@%s: %v", art.RepositoryName, art.Digest, err)
+		return "", err
+	}
+	msg += ", it is rejected due to the scan gating policy of the project"
+	logger.Infof("artifact %s@%s is rejected by the scan gating: %s", art.RepositoryName, art.Digest, msg)
+	return "", errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage(msg)
+}
+
+func triggerScan(ctx context.Context, art *artifact.Artifact, tag string) error {
+	err := orm.WithTransaction(func(ctx context.Context) error {
+		var options []scan.Option
+		if len(tag) > 0 {
+			options = append(options, scan.WithTag(tag))
+		}
+		return scanController.Scan(ctx, art, options...)
+	})(orm.SetTransactionOpNameToContext(ctx, "tx-scan-gating"))
+	// the artifact is being scanned already
+	if errors.IsConflictErr(err) {
+		return nil
+	}
+	return err
+}
+
+// waitForScan waits for the scan to complete, nil is returned if it isn't completed before the timeout
+func waitForScan(ctx context.Context, art *artifact.Artifact, proj *proModels.Project) (*scan.Vulnerable, error) {
+	var (
+		vulnerable *scan.Vulnerable
+		aborted    error
+	)
+	allowlist := proj.CVEAllowlist.CVESet()
+	err := retry.Retry(func() error {
+		v, err := scanController.GetVulnerable(ctx, art, allowlist)
+		if err != nil {
+			// the report placeholder isn't created yet
+			if errors.IsNotFoundErr(err) {
+				return err
+			}
+			aborted = err
+			return retry.Abort(err)
+		}
+		if !job.Status(v.ScanStatus).Final() {
+			return errors.Errorf("the scan status is %s", v.ScanStatus)
+		}
+		vulnerable = v
+		return nil
+	}, retry.InitialInterval(pollInitialInterval), retry.MaxInterval(pollMaxInterval), retry.Timeout(proj.ScanGatingTimeout()))
+	if err != nil && aborted != nil {
+		return nil, aborted
+	}
+	return vulnerable, nil
+}
+
+// revert reverts the changes made by the push: the tag is moved back to the artifact it was attached to, and
+// the artifact is deleted if it was created by the push, otherwise only the pushed tag is removed from it
+func revert(ctx context.Context, art *artifact.Artifact, before *state) error {
+	return orm.WithTransaction(func(ctx context.Context) error {
+		tagMoved := len(before.tag) > 0 && (before.tagged == nil || before.tagged.ID != art.ID)
+		if tagMoved && before.tagged != nil {
+			if err := tagController.Ensure(ctx, before.tagged.RepositoryID, before.tagged.ID, before.tag); err != nil {
+				return err
+			}
+		}
+		if !before.existed {
+			return artifactController.Delete(ctx, art.ID)
+		}
+		if !tagMoved || before.tagged != nil {
+			return nil
+		}
+		tags, err := tagController.List(ctx, q.New(q.KeyWords{"artifact_id": art.ID, "name": before.tag}), nil)
+		if err != nil {
+			return err
+		}
+		for _, t := range tags {
+			if err = tagController.Delete(ctx, t.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})(orm.SetTransactionOpNameToContext(ctx, "tx-scan-gating-revert"))
+}
+
+// filterVulnerabilities returns the vulnerabilities of the severity or higher, sorted by the severity in descending order
+func filterVulnerabilities(vulnerabilities []*vuln.VulnerabilityItem, severity vuln.Severity) []*vuln.VulnerabilityItem {
+	var result []*vuln.VulnerabilityItem
+	for _, v := range vulnerabilities {
+		if v.Severity.Code() >= severity.Code() {
+			result = append(result, v)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Severity.Code() != result[j].Severity.Code() {
+			return result[i].Severity.Code() > result[j].Severity.Code()
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// formatVulnerabilities formats the vulnerabilities as "CVE-2021-0001 (Critical, openssl 1.1.1), ..."
+func formatVulnerabilities(vulnerabilities []*vuln.VulnerabilityItem) string {
+	var items []string
+	for i, v := range vulnerabilities {
+		if i == maxListedVulnerabilities {
+			items = append(items, fmt.Sprintf("and %d more", len(vulnerabilities)-maxListedVulnerabilities))
+			break
+		}
+		items = append(items, fmt.Sprintf("%s (%s, %s %s)", v.ID, v.Severity, v.Package, v.Version))
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,365 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scangating
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/image"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/controller/tag"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	securitytesting "github.com/goharbor/harbor/src/testing/common/security"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
+	tagtesting "github.com/goharbor/harbor/src/testing/controller/tag"
+	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/suite"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+
+	originalArtifactController artifact.Controller
+	artifactController         *artifacttesting.Controller
+
+	originalProjectController project.Controller
+	projectController         *projecttesting.Controller
+
+	originalScanController scan.Controller
+	scanController         *scantesting.Controller
+
+	originalTagController tag.Controller
+	tagController         *tagtesting.FakeController
+
+	checker     *scantesting.Checker
+	scanChecker func() scan.Checker
+
+	manifest string
+	artifact *artifact.Artifact
+	project  *proModels.Project
+	security *securitytesting.Context
+
+	next http.Handler
+}
+
+func (suite *MiddlewareTestSuite) SetupSuite() {
+	pollInitialInterval = time.Millisecond
+	pollMaxInterval = time.Millisecond
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	suite.originalArtifactController = artifactController
+	suite.artifactController = &artifacttesting.Controller{}
+	artifactController = suite.artifactController
+
+	suite.originalProjectController = projectController
+	suite.projectController = &projecttesting.Controller{}
+	projectController = suite.projectController
+
+	suite.originalScanController = scanController
+	suite.scanController = &scantesting.Controller{}
+	scanController = suite.scanController
+
+	suite.originalTagController = tagController
+	suite.tagController = &tagtesting.FakeController{}
+	tagController = suite.tagController
+
+	suite.checker = &scantesting.Checker{}
+	suite.scanChecker = scanChecker
+	scanChecker = func() scan.Checker {
+		return suite.checker
+	}
+
+	suite.manifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json"}`
+	suite.artifact = &artifact.Artifact{}
+	suite.artifact.ID = 1
+	suite.artifact.Type = image.ArtifactTypeImage
+	suite.artifact.ProjectID = 1
+	suite.artifact.RepositoryID = 1
+	suite.artifact.RepositoryName = "library/photon"
+	suite.artifact.Digest = digest.FromString(suite.manifest).String()
+
+	suite.project = &proModels.Project{
+		ProjectID: suite.artifact.ProjectID,
+		Name:      "library",
+		Metadata: map[string]string{
+			proModels.ProMetaScanGating:        proModels.ScanGatingReject,
+			proModels.ProMetaScanGatingTimeout: "1",
+			proModels.ProMetaAutoScan:          "true",
+			proModels.ProMetaSeverity:          vuln.High.String(),
+		},
+	}
+
+	suite.security = &securitytesting.Context{}
+	suite.security.On("IsAuthenticated").Return(true)
+
+	suite.next = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Docker-Content-Digest", suite.artifact.Digest)
+		w.WriteHeader(http.StatusCreated)
+	})
+}
+
+func (suite *MiddlewareTestSuite) TearDownTest() {
+	artifactController = suite.originalArtifactController
+	projectController = suite.originalProjectController
+	scanController = suite.originalScanController
+	tagController = suite.originalTagController
+
+	scanChecker = suite.scanChecker
+}
+
+func (suite *MiddlewareTestSuite) serve(method string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/v2/library/photon/manifests/2.0", strings.NewReader(suite.manifest))
+	req = req.WithContext(orm.NewContext(req.Context(), &ormtesting.FakeOrmer{}))
+	rr := httptest.NewRecorder()
+	// the security context is set by the security middleware between the two middlewares
+	authenticated := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		PrepareMiddleware()(suite.next).ServeHTTP(w, r.WithContext(security.NewContext(r.Context(), suite.security)))
+	})
+	Middleware()(authenticated).ServeHTTP(rr, req)
+	return rr
+}
+
+// mockArtifact mocks the artifact before and after the push, existed specifies whether the pushed artifact exists
+// before the push and tagged is the artifact that the tag was attached to before the push
+func (suite *MiddlewareTestSuite) mockArtifact(existed bool, tagged *artifact.Artifact) {
+	if existed {
+		suite.artifactController.On("GetByReference", mock.Anything, "library/photon", suite.artifact.Digest, mock.Anything).Return(suite.artifact, nil).Once()
+	} else {
+		suite.artifactController.On("GetByReference", mock.Anything, "library/photon", suite.artifact.Digest, mock.Anything).Return(nil, errors.NotFoundError(nil)).Once()
+	}
+	if tagged != nil {
+		suite.artifactController.On("GetByReference", mock.Anything, "library/photon", "2.0", mock.Anything).Return(tagged, nil).Once()
+	} else {
+		suite.artifactController.On("GetByReference", mock.Anything, "library/photon", "2.0", mock.Anything).Return(nil, errors.NotFoundError(nil)).Once()
+	}
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+}
+
+// mockVulnerable mocks the scan result of the pushed artifact with critical vulnerabilities
+func (suite *MiddlewareTestSuite) mockVulnerable() {
+	critical := vuln.Critical
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(suite.scanController, "Scan").Return(nil)
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{
+		ScanStatus:           "Success",
+		Severity:             &critical,
+		VulnerabilitiesCount: 1,
+		Vulnerabilities:      []*vuln.VulnerabilityItem{{ID: "CVE-2021-0003", Severity: vuln.Critical, Package: "openssl", Version: "1.1.1"}},
+	}, nil)
+}
+
+func (suite *MiddlewareTestSuite) TestNotPutManifest() {
+	rr := suite.serve(http.MethodGet)
+	suite.Equal(http.StatusCreated, rr.Code)
+	suite.projectController.AssertNotCalled(suite.T(), "Get", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestGatingDisabled() {
+	delete(suite.project.Metadata, proModels.ProMetaScanGating)
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusCreated, rr.Code)
+	suite.artifactController.AssertNotCalled(suite.T(), "GetByReference", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestNotAuthenticated() {
+	suite.security = &securitytesting.Context{}
+	suite.security.On("IsAuthenticated").Return(false)
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusCreated, rr.Code)
+	suite.projectController.AssertNotCalled(suite.T(), "Get", mock.Anything, mock.Anything, mock.Anything)
+	suite.artifactController.AssertNotCalled(suite.T(), "GetByReference", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestProjectNotFound() {
+	mock.OnAnything(suite.projectController, "Get").Return(nil, errors.NotFoundError(nil))
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusCreated, rr.Code)
+	suite.artifactController.AssertNotCalled(suite.T(), "GetByReference", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestNotScannable() {
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	suite.mockArtifact(false, nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(false, nil)
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusCreated, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestNoVulnerabilities() {
+	low := vuln.Low
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	suite.mockArtifact(false, nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	// the artifact is being scanned by the auto scan already
+	mock.OnAnything(suite.scanController, "Scan").Return(errors.ConflictError(nil))
+	// not started, running and then completed
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(nil, errors.NotFoundError(nil)).Once()
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{ScanStatus: "Running"}, nil).Once()
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{
+		ScanStatus:           "Success",
+		Severity:             &low,
+		VulnerabilitiesCount: 1,
+		Vulnerabilities:      []*vuln.VulnerabilityItem{{ID: "CVE-2021-0001", Severity: vuln.Low}},
+	}, nil).Once()
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusCreated, rr.Code)
+}
+
+func (suite *MiddlewareTestSuite) TestScanTriggered() {
+	suite.project.Metadata[proModels.ProMetaAutoScan] = "false"
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	suite.mockArtifact(false, nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(suite.scanController, "Scan").Return(nil)
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{ScanStatus: "Success"}, nil)
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusCreated, rr.Code)
+	suite.scanController.AssertCalled(suite.T(), "Scan", mock.Anything, suite.artifact, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestRejected() {
+	critical := vuln.Critical
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	suite.mockArtifact(false, nil)
+	mock.OnAnything(suite.artifactController, "Delete").Return(nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(suite.scanController, "Scan").Return(nil)
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{
+		ScanStatus:           "Success",
+		Severity:             &critical,
+		VulnerabilitiesCount: 3,
+		Vulnerabilities: []*vuln.VulnerabilityItem{
+			{ID: "CVE-2021-0001", Severity: vuln.Low, Package: "bash", Version: "5.0"},
+			{ID: "CVE-2021-0002", Severity: vuln.High, Package: "curl", Version: "7.0"},
+			{ID: "CVE-2021-0003", Severity: vuln.Critical, Package: "openssl", Version: "1.1.1"},
+		},
+	}, nil)
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+	suite.Contains(rr.Body.String(), "CVE-2021-0003 (Critical, openssl 1.1.1), CVE-2021-0002 (High, curl 7.0)")
+	suite.NotContains(rr.Body.String(), "CVE-2021-0001")
+	suite.Contains(rr.Body.String(), "rejected")
+	suite.artifactController.AssertCalled(suite.T(), "Delete", mock.Anything, suite.artifact.ID)
+	suite.tagController.AssertNotCalled(suite.T(), "Ensure")
+}
+
+func (suite *MiddlewareTestSuite) TestRejectedNewTagOfExistingArtifact() {
+	suite.mockVulnerable()
+	suite.mockArtifact(true, nil)
+	suite.tagController.On("List").Return([]*tag.Tag{{}}, nil)
+	suite.tagController.On("Delete").Return(nil)
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+	suite.Contains(rr.Body.String(), "rejected")
+	suite.tagController.AssertCalled(suite.T(), "Delete")
+	suite.artifactController.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestRejectedTagMovedFromOtherArtifact() {
+	previous := &artifact.Artifact{}
+	previous.ID = 2
+	previous.RepositoryID = 1
+	suite.mockVulnerable()
+	suite.mockArtifact(true, previous)
+	suite.tagController.On("Ensure").Return(nil)
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+	suite.tagController.AssertCalled(suite.T(), "Ensure")
+	suite.tagController.AssertNotCalled(suite.T(), "Delete")
+	suite.artifactController.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestRejectedRepush() {
+	suite.mockVulnerable()
+	suite.mockArtifact(true, suite.artifact)
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+	suite.tagController.AssertNotCalled(suite.T(), "Ensure")
+	suite.tagController.AssertNotCalled(suite.T(), "Delete")
+	suite.artifactController.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestQuarantined() {
+	suite.project.Metadata[proModels.ProMetaScanGating] = proModels.ScanGatingQuarantine
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	suite.mockArtifact(false, nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(suite.scanController, "Scan").Return(nil)
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{ScanStatus: "Error"}, nil)
+
+	// the quarantined artifact is kept, so the push succeeds with the warning
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusCreated, rr.Code)
+	suite.Equal(suite.artifact.Digest, rr.Header().Get("Docker-Content-Digest"))
+	suite.Contains(rr.Header().Get("Warning"), "quarantined")
+	suite.artifactController.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *MiddlewareTestSuite) TestTimeout() {
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	suite.mockArtifact(false, nil)
+	mock.OnAnything(suite.artifactController, "Delete").Return(nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(suite.scanController, "Scan").Return(nil)
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{ScanStatus: "Pending"}, nil)
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+	suite.Contains(rr.Body.String(), "isn't completed in 1s")
+}
+
+func (suite *MiddlewareTestSuite) TestGetVulnerableFailed() {
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	suite.mockArtifact(false, nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(suite.scanController, "Scan").Return(nil)
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(nil, fmt.Errorf("error"))
+
+	rr := suite.serve(http.MethodPut)
+	suite.Equal(http.StatusInternalServerError, rr.Code)
+	suite.artifactController.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, &MiddlewareTestSuite{})
+}
@@ -38,11 +38,44 @@ var (
 	}
 )
 
+// the auth methods recorded in the client info of the request
+const (
+	authMethodBasic     = "basic"
+	authMethodRobot     = "robot"
+	authMethodOIDC      = "oidc"
+	authMethodToken     = "token"
+	authMethodSession   = "session"
+	authMethodAuthProxy = "auth_proxy"
+	authMethodSecret    = "secret"
+)
+
 // security context generator
 type generator interface {
 	Generate(req *http.Request) security.Context
 }
 
+// authMethod returns the auth method of the request authenticated by the generator
+func authMethod(g generator) string {
+	switch g.(type) {
+	case *basicAuth:
+		return authMethodBasic
+	case *robot:
+		return authMethodRobot
+	case *oidcCli, *idToken:
+		return authMethodOIDC
+	case *v2Token:
+		return authMethodToken
+	case *session:
+		return authMethodSession
+	case *authProxy:
+		return authMethodAuthProxy
+	case *secret, *proxyCacheSecret:
+		return authMethodSecret
+	default:
+		return ""
+	}
+}
+
 // Middleware returns a security context middleware that populates the security context into the request context
 func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
 	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
@@ -53,13 +86,18 @@ func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler
 		} else {
 			log.Warningf("failed to get auth mode: %v", err)
 		}
+		client := lib.ClientInfo{
+			IP:        lib.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		}
 		for _, generator := range generators {
 			if ctx := generator.Generate(r); ctx != nil {
 				r = r.WithContext(security.NewContext(r.Context(), ctx))
+				client.AuthMethod = authMethod(generator)
 				break
 			}
 		}
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(lib.WithClientInfo(r.Context(), client)))
 	}, skippers...)
 }
 
@@ -21,6 +21,7 @@ import (
 
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/utils/test"
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,13 +34,20 @@ func TestMain(m *testing.M) {
 func TestSecurity(t *testing.T) {
 	var ctx security.Context
 	var exist bool
+	var client lib.ClientInfo
 	generators = []generator{&unauthorized{}}
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx, exist = security.FromContext(r.Context())
+		client = lib.GetClientInfo(r.Context())
 	})
 	req, err := http.NewRequest("POST", "http://127.0.0.1:8080/api/users", nil)
 	require.Nil(t, err)
+	req.RemoteAddr = "10.0.0.1:12345"
+	req.Header.Set("User-Agent", "harbor-cli")
 	Middleware()(handler).ServeHTTP(nil, req)
 	require.True(t, exist)
 	assert.NotNil(t, ctx)
+	assert.Equal(t, "10.0.0.1", client.IP)
+	assert.Equal(t, "harbor-cli", client.UserAgent)
+	assert.Empty(t, client.AuthMethod)
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	"github.com/goharbor/harbor/src/server/middleware"
 	"github.com/goharbor/harbor/src/server/middleware/util"
@@ -63,7 +64,8 @@ func Middleware() func(http.Handler) http.Handler {
 			return err
 		}
 
-		if !proj.VulPrevented() {
+		// the vulnerable artifacts are quarantined by the scan gating
+		if !proj.VulPrevented() && proj.ScanGating() != proModels.ScanGatingQuarantine {
 			// vulnerability prevention disabled, skip the checking
 			logger.Debugf("project %s vulnerability prevention disabled, skip the checking", proj.Name)
 			return nil
@@ -334,6 +334,27 @@ func (suite *MiddlewareTestSuite) TestPrevented() {
 	}
 }
 
+func (suite *MiddlewareTestSuite) TestQuarantined() {
+	critical := vuln.Critical
+
+	suite.project.Metadata[proModels.ProMetaPreventVul] = "false"
+	suite.project.Metadata[proModels.ProMetaScanGating] = proModels.ScanGatingQuarantine
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	mock.OnAnything(suite.projectController, "Get").Return(suite.project, nil)
+	mock.OnAnything(suite.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(suite.scanController, "GetVulnerable").Return(&scan.Vulnerable{
+		ScanStatus:           "Success",
+		Severity:             &critical,
+		VulnerabilitiesCount: 1,
+	}, nil)
+
+	req := suite.makeRequest()
+	rr := httptest.NewRecorder()
+
+	Middleware()(suite.next).ServeHTTP(rr, req)
+	suite.Equal(rr.Code, http.StatusPreconditionFailed)
+}
+
 func (suite *MiddlewareTestSuite) TestArtifactIsImageIndex() {
 	critical := vuln.Critical
 
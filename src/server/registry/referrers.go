@@ -0,0 +1,145 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/server/router"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// the query parameter and the response header for filtering the referrers by the artifact type
+	artifactTypeQuery     = "artifactType"
+	filtersAppliedHeader  = "OCI-Filters-Applied"
+	filtersAppliedByTypes = "artifactType"
+)
+
+func newReferrersHandler() http.Handler {
+	return &referrersHandler{
+		artMgr:       artifact.Mgr,
+		accessoryMgr: accessory.Mgr,
+	}
+}
+
+type referrersHandler struct {
+	artMgr       artifact.Manager
+	accessoryMgr accessory.Manager
+}
+
+// descriptor is the OCI descriptor with the "artifactType" field which isn't supported by the vendored image spec
+type descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// referrers is the OCI image index that lists the referrers of the subject artifact
+type referrers struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	MediaType     string        `json:"mediaType"`
+	Manifests     []*descriptor `json:"manifests"`
+}
+
+// ServeHTTP lists the referrers of the artifact specified by the digest, the response looks like:
+//
+// Content-Type: application/vnd.oci.image.index.v1+json
+// OCI-Filters-Applied: artifactType
+//
+//	{
+//	   "schemaVersion": 2,
+//	   "mediaType": "application/vnd.oci.image.index.v1+json",
+//	   "manifests": [
+//	     {
+//	       "mediaType": "application/vnd.oci.image.manifest.v1+json",
+//	       "artifactType": "<artifact type>",
+//	       "digest": "<digest>",
+//	       "size": <size>,
+//	       "annotations": {...}
+//	     },
+//	     ...
+//	   ]
+//	}
+func (r *referrersHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	repository := router.Param(ctx, ":splat")
+	reference := router.Param(ctx, ":reference")
+	artifactType := req.URL.Query().Get(artifactTypeQuery)
+
+	if _, err := digest.Parse(reference); err != nil {
+		lib_http.SendError(w, errors.Wrapf(err, "unsupported digest %s", reference).WithCode(errors.BadRequestCode))
+		return
+	}
+
+	// the referrers of the subject artifact are recorded as its accessories,
+	// the subject artifact itself may not exist as the referrers can be pushed before it
+	accs, err := r.accessoryMgr.List(ctx, q.New(q.KeyWords{
+		"SubjectArtifactRepo":   repository,
+		"SubjectArtifactDigest": reference,
+	}))
+	if err != nil {
+		lib_http.SendError(w, err)
+		return
+	}
+
+	manifests := make([]*descriptor, 0, len(accs))
+	for _, acc := range accs {
+		data := acc.GetData()
+		art, err := r.artMgr.Get(ctx, data.ArtifactID)
+		if err != nil {
+			// the referrer may be deleted at the same time
+			if errors.IsNotFoundErr(err) {
+				log.G(ctx).Debugf("the referrer %s of %s@%s not found, skip", data.Digest, repository, reference)
+				continue
+			}
+			lib_http.SendError(w, err)
+			return
+		}
+		if len(artifactType) > 0 && art.ArtifactType != artifactType {
+			continue
+		}
+		manifests = append(manifests, &descriptor{
+			MediaType:    art.ManifestMediaType,
+			ArtifactType: art.ArtifactType,
+			Digest:       art.Digest,
+			Size:         data.Size,
+			Annotations:  art.Annotations,
+		})
+	}
+
+	w.Header().Set("Content-Type", v1.MediaTypeImageIndex)
+	if len(artifactType) > 0 {
+		w.Header().Set(filtersAppliedHeader, filtersAppliedByTypes)
+	}
+	if err := json.NewEncoder(w).Encode(&referrers{
+		SchemaVersion: 2,
+		MediaType:     v1.MediaTypeImageIndex,
+		Manifests:     manifests,
+	}); err != nil {
+		lib_http.SendError(w, err)
+		return
+	}
+}
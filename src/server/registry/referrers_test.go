@@ -0,0 +1,143 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	beegocontext "github.com/astaxie/beego/context"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/accessory/model/subject"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/server/router"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	arttesting "github.com/goharbor/harbor/src/testing/pkg/artifact"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+)
+
+type referrersTestSuite struct {
+	suite.Suite
+	handler      *referrersHandler
+	artMgr       *arttesting.Manager
+	accessoryMgr *accessorytesting.Manager
+}
+
+func (r *referrersTestSuite) SetupTest() {
+	r.artMgr = &arttesting.Manager{}
+	r.accessoryMgr = &accessorytesting.Manager{}
+	r.handler = &referrersHandler{
+		artMgr:       r.artMgr,
+		accessoryMgr: r.accessoryMgr,
+	}
+}
+
+func (r *referrersTestSuite) newRequest(path, reference string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	input := &beegocontext.BeegoInput{}
+	input.SetParam(":splat", "library/hello-world")
+	input.SetParam(":reference", reference)
+	return req.WithContext(context.WithValue(req.Context(), router.ContextKeyInput{}, input))
+}
+
+func (r *referrersTestSuite) TestInvalidDigest() {
+	req := r.newRequest("/v2/library/hello-world/referrers/latest", "latest")
+	w := httptest.NewRecorder()
+	r.handler.ServeHTTP(w, req)
+	r.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (r *referrersTestSuite) TestListReferrers() {
+	digest := "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+	mock.OnAnything(r.accessoryMgr, "List").Return([]accessorymodel.Accessory{
+		subject.New(accessorymodel.AccessoryData{
+			ArtifactID:            2,
+			SubjectArtifactRepo:   "library/hello-world",
+			SubjectArtifactDigest: digest,
+			Size:                  512,
+			Digest:                "sha256:sbom",
+		}),
+		subject.New(accessorymodel.AccessoryData{
+			ArtifactID:            3,
+			SubjectArtifactRepo:   "library/hello-world",
+			SubjectArtifactDigest: digest,
+			Size:                  1024,
+			Digest:                "sha256:attestation",
+		}),
+	}, nil)
+	r.artMgr.On("Get", mock.Anything, int64(2)).Return(&artifact.Artifact{
+		ID:                2,
+		ManifestMediaType: v1.MediaTypeImageManifest,
+		ArtifactType:      "application/spdx+json",
+		Digest:            "sha256:sbom",
+		Annotations:       map[string]string{"org.opencontainers.image.created": "2021-01-01T00:00:00Z"},
+	}, nil)
+	r.artMgr.On("Get", mock.Anything, int64(3)).Return(&artifact.Artifact{
+		ID:                3,
+		ManifestMediaType: v1.MediaTypeImageManifest,
+		ArtifactType:      "application/vnd.in-toto+json",
+		Digest:            "sha256:attestation",
+	}, nil)
+
+	// list all the referrers
+	req := r.newRequest("/v2/library/hello-world/referrers/"+digest, digest)
+	w := httptest.NewRecorder()
+	r.handler.ServeHTTP(w, req)
+	r.Equal(http.StatusOK, w.Code)
+	r.Equal(v1.MediaTypeImageIndex, w.Header().Get("Content-Type"))
+	r.Empty(w.Header().Get("OCI-Filters-Applied"))
+	index := &referrers{}
+	r.Require().Nil(json.NewDecoder(w.Body).Decode(index))
+	r.Equal(2, index.SchemaVersion)
+	r.Equal(v1.MediaTypeImageIndex, index.MediaType)
+	r.Require().Len(index.Manifests, 2)
+	r.Equal("sha256:sbom", index.Manifests[0].Digest)
+	r.Equal(int64(512), index.Manifests[0].Size)
+	r.Equal("application/spdx+json", index.Manifests[0].ArtifactType)
+	r.Len(index.Manifests[0].Annotations, 1)
+
+	// filter the referrers by the artifact type
+	req = r.newRequest("/v2/library/hello-world/referrers/"+digest+"?artifactType=application/vnd.in-toto%2Bjson", digest)
+	w = httptest.NewRecorder()
+	r.handler.ServeHTTP(w, req)
+	r.Equal(http.StatusOK, w.Code)
+	r.Equal("artifactType", w.Header().Get("OCI-Filters-Applied"))
+	index = &referrers{}
+	r.Require().Nil(json.NewDecoder(w.Body).Decode(index))
+	r.Require().Len(index.Manifests, 1)
+	r.Equal("sha256:attestation", index.Manifests[0].Digest)
+}
+
+func (r *referrersTestSuite) TestListReferrersOfNonExistingSubject() {
+	digest := "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+	mock.OnAnything(r.accessoryMgr, "List").Return([]accessorymodel.Accessory{}, nil)
+	req := r.newRequest("/v2/library/hello-world/referrers/"+digest, digest)
+	w := httptest.NewRecorder()
+	r.handler.ServeHTTP(w, req)
+	r.Equal(http.StatusOK, w.Code)
+	index := &referrers{}
+	r.Require().Nil(json.NewDecoder(w.Body).Decode(index))
+	r.NotNil(index.Manifests)
+	r.Len(index.Manifests, 0)
+}
+
+func TestReferrersTestSuite(t *testing.T) {
+	suite.Run(t, &referrersTestSuite{})
+}
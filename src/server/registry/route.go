@@ -19,6 +19,7 @@ import (
 
 	"github.com/goharbor/harbor/src/server/middleware/blob"
 	"github.com/goharbor/harbor/src/server/middleware/contenttrust"
+	"github.com/goharbor/harbor/src/server/middleware/cosign"
 	"github.com/goharbor/harbor/src/server/middleware/immutable"
 	"github.com/goharbor/harbor/src/server/middleware/metric"
 	"github.com/goharbor/harbor/src/server/middleware/quota"
@@ -45,6 +46,12 @@ func RegisterRoutes() {
 		Path("/*/tags/list").
 		Middleware(metric.InjectOpIDMiddleware(metric.ListTagOperationID)).
 		Handler(newTagHandler())
+	// list referrers
+	root.NewRoute().
+		Method(http.MethodGet).
+		Path("/*/referrers/:reference").
+		Middleware(metric.InjectOpIDMiddleware(metric.ReferrersOperationID)).
+		Handler(newReferrersHandler())
 	// manifest
 	root.NewRoute().
 		Method(http.MethodGet).
@@ -76,6 +83,7 @@ func RegisterRoutes() {
 		Middleware(immutable.Middleware()).
 		Middleware(quota.PutManifestMiddleware()).
 		Middleware(blob.PutManifestMiddleware()).
+		Middleware(cosign.SignatureMiddleware()).
 		HandlerFunc(putManifest)
 	// blob head
 	root.NewRoute().
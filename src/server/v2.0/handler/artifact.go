@@ -85,7 +85,7 @@ func (a *artifactAPI) ListArtifacts(ctx context.Context, params operation.ListAr
 
 	// set option
 	option := option(params.WithTag, params.WithImmutableStatus,
-		params.WithLabel, params.WithSignature)
+		params.WithLabel, params.WithSignature, params.WithAccessory)
 
 	// get the total count of artifacts
 	total, err := a.artCtl.Count(ctx, query)
@@ -119,7 +119,7 @@ func (a *artifactAPI) GetArtifact(ctx context.Context, params operation.GetArtif
 	}
 	// set option
 	option := option(params.WithTag, params.WithImmutableStatus,
-		params.WithLabel, params.WithSignature)
+		params.WithLabel, params.WithSignature, params.WithAccessory)
 
 	// get the artifact
 	artifact, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, option)
@@ -424,10 +424,11 @@ func (a *artifactAPI) RemoveLabel(ctx context.Context, params operation.RemoveLa
 	return operation.NewRemoveLabelOK()
 }
 
-func option(withTag, withImmutableStatus, withLabel, withSignature *bool) *artifact.Option {
+func option(withTag, withImmutableStatus, withLabel, withSignature, withAccessory *bool) *artifact.Option {
 	option := &artifact.Option{
-		WithTag:   true, // return the tag by default
-		WithLabel: lib.BoolValue(withLabel),
+		WithTag:       true, // return the tag by default
+		WithLabel:     lib.BoolValue(withLabel),
+		WithAccessory: lib.BoolValue(withAccessory),
 	}
 
 	if withTag != nil {
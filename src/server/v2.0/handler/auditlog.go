@@ -88,6 +88,10 @@ func (a *auditlogAPI) ListAuditLogs(ctx context.Context, params auditlog.ListAud
 			Username:     log.Username,
 			Operation:    log.Operation,
 			OpTime:       strfmt.DateTime(log.OpTime),
+			ClientIP:     log.ClientIP,
+			UserAgent:    log.UserAgent,
+			AuthMethod:   log.AuthMethod,
+			StatusCode:   int64(log.StatusCode),
 		})
 	}
 	return operation.NewListAuditLogsOK().
@@ -11,6 +11,7 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/controller/gc"
+	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/task"
@@ -21,12 +22,14 @@ import (
 
 type gcAPI struct {
 	BaseAPI
-	gcCtr gc.Controller
+	gcCtr      gc.Controller
+	projectCtl project.Controller
 }
 
 func newGCAPI() *gcAPI {
 	return &gcAPI{
-		gcCtr: gc.NewController(),
+		gcCtr:      gc.NewController(),
+		projectCtl: project.Ctl,
 	}
 }
 
@@ -38,7 +41,11 @@ func (g *gcAPI) CreateGCSchedule(ctx context.Context, params operation.CreateGCS
 	if err := g.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceGarbageCollection); err != nil {
 		return g.SendError(ctx, err)
 	}
-	id, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	scope, err := g.getSystemScope(ctx, params.Schedule.Schedule.Type, params.Schedule.Parameters)
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+	id, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters, scope)
 	if err != nil {
 		return g.SendError(ctx, err)
 	}
@@ -55,14 +62,15 @@ func (g *gcAPI) UpdateGCSchedule(ctx context.Context, params operation.UpdateGCS
 	if err := g.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceGarbageCollection); err != nil {
 		return g.SendError(ctx, err)
 	}
-	_, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	_, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters, nil)
 	if err != nil {
 		return g.SendError(ctx, err)
 	}
 	return operation.NewUpdateGCScheduleOK()
 }
 
-func (g *gcAPI) kick(ctx context.Context, scheType string, cron string, parameters map[string]interface{}) (int64, error) {
+// kick starts or schedules the gc, the gc is restricted to the scope if it's not nil
+func (g *gcAPI) kick(ctx context.Context, scheType string, cron string, parameters map[string]interface{}, scope *gc.Scope) (int64, error) {
 	if parameters == nil {
 		parameters = make(map[string]interface{})
 	}
@@ -76,6 +84,7 @@ func (g *gcAPI) kick(ctx context.Context, scheType string, cron string, paramete
 	case ScheduleManual:
 		policy := gc.Policy{
 			ExtraAttrs: parameters,
+			Scope:      scope,
 		}
 		if dryRun, ok := parameters["dry_run"].(bool); ok {
 			policy.DryRun = dryRun
@@ -85,10 +94,11 @@ func (g *gcAPI) kick(ctx context.Context, scheType string, cron string, paramete
 		}
 		id, err = g.gcCtr.Start(ctx, policy, task.ExecutionTriggerManual)
 	case ScheduleNone:
-		err = g.gcCtr.DeleteSchedule(ctx)
+		err = g.gcCtr.DeleteSchedule(ctx, scheduleVendorID(scope))
 	case ScheduleHourly, ScheduleDaily, ScheduleWeekly, ScheduleCustom:
 		policy := gc.Policy{
 			ExtraAttrs: parameters,
+			Scope:      scope,
 		}
 		if dryRun, ok := parameters["dry_run"].(bool); ok {
 			policy.DryRun = dryRun
@@ -114,7 +124,7 @@ func (g *gcAPI) createSchedule(ctx context.Context, cronType, cron string, polic
 }
 
 func (g *gcAPI) updateSchedule(ctx context.Context, cronType, cron string, policy gc.Policy) error {
-	if err := g.gcCtr.DeleteSchedule(ctx); err != nil {
+	if err := g.gcCtr.DeleteSchedule(ctx, scheduleVendorID(policy.Scope)); err != nil {
 		return err
 	}
 	return g.createSchedule(ctx, cronType, cron, policy)
@@ -124,7 +134,7 @@ func (g *gcAPI) GetGCSchedule(ctx context.Context, params operation.GetGCSchedul
 	if err := g.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceGarbageCollection); err != nil {
 		return g.SendError(ctx, err)
 	}
-	schedule, err := g.gcCtr.GetSchedule(ctx)
+	schedule, err := g.gcCtr.GetSchedule(ctx, gc.SystemVendorID)
 	if errors.IsNotFoundErr(err) {
 		return operation.NewGetGCScheduleOK()
 	}
@@ -143,20 +153,32 @@ func (g *gcAPI) GetGCHistory(ctx context.Context, params operation.GetGCHistoryP
 	if err != nil {
 		return g.SendError(ctx, err)
 	}
-	total, err := g.gcCtr.ExecutionCount(ctx, query)
+	total, results, err := g.listHistory(ctx, query)
 	if err != nil {
 		return g.SendError(ctx, err)
 	}
+
+	return operation.NewGetGCHistoryOK().
+		WithXTotalCount(total).
+		WithLink(g.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (g *gcAPI) listHistory(ctx context.Context, query *q.Query) (int64, []*models.GCHistory, error) {
+	total, err := g.gcCtr.ExecutionCount(ctx, query)
+	if err != nil {
+		return 0, nil, err
+	}
 	execs, err := g.gcCtr.ListExecutions(ctx, query)
 	if err != nil {
-		return g.SendError(ctx, err)
+		return 0, nil, err
 	}
 
 	var hs []*model.GCHistory
 	for _, exec := range execs {
 		extraAttrsString, err := json.Marshal(exec.ExtraAttrs)
 		if err != nil {
-			return g.SendError(ctx, err)
+			return 0, nil, err
 		}
 		hs = append(hs, &model.GCHistory{
 			ID:         exec.ID,
@@ -176,11 +198,7 @@ func (g *gcAPI) GetGCHistory(ctx context.Context, params operation.GetGCHistoryP
 	for _, h := range hs {
 		results = append(results, h.ToSwagger())
 	}
-
-	return operation.NewGetGCHistoryOK().
-		WithXTotalCount(total).
-		WithLink(g.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
-		WithPayload(results)
+	return total, results, nil
 }
 
 func (g *gcAPI) GetGC(ctx context.Context, params operation.GetGCParams) middleware.Responder {
@@ -208,6 +226,7 @@ func (g *gcAPI) GetGC(ctx context.Context, params operation.GetGCParams) middlew
 		},
 		CreationTime: exec.StartTime,
 		UpdateTime:   exec.EndTime,
+		Progress:     exec.Progress,
 	}
 
 	return operation.NewGetGCOK().WithPayload(res.ToSwagger())
@@ -232,3 +251,152 @@ func (g *gcAPI) GetGCLog(ctx context.Context, params operation.GetGCLogParams) m
 	}
 	return operation.NewGetGCLogOK().WithPayload(string(log))
 }
+
+func (g *gcAPI) CreateProjectGCSchedule(ctx context.Context, params operation.CreateProjectGCScheduleParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := g.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourceGarbageCollection); err != nil {
+		return g.SendError(ctx, err)
+	}
+	scope, err := g.getScope(ctx, projectNameOrID, params.Schedule.Parameters)
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+	if _, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters, scope); err != nil {
+		return g.SendError(ctx, err)
+	}
+	return operation.NewCreateProjectGCScheduleCreated()
+}
+
+func (g *gcAPI) UpdateProjectGCSchedule(ctx context.Context, params operation.UpdateProjectGCScheduleParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := g.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionUpdate, rbac.ResourceGarbageCollection); err != nil {
+		return g.SendError(ctx, err)
+	}
+	scope, err := g.getScope(ctx, projectNameOrID, params.Schedule.Parameters)
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+	if _, err := g.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters, scope); err != nil {
+		return g.SendError(ctx, err)
+	}
+	return operation.NewUpdateProjectGCScheduleOK()
+}
+
+func (g *gcAPI) GetProjectGCSchedule(ctx context.Context, params operation.GetProjectGCScheduleParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := g.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionRead, rbac.ResourceGarbageCollection); err != nil {
+		return g.SendError(ctx, err)
+	}
+	p, err := g.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+	schedule, err := g.gcCtr.GetSchedule(ctx, p.ProjectID)
+	if errors.IsNotFoundErr(err) {
+		return operation.NewGetProjectGCScheduleOK()
+	}
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+
+	return operation.NewGetProjectGCScheduleOK().WithPayload(model.NewGCSchedule(schedule).ToSwagger())
+}
+
+func (g *gcAPI) GetProjectGCHistory(ctx context.Context, params operation.GetProjectGCHistoryParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := g.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionList, rbac.ResourceGarbageCollection); err != nil {
+		return g.SendError(ctx, err)
+	}
+	p, err := g.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+	query, err := g.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+	query.Keywords["VendorID"] = p.ProjectID
+	total, results, err := g.listHistory(ctx, query)
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+
+	return operation.NewGetProjectGCHistoryOK().
+		WithXTotalCount(total).
+		WithLink(g.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+// getScope returns the gc scope of the project, the "repository" in the parameters restricts it to the repository of the project
+func (g *gcAPI) getScope(ctx context.Context, projectNameOrID interface{}, parameters map[string]interface{}) (*gc.Scope, error) {
+	p, err := g.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return newScope(p.ProjectID, p.Name, parameters)
+}
+
+// getSystemScope returns the gc scope specified by the "project_name", "project_id" or "repository" in the parameters
+// of the system gc, nil is returned for the system wide gc. Unlike the project gc, the project may have been deleted,
+// the artifacts left in the trash by it are matched by the project name then.
+func (g *gcAPI) getSystemScope(ctx context.Context, scheType string, parameters map[string]interface{}) (*gc.Scope, error) {
+	projectName, _ := parameters["project_name"].(string)
+	repository, _ := parameters["repository"].(string)
+	var projectID int64
+	// the numbers in the parameters are decoded as float64
+	if id, ok := parameters["project_id"].(float64); ok {
+		projectID = int64(id)
+	}
+	if len(projectName) == 0 && projectID <= 0 && len(repository) == 0 {
+		return nil, nil
+	}
+	if scheType != ScheduleManual {
+		return nil, errors.BadRequestError(nil).WithMessage("the garbage collection restricted to a project or repository can only be triggered manually")
+	}
+	if len(projectName) == 0 && len(repository) > 0 {
+		projectName = strings.SplitN(repository, "/", 2)[0]
+	}
+
+	var projectNameOrID interface{} = projectName
+	if projectID > 0 {
+		projectNameOrID = projectID
+	}
+	p, err := g.projectCtl.Get(ctx, projectNameOrID)
+	if err == nil {
+		if len(projectName) > 0 && projectName != p.Name {
+			return nil, errors.BadRequestError(nil).WithMessage("the project %d isn't %s", projectID, projectName)
+		}
+		return newScope(p.ProjectID, p.Name, parameters)
+	}
+	if !errors.IsNotFoundErr(err) {
+		return nil, err
+	}
+	// the project is deleted
+	if len(projectName) == 0 {
+		return nil, errors.BadRequestError(nil).WithMessage("the project %d doesn't exist, specify the project_name or repository of the deleted project", projectID)
+	}
+	return newScope(projectID, projectName, parameters)
+}
+
+// newScope returns the gc scope of the project, the "repository" in the parameters restricts it to the repository of the project
+func newScope(projectID int64, projectName string, parameters map[string]interface{}) (*gc.Scope, error) {
+	scope := &gc.Scope{
+		ProjectID:   projectID,
+		ProjectName: projectName,
+	}
+	if repository, ok := parameters["repository"].(string); ok && len(repository) > 0 {
+		if !strings.HasPrefix(repository, projectName+"/") {
+			return nil, errors.BadRequestError(nil).WithMessage("the repository %s doesn't belong to the project %s", repository, projectName)
+		}
+		scope.Repository = repository
+	}
+	return scope, nil
+}
+
+// scheduleVendorID returns the vendor ID of the gc schedule restricted to the scope
+func scheduleVendorID(scope *gc.Scope) int64 {
+	if scope != nil {
+		return scope.ProjectID
+	}
+	return gc.SystemVendorID
+}
@@ -0,0 +1,130 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/controller/gc"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	gctesting "github.com/goharbor/harbor/src/testing/controller/gc"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+	"github.com/stretchr/testify/suite"
+)
+
+type GCTestSuite struct {
+	htesting.Suite
+
+	gcCtl      *gctesting.Controller
+	projectCtl *projecttesting.Controller
+}
+
+func (suite *GCTestSuite) SetupSuite() {
+	suite.gcCtl = &gctesting.Controller{}
+	suite.projectCtl = &projecttesting.Controller{}
+
+	suite.Config = &restapi.Config{
+		GCAPI: &gcAPI{
+			gcCtr:      suite.gcCtl,
+			projectCtl: suite.projectCtl,
+		},
+	}
+
+	suite.Suite.SetupSuite()
+}
+
+func (suite *GCTestSuite) SetupTest() {
+	suite.gcCtl.ExpectedCalls = nil
+	suite.gcCtl.Calls = nil
+	suite.projectCtl.ExpectedCalls = nil
+	suite.projectCtl.Calls = nil
+}
+
+// startGC starts the system gc with the parameters and returns the status code and the scope of the started gc
+func (suite *GCTestSuite) startGC(scheduleType string, parameters map[string]interface{}) (int, *gc.Scope) {
+	suite.Security.On("IsAuthenticated").Return(true).Once()
+	suite.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Once()
+	mock.OnAnything(suite.gcCtl, "Start").Return(int64(1), nil).Once()
+
+	res, err := suite.PostJSON("/system/gc/schedule", map[string]interface{}{
+		"schedule":   map[string]interface{}{"type": scheduleType},
+		"parameters": parameters,
+	})
+	suite.Require().NoError(err)
+
+	for _, call := range suite.gcCtl.Calls {
+		if call.Method == "Start" {
+			return res.StatusCode, call.Arguments.Get(1).(gc.Policy).Scope
+		}
+	}
+	return res.StatusCode, nil
+}
+
+func (suite *GCTestSuite) TestStartSystemWide() {
+	code, scope := suite.startGC(ScheduleManual, map[string]interface{}{"delete_untagged": true})
+	suite.Equal(201, code)
+	suite.Nil(scope)
+	suite.projectCtl.AssertNotCalled(suite.T(), "Get", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *GCTestSuite) TestStartScopedToProject() {
+	mock.OnAnything(suite.projectCtl, "Get").Return(&models.Project{ProjectID: 1, Name: "library"}, nil)
+
+	code, scope := suite.startGC(ScheduleManual, map[string]interface{}{"project_id": 1})
+	suite.Equal(201, code)
+	suite.Equal(&gc.Scope{ProjectID: 1, ProjectName: "library"}, scope)
+}
+
+func (suite *GCTestSuite) TestStartScopedToDeletedProject() {
+	mock.OnAnything(suite.projectCtl, "Get").Return(nil, errors.NotFoundError(nil))
+
+	code, scope := suite.startGC(ScheduleManual, map[string]interface{}{"project_name": "library", "project_id": 1})
+	suite.Equal(201, code)
+	suite.Equal(&gc.Scope{ProjectID: 1, ProjectName: "library"}, scope)
+}
+
+func (suite *GCTestSuite) TestStartScopedToRepositoryOfDeletedProject() {
+	mock.OnAnything(suite.projectCtl, "Get").Return(nil, errors.NotFoundError(nil))
+
+	code, scope := suite.startGC(ScheduleManual, map[string]interface{}{"repository": "library/hello-world"})
+	suite.Equal(201, code)
+	suite.Equal(&gc.Scope{ProjectName: "library", Repository: "library/hello-world"}, scope)
+}
+
+func (suite *GCTestSuite) TestStartScopedBadRequest() {
+	// the name of the deleted project is required to match its artifacts in the trash
+	mock.OnAnything(suite.projectCtl, "Get").Return(nil, errors.NotFoundError(nil))
+	code, scope := suite.startGC(ScheduleManual, map[string]interface{}{"project_id": 1})
+	suite.Equal(400, code)
+	suite.Nil(scope)
+
+	// the repository out of the project
+	code, scope = suite.startGC(ScheduleManual, map[string]interface{}{"project_name": "library", "repository": "other/hello-world"})
+	suite.Equal(400, code)
+	suite.Nil(scope)
+
+	// the scoped gc cannot be scheduled by the system
+	code, scope = suite.startGC(ScheduleDaily, map[string]interface{}{"project_name": "library"})
+	suite.Equal(400, code)
+	suite.Nil(scope)
+}
+
+func TestGCTestSuite(t *testing.T) {
+	suite.Run(t, &GCTestSuite{})
+}
@@ -44,6 +44,7 @@ func New() http.Handler {
 		IconAPI:               newIconAPI(),
 		RobotAPI:              newRobotAPI(),
 		Robotv1API:            newRobotV1API(),
+		RoleAPI:               newRoleAPI(),
 		ReplicationAPI:        newReplicationAPI(),
 		RegistryAPI:           newRegistryAPI(),
 		SysteminfoAPI:         newSystemInfoAPI(),
@@ -51,6 +52,7 @@ func New() http.Handler {
 		LdapAPI:               newLdapAPI(),
 		LabelAPI:              newLabelAPI(),
 		GCAPI:                 newGCAPI(),
+		PurgeAPI:              newPurgeAPI(),
 		QuotaAPI:              newQuotaAPI(),
 		RetentionAPI:          newRetentionAPI(),
 		WebhookAPI:            newNotificationPolicyAPI(),
@@ -37,6 +37,7 @@ func (a *Artifact) ToSwagger() *models.Artifact {
 		Type:              a.Type,
 		MediaType:         a.MediaType,
 		ManifestMediaType: a.ManifestMediaType,
+		ArtifactType:      a.ArtifactType,
 		ProjectID:         a.ProjectID,
 		RepositoryID:      a.RepositoryID,
 		Digest:            a.Digest,
@@ -63,6 +64,9 @@ func (a *Artifact) ToSwagger() *models.Artifact {
 	for _, label := range a.Labels {
 		art.Labels = append(art.Labels, NewLabel(label).ToSwagger())
 	}
+	for _, acc := range a.Accessories {
+		art.Accessories = append(art.Accessories, NewAccessory(acc.GetData()).ToSwagger())
+	}
 	if len(a.ScanOverview) > 0 {
 		art.ScanOverview = models.ScanOverview{}
 		for key, value := range a.ScanOverview {
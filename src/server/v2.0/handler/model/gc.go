@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/goharbor/harbor/src/controller/gc"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
@@ -31,6 +32,7 @@ type GCHistory struct {
 	Deleted      bool           `json:"deleted"`
 	CreationTime time.Time      `json:"creation_time"`
 	UpdateTime   time.Time      `json:"update_time"`
+	Progress     *gc.Progress   `json:"progress"`
 }
 
 // ToSwagger converts the history to the swagger model
@@ -50,6 +52,27 @@ func (h *GCHistory) ToSwagger() *models.GCHistory {
 		},
 		CreationTime: strfmt.DateTime(h.CreationTime),
 		UpdateTime:   strfmt.DateTime(h.UpdateTime),
+		Progress:     newGCProgress(h.Progress),
+	}
+}
+
+func newGCProgress(p *gc.Progress) *models.GCProgress {
+	if p == nil {
+		return nil
+	}
+	repositories := make(map[string]models.GCRepositoryProgress, len(p.Repositories))
+	for name, repo := range p.Repositories {
+		repositories[name] = models.GCRepositoryProgress{
+			ManifestsTotal: int64(repo.ManifestsTotal),
+			ManifestsDone:  int64(repo.ManifestsDone),
+		}
+	}
+	return &models.GCProgress{
+		Resumed:      p.Resumed,
+		BlobsTotal:   int64(p.BlobsTotal),
+		BlobsDone:    int64(p.BlobsDone),
+		BytesFreed:   p.BytesFreed,
+		Repositories: repositories,
 	}
 }
 
@@ -31,3 +31,33 @@ func NewNotificationJob(j *model.Job) *NotificationJob {
 		Job: j,
 	}
 }
+
+// NotificationDelivery ...
+type NotificationDelivery struct {
+	*model.Delivery
+}
+
+// ToSwagger ...
+func (n *NotificationDelivery) ToSwagger() *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:             n.ID,
+		JobID:          n.JobID,
+		PolicyID:       n.PolicyID,
+		Success:        n.Success,
+		RequestURL:     n.RequestURL,
+		RequestHeaders: n.RequestHeaders,
+		RequestBody:    n.RequestBody,
+		StatusCode:     int64(n.StatusCode),
+		ResponseBody:   n.ResponseBody,
+		Latency:        n.Latency,
+		Error:          n.Error,
+		CreationTime:   strfmt.DateTime(n.CreationTime),
+	}
+}
+
+// NewNotificationDelivery ...
+func NewNotificationDelivery(d *model.Delivery) *NotificationDelivery {
+	return &NotificationDelivery{
+		Delivery: d,
+	}
+}
@@ -24,6 +24,20 @@ func (n *NotifiactionPolicy) ToSwagger() *models.WebhookPolicy {
 		Name:         n.Name,
 		ProjectID:    n.ProjectID,
 		Targets:      n.ToTargets(),
+		Filters:      n.ToFilters(),
+	}
+}
+
+// ToFilters ...
+func (n *NotifiactionPolicy) ToFilters() *models.WebhookPolicyFilters {
+	if n.Filters == nil {
+		return nil
+	}
+	return &models.WebhookPolicyFilters{
+		Repository: n.Filters.Repository,
+		Tag:        n.Filters.Tag,
+		Labels:     n.Filters.Labels,
+		Severity:   n.Filters.Severity,
 	}
 }
 
@@ -36,6 +50,7 @@ func (n *NotifiactionPolicy) ToTargets() []*models.WebhookTargetObject {
 			Address:        t.Address,
 			AuthHeader:     t.AuthHeader,
 			SkipCertVerify: t.SkipCertVerify,
+			PayloadFormat:  t.PayloadFormat,
 		})
 	}
 	return results
@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// ExecHistory execution history
+type ExecHistory struct {
+	Schedule     *ScheduleParam `json:"schedule"`
+	ID           int64          `json:"id"`
+	Name         string         `json:"job_name"`
+	Kind         string         `json:"job_kind"`
+	Parameters   string         `json:"job_parameters"`
+	Status       string         `json:"job_status"`
+	UUID         string         `json:"-"`
+	Deleted      bool           `json:"deleted"`
+	CreationTime time.Time      `json:"creation_time"`
+	UpdateTime   time.Time      `json:"update_time"`
+}
+
+// ToSwagger converts the history to the swagger model
+func (h *ExecHistory) ToSwagger() *models.ExecHistory {
+	return &models.ExecHistory{
+		ID:            h.ID,
+		JobName:       h.Name,
+		JobKind:       h.Kind,
+		JobParameters: h.Parameters,
+		Deleted:       h.Deleted,
+		JobStatus:     h.Status,
+		Schedule: &models.ScheduleObj{
+			// covert MANUAL to Manual because the type of the ScheduleObj
+			// must be 'Hourly', 'Daily', 'Weekly', 'Custom', 'Manual' and 'None'
+			Type: strings.Title(strings.ToLower(h.Schedule.Type)),
+			Cron: h.Schedule.Cron,
+		},
+		CreationTime: strfmt.DateTime(h.CreationTime),
+		UpdateTime:   strfmt.DateTime(h.UpdateTime),
+	}
+}
+
+// PurgeSchedule ...
+type PurgeSchedule struct {
+	*scheduler.Schedule
+}
+
+// ToSwagger converts the schedule to the swagger model
+func (s *PurgeSchedule) ToSwagger() *models.ExecHistory {
+	if s.Schedule == nil {
+		return nil
+	}
+
+	e, err := json.Marshal(s.ExtraAttrs)
+	if err != nil {
+		log.Error(err)
+	}
+
+	return &models.ExecHistory{
+		ID:            s.ID,
+		JobName:       "",
+		JobKind:       s.CRON,
+		JobParameters: string(e),
+		Deleted:       false,
+		JobStatus:     s.Status,
+		Schedule: &models.ScheduleObj{
+			Cron: s.CRON,
+			Type: s.CRONType,
+		},
+		CreationTime: strfmt.DateTime(s.CreationTime),
+		UpdateTime:   strfmt.DateTime(s.UpdateTime),
+	}
+}
+
+// NewPurgeSchedule ...
+func NewPurgeSchedule(s *scheduler.Schedule) *PurgeSchedule {
+	return &PurgeSchedule{Schedule: s}
+}
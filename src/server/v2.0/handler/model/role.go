@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/go-openapi/strfmt"
+	"github.com/goharbor/harbor/src/controller/role"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// Role ...
+type Role struct {
+	*role.Role
+}
+
+// ToSwagger ...
+func (r *Role) ToSwagger() *models.Role {
+	perms := []*models.Access{}
+	for _, p := range r.Permissions {
+		temp := &models.Access{}
+		lib.JSONCopy(temp, p)
+		perms = append(perms, temp)
+	}
+
+	return &models.Role{
+		ID:           r.ID,
+		Name:         r.Name,
+		Description:  r.Description,
+		Builtin:      r.BuiltIn,
+		Permissions:  perms,
+		CreationTime: strfmt.DateTime(r.CreationTime),
+		UpdateTime:   strfmt.DateTime(r.UpdateTime),
+	}
+}
+
+// NewRole ...
+func NewRole(r *role.Role) *Role {
+	return &Role{
+		Role: r,
+	}
+}
@@ -2,10 +2,20 @@ package handler
 
 import (
 	"context"
+	"net/url"
+	"time"
+
 	"github.com/go-openapi/runtime/middleware"
+	cModels "github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/event/handler/util"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/notification/job"
+	job_model "github.com/goharbor/harbor/src/pkg/notification/job/model"
 	"github.com/goharbor/harbor/src/pkg/notification/policy"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/webhookjob"
@@ -16,6 +26,7 @@ func newNotificationJobAPI() *notificationJobAPI {
 	return &notificationJobAPI{
 		webhookjobMgr:    job.Mgr,
 		webhookPolicyMgr: policy.Mgr,
+		resendHook:       util.ResendHook,
 	}
 }
 
@@ -23,6 +34,7 @@ type notificationJobAPI struct {
 	BaseAPI
 	webhookjobMgr    job.Manager
 	webhookPolicyMgr policy.Manager
+	resendHook       func(ply *policy_model.Policy, j *job_model.Job) error
 }
 
 func (n *notificationJobAPI) ListWebhookJobs(ctx context.Context, params webhookjob.ListWebhookJobsParams) middleware.Responder {
@@ -65,3 +77,156 @@ func (n *notificationJobAPI) ListWebhookJobs(ctx context.Context, params webhook
 		WithLink(n.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
 		WithPayload(results)
 }
+
+func (n *notificationJobAPI) ListWebhookJobDeliveries(ctx context.Context, params webhookjob.ListWebhookJobDeliveriesParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := n.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionList, rbac.ResourceNotificationPolicy); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	j, _, err := n.getJob(ctx, projectNameOrID, params.WebhookJobID)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	query, err := n.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+	query.Keywords["JobID"] = j.ID
+
+	return n.listDeliveries(ctx, query, params.HTTPRequest.URL, func(total int64, link string, payload []*models.WebhookDelivery) middleware.Responder {
+		return operation.NewListWebhookJobDeliveriesOK().WithXTotalCount(total).WithLink(link).WithPayload(payload)
+	})
+}
+
+func (n *notificationJobAPI) ListWebhookPolicyFailedDeliveries(ctx context.Context, params webhookjob.ListWebhookPolicyFailedDeliveriesParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := n.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionList, rbac.ResourceNotificationPolicy); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	ply, err := n.getPolicy(ctx, projectNameOrID, params.WebhookPolicyID)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	query, err := n.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+	query.Keywords["PolicyID"] = ply.ID
+	query.Keywords["Success"] = false
+
+	return n.listDeliveries(ctx, query, params.HTTPRequest.URL, func(total int64, link string, payload []*models.WebhookDelivery) middleware.Responder {
+		return operation.NewListWebhookPolicyFailedDeliveriesOK().WithXTotalCount(total).WithLink(link).WithPayload(payload)
+	})
+}
+
+func (n *notificationJobAPI) RedeliverWebhookJob(ctx context.Context, params webhookjob.RedeliverWebhookJobParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := n.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionUpdate, rbac.ResourceNotificationPolicy); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	j, ply, err := n.getJob(ctx, projectNameOrID, params.WebhookJobID)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+	if err := n.resendHook(ply, j); err != nil {
+		return n.SendError(ctx, err)
+	}
+	return operation.NewRedeliverWebhookJobOK()
+}
+
+func (n *notificationJobAPI) RedeliverWebhookPolicyJobs(ctx context.Context, params webhookjob.RedeliverWebhookPolicyJobsParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := n.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionUpdate, rbac.ResourceNotificationPolicy); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	if params.Range == nil {
+		return n.SendError(ctx, errors.BadRequestError(nil).WithMessage("the time range is required"))
+	}
+	start, end := time.Time(params.Range.StartTime), time.Time(params.Range.EndTime)
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return n.SendError(ctx, errors.BadRequestError(nil).WithMessage("invalid time range, both the start time and end time are required and the end time cannot be earlier than the start time"))
+	}
+
+	ply, err := n.getPolicy(ctx, projectNameOrID, params.WebhookPolicyID)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	jobs, err := n.webhookjobMgr.List(ctx, q.New(q.KeyWords{
+		"PolicyID":     ply.ID,
+		"Status":       cModels.JobError,
+		"CreationTime": &q.Range{Min: start, Max: end},
+	}))
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	var count int64
+	for _, j := range jobs {
+		if err := n.resendHook(ply, j); err != nil {
+			log.Errorf("failed to redeliver the webhook job %d: %v", j.ID, err)
+			continue
+		}
+		count++
+	}
+	return operation.NewRedeliverWebhookPolicyJobsOK().WithPayload(&models.WebhookRedeliveryResult{
+		Count: count,
+	})
+}
+
+func (n *notificationJobAPI) listDeliveries(ctx context.Context, query *q.Query, u *url.URL,
+	respond func(total int64, link string, payload []*models.WebhookDelivery) middleware.Responder) middleware.Responder {
+	total, err := n.webhookjobMgr.CountDeliveries(ctx, query)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	deliveries, err := n.webhookjobMgr.ListDeliveries(ctx, query)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	var results []*models.WebhookDelivery
+	for _, d := range deliveries {
+		results = append(results, model.NewNotificationDelivery(d).ToSwagger())
+	}
+	return respond(total, n.Links(ctx, u, total, query.PageNumber, query.PageSize).String(), results)
+}
+
+// getPolicy returns the policy and makes sure it belongs to the project
+func (n *notificationJobAPI) getPolicy(ctx context.Context, projectNameOrID interface{}, policyID int64) (*policy_model.Policy, error) {
+	projectID, err := getProjectID(ctx, projectNameOrID)
+	if err != nil {
+		return nil, err
+	}
+	ply, err := n.webhookPolicyMgr.Get(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+	if ply.ProjectID != projectID {
+		return nil, errors.NotFoundError(nil).WithMessage("webhook policy %d not found in project %v", policyID, projectNameOrID)
+	}
+	return ply, nil
+}
+
+// getJob returns the job and its policy, and makes sure the job belongs to the project
+func (n *notificationJobAPI) getJob(ctx context.Context, projectNameOrID interface{}, jobID int64) (*job_model.Job, *policy_model.Policy, error) {
+	j, err := n.webhookjobMgr.Get(ctx, jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ply, err := n.getPolicy(ctx, projectNameOrID, j.PolicyID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return nil, nil, errors.NotFoundError(nil).WithMessage("webhook job %d not found in project %v", jobID, projectNameOrID)
+		}
+		return nil, nil, err
+	}
+	return j, ply, nil
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"fmt"
+	"github.com/bmatcuk/doublestar"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/goharbor/harbor/src/common/rbac"
@@ -14,10 +15,13 @@ import (
 	"github.com/goharbor/harbor/src/pkg/notification/job"
 	"github.com/goharbor/harbor/src/pkg/notification/policy"
 	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	notifier_model "github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/webhook"
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/webhook"
+	"net/mail"
 	"strings"
 	"time"
 )
@@ -91,6 +95,9 @@ func (n *notificationPolicyAPI) CreateWebhookPolicyOfProject(ctx context.Context
 	if ok, err := n.validateTargets(policy); !ok {
 		return n.SendError(ctx, err)
 	}
+	if err := validateFilters(policy.Filters); err != nil {
+		return n.SendError(ctx, err)
+	}
 
 	projectID, err := getProjectID(ctx, projectNameOrID)
 	if err != nil {
@@ -121,6 +128,9 @@ func (n *notificationPolicyAPI) UpdateWebhookPolicyOfProject(ctx context.Context
 	if ok, err := n.validateTargets(policy); !ok {
 		return n.SendError(ctx, err)
 	}
+	if err := validateFilters(policy.Filters); err != nil {
+		return n.SendError(ctx, err)
+	}
 
 	projectID, err := getProjectID(ctx, projectNameOrID)
 	if err != nil {
@@ -203,6 +213,10 @@ func (n *notificationPolicyAPI) GetSupportedEventTypes(ctx context.Context, para
 		notificationTypes.EventType = append(notificationTypes.EventType, models.EventType(key))
 	}
 
+	for key := range notification.SupportedPayloadFormats {
+		notificationTypes.PayloadFormats = append(notificationTypes.PayloadFormats, key)
+	}
+
 	return operation.NewGetSupportedEventTypesOK().WithPayload(notificationTypes)
 }
 
@@ -225,21 +239,61 @@ func (n *notificationPolicyAPI) validateTargets(policy *policy_model.Policy) (bo
 		return false, errors.New(nil).WithMessage("empty notification target with policy %s", policy.Name).WithCode(errors.BadRequestCode)
 	}
 	for _, target := range policy.Targets {
-		url, err := utils.ParseEndpoint(target.Address)
-		if err != nil {
-			return false, errors.New(err).WithCode(errors.BadRequestCode)
-		}
-		// Prevent SSRF security issue #3755
-		target.Address = url.Scheme + "://" + url.Host + url.Path
-
 		_, ok := notification.SupportedNotifyTypes[target.Type]
 		if !ok {
 			return false, errors.New(nil).WithMessage("unsupported target type %s with policy %s", target.Type, policy.Name).WithCode(errors.BadRequestCode)
 		}
+
+		if target.Type == notifier_model.NotifyTypeEmail {
+			// the address of the email target is the comma separated recipients
+			recipients := target.Recipients()
+			if len(recipients) == 0 {
+				return false, errors.New(nil).WithMessage("empty recipients with policy %s", policy.Name).WithCode(errors.BadRequestCode)
+			}
+			for _, recipient := range recipients {
+				if _, err := mail.ParseAddress(recipient); err != nil {
+					return false, errors.New(err).WithMessage("invalid recipient %s with policy %s", recipient, policy.Name).WithCode(errors.BadRequestCode)
+				}
+			}
+		} else {
+			url, err := utils.ParseEndpoint(target.Address)
+			if err != nil {
+				return false, errors.New(err).WithCode(errors.BadRequestCode)
+			}
+			// Prevent SSRF security issue #3755
+			target.Address = url.Scheme + "://" + url.Host + url.Path
+		}
+
+		if len(target.SigningSecret) > 0 && target.Type != notifier_model.NotifyTypeHTTP {
+			return false, errors.New(nil).WithMessage("signing secret is not supported by target type %s with policy %s", target.Type, policy.Name).WithCode(errors.BadRequestCode)
+		}
+		if len(target.PayloadFormat) > 0 {
+			if target.Type != notifier_model.NotifyTypeHTTP {
+				return false, errors.New(nil).WithMessage("payload format is not supported by target type %s with policy %s", target.Type, policy.Name).WithCode(errors.BadRequestCode)
+			}
+			if _, ok := notification.SupportedPayloadFormats[target.PayloadFormat]; !ok {
+				return false, errors.New(nil).WithMessage("unsupported payload format %s with policy %s", target.PayloadFormat, policy.Name).WithCode(errors.BadRequestCode)
+			}
+		}
 	}
 	return true, nil
 }
 
+func validateFilters(filters *policy_model.Filters) error {
+	if filters == nil {
+		return nil
+	}
+	for _, pattern := range []string{filters.Repository, filters.Tag} {
+		if _, err := doublestar.Match(pattern, ""); err != nil {
+			return errors.New(err).WithMessage("invalid pattern %s in the filters", pattern).WithCode(errors.BadRequestCode)
+		}
+	}
+	if len(filters.Severity) > 0 && vuln.ParseSeverityVersion3(filters.Severity).Code() == vuln.None.Code() {
+		return errors.New(nil).WithMessage("invalid severity %s in the filters", filters.Severity).WithCode(errors.BadRequestCode)
+	}
+	return nil
+}
+
 func (n *notificationPolicyAPI) validateEventTypes(policy *policy_model.Policy) (bool, error) {
 	if len(policy.EventTypes) == 0 {
 		return false, errors.New(nil).WithMessage("empty event type").WithCode(errors.BadRequestCode)
@@ -28,8 +28,10 @@ import (
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/security/local"
 	robotSec "github.com/goharbor/harbor/src/common/security/robot"
+	"github.com/goharbor/harbor/src/controller/gc"
 	"github.com/goharbor/harbor/src/controller/p2p/preheat"
 	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/proxy"
 	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/controller/registry"
 	"github.com/goharbor/harbor/src/controller/repository"
@@ -59,6 +61,9 @@ import (
 // for the proxy cache type project, we will create a 7 days retention policy for it by default
 const defaultDaysToRetentionForProxyCacheProject = 7
 
+// the max seconds that the push waits for the scan to complete when the scan gating is enabled
+const maxScanGatingTimeout = 3600
+
 func newProjectAPI() *projectAPI {
 	return &projectAPI{
 		auditMgr:      audit.Mgr,
@@ -72,6 +77,7 @@ func newProjectAPI() *projectAPI {
 		preheatCtl:    preheat.Ctl,
 		retentionCtl:  retention.Ctl,
 		scannerCtl:    scanner.DefaultController,
+		gcCtl:         gc.Ctl,
 	}
 }
 
@@ -88,6 +94,7 @@ type projectAPI struct {
 	preheatCtl    preheat.Controller
 	retentionCtl  retention.Controller
 	scannerCtl    scanner.Controller
+	gcCtl         gc.Controller
 }
 
 func (a *projectAPI) CreateProject(ctx context.Context, params operation.CreateProjectParams) middleware.Responder {
@@ -147,13 +154,23 @@ func (a *projectAPI) CreateProject(ctx context.Context, params operation.CreateP
 		req.Metadata.Public = strconv.FormatBool(false)
 	}
 
-	// ignore enable_content_trust metadata for proxy cache project
+	// ignore enable_content_trust and enable_content_trust_cosign metadata for proxy cache project
 	// see https://github.com/goharbor/harbor/issues/12940 to get more info
 	if req.RegistryID != nil {
 		req.Metadata.EnableContentTrust = nil
+		req.Metadata.EnableContentTrustCosign = nil
+		// the artifacts cannot be pushed into the proxy cache project
+		req.Metadata.ScanGating = nil
+		req.Metadata.ScanGatingTimeout = nil
+	} else {
+		// the proxy cache settings only work for proxy cache project
+		req.Metadata.ProxyCacheServeStale = nil
+		req.Metadata.ProxyCacheStaleWindow = nil
+		req.Metadata.ProxyCacheTagTTL = nil
+		req.Metadata.ProxyCacheEvictThreshold = nil
 	}
 
-	// validate the RegistryID and StorageLimit in the body of the request
+	// validate the RegistryID, StorageLimit and proxy cache settings in the body of the request
 	if err := a.validateProjectReq(ctx, req); err != nil {
 		return a.SendError(ctx, err)
 	}
@@ -207,7 +224,10 @@ func (a *projectAPI) CreateProject(ctx context.Context, params operation.CreateP
 	// create the quota for the project
 	if req.StorageLimit != nil {
 		referenceID := quota.ReferenceID(projectID)
-		hardLimits := types.ResourceList{types.ResourceStorage: *req.StorageLimit}
+		hardLimits, err := projectHardLimits(ctx, *req.StorageLimit)
+		if err != nil {
+			return a.SendError(ctx, fmt.Errorf("failed to get quota setting: %v", err))
+		}
 		if _, err := a.quotaCtl.Create(ctx, quota.ProjectReference, referenceID, hardLimits); err != nil {
 			return a.SendError(ctx, fmt.Errorf("failed to create quota for project: %v", err))
 		}
@@ -252,6 +272,12 @@ func (a *projectAPI) DeleteProject(ctx context.Context, params operation.DeleteP
 		return a.SendError(ctx, errors.PreconditionFailedError(errors.New(result.Message)))
 	}
 
+	// the user groups owning the project are listed before deleting it as they're refreshed after the deletion
+	groups, err := quota.GroupsOfProject(ctx, p.ProjectID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
 	if err := a.projectCtl.Delete(ctx, p.ProjectID); err != nil {
 		return a.SendError(ctx, err)
 	}
@@ -271,11 +297,21 @@ func (a *projectAPI) DeleteProject(ctx context.Context, params operation.DeleteP
 		}
 	}
 
+	// the usage of the deleted project should be excluded from the quotas of the user groups owning it
+	if err := quota.RefreshForGroups(ctx, groups...); err != nil {
+		log.Warningf("failed to refresh quotas of the user groups owning the project %s, error: %v", projectNameOrID, err)
+	}
+
 	// preheat policies under the project should be deleted after deleting the project
 	if err = a.preheatCtl.DeletePoliciesOfProject(ctx, p.ProjectID); err != nil {
 		return a.SendError(ctx, err)
 	}
 
+	// the gc schedule of the project should be deleted after deleting the project
+	if err = a.gcCtl.DeleteSchedule(ctx, p.ProjectID); err != nil {
+		return a.SendError(ctx, err)
+	}
+
 	return operation.NewDeleteProjectOK()
 }
 
@@ -538,10 +574,27 @@ func (a *projectAPI) UpdateProject(ctx context.Context, params operation.UpdateP
 		}
 	}
 
-	// ignore enable_content_trust metadata for proxy cache project
+	// ignore enable_content_trust and enable_content_trust_cosign metadata for proxy cache project
 	// see https://github.com/goharbor/harbor/issues/12940 to get more info
 	if params.Project.Metadata != nil && p.IsProxy() {
 		params.Project.Metadata.EnableContentTrust = nil
+		params.Project.Metadata.EnableContentTrustCosign = nil
+		// the artifacts cannot be pushed into the proxy cache project
+		params.Project.Metadata.ScanGating = nil
+		params.Project.Metadata.ScanGatingTimeout = nil
+	}
+	// the proxy cache settings only work for proxy cache project
+	if params.Project.Metadata != nil && !p.IsProxy() {
+		params.Project.Metadata.ProxyCacheServeStale = nil
+		params.Project.Metadata.ProxyCacheStaleWindow = nil
+		params.Project.Metadata.ProxyCacheTagTTL = nil
+		params.Project.Metadata.ProxyCacheEvictThreshold = nil
+	}
+	if err := validateProxyCacheMetadata(params.Project.Metadata); err != nil {
+		return a.SendError(ctx, err)
+	}
+	if err := validateScanGatingMetadata(params.Project.Metadata); err != nil {
+		return a.SendError(ctx, err)
 	}
 	lib.JSONCopy(&p.Metadata, params.Project.Metadata)
 
@@ -633,6 +686,28 @@ func (a *projectAPI) SetScannerOfProject(ctx context.Context, params operation.S
 	return operation.NewSetScannerOfProjectOK()
 }
 
+func (a *projectAPI) PrewarmProxyCache(ctx context.Context, params operation.PrewarmProxyCacheParams) middleware.Responder {
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionUpdate); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if _, err := proxy.ControllerInstance().Prewarm(ctx, p, params.Payload.References); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewPrewarmProxyCacheCreated()
+}
+
 func (a *projectAPI) deletable(ctx context.Context, projectNameOrID interface{}) (*project.Project, *models.ProjectDeletable, error) {
 	p, err := a.getProject(ctx, projectNameOrID)
 	if err != nil {
@@ -687,12 +762,95 @@ func (a *projectAPI) validateProjectReq(ctx context.Context, req *models.Project
 	}
 
 	if req.StorageLimit != nil {
-		hardLimits := types.ResourceList{types.ResourceStorage: *req.StorageLimit}
+		hardLimits, err := projectHardLimits(ctx, *req.StorageLimit)
+		if err != nil {
+			return err
+		}
 		if err := quota.Validate(ctx, quota.ProjectReference, hardLimits); err != nil {
 			return errors.BadRequestError(err)
 		}
 	}
 
+	if err := validateProxyCacheMetadata(req.Metadata); err != nil {
+		return err
+	}
+	return validateScanGatingMetadata(req.Metadata)
+}
+
+// projectHardLimits returns the hard limits of the project quota, the storage limit is
+// specified by the request and the others are the global default values
+func projectHardLimits(ctx context.Context, storageLimit int64) (types.ResourceList, error) {
+	setting, err := config.QuotaSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return types.ResourceList{
+		types.ResourceStorage:    storageLimit,
+		types.ResourceCount:      setting.CountPerProject,
+		types.ResourceRepository: setting.RepositoryPerProject,
+	}, nil
+}
+
+// validateProxyCacheMetadata validates the settings of the proxy cache project
+func validateProxyCacheMetadata(meta *models.ProjectMetadata) error {
+	if meta == nil {
+		return nil
+	}
+	if meta.ProxyCacheServeStale != nil {
+		serve, err := strconv.ParseBool(*meta.ProxyCacheServeStale)
+		if err != nil {
+			return errors.BadRequestError(nil).WithMessage("invalid value of %s: %s", pkgModels.ProMetaProxyCacheServeStale, *meta.ProxyCacheServeStale)
+		}
+		*meta.ProxyCacheServeStale = strconv.FormatBool(serve)
+	}
+	if meta.ProxyCacheStaleWindow != nil {
+		window, err := strconv.ParseInt(*meta.ProxyCacheStaleWindow, 10, 64)
+		if err != nil || window < 0 {
+			return errors.BadRequestError(nil).WithMessage("invalid value of %s: %s, it should be a non-negative integer of minutes",
+				pkgModels.ProMetaProxyCacheStaleWindow, *meta.ProxyCacheStaleWindow)
+		}
+		*meta.ProxyCacheStaleWindow = strconv.FormatInt(window, 10)
+	}
+	if meta.ProxyCacheTagTTL != nil {
+		ttl, err := strconv.ParseInt(*meta.ProxyCacheTagTTL, 10, 64)
+		if err != nil || ttl < 0 {
+			return errors.BadRequestError(nil).WithMessage("invalid value of %s: %s, it should be a non-negative integer of minutes",
+				pkgModels.ProMetaProxyCacheTagTTL, *meta.ProxyCacheTagTTL)
+		}
+		*meta.ProxyCacheTagTTL = strconv.FormatInt(ttl, 10)
+	}
+	if meta.ProxyCacheEvictThreshold != nil {
+		threshold, err := strconv.ParseInt(*meta.ProxyCacheEvictThreshold, 10, 64)
+		if err != nil || threshold < 0 || threshold > 100 {
+			return errors.BadRequestError(nil).WithMessage("invalid value of %s: %s, it should be an integer between 0 and 100",
+				pkgModels.ProMetaProxyCacheEvictThreshold, *meta.ProxyCacheEvictThreshold)
+		}
+		*meta.ProxyCacheEvictThreshold = strconv.FormatInt(threshold, 10)
+	}
+	return nil
+}
+
+// validateScanGatingMetadata validates the settings of the scan gating
+func validateScanGatingMetadata(meta *models.ProjectMetadata) error {
+	if meta == nil {
+		return nil
+	}
+	if meta.ScanGating != nil {
+		mode := strings.ToLower(*meta.ScanGating)
+		if mode != "" && mode != pkgModels.ScanGatingReject && mode != pkgModels.ScanGatingQuarantine {
+			return errors.BadRequestError(nil).WithMessage("invalid value of %s: %s, it should be %s or %s",
+				pkgModels.ProMetaScanGating, *meta.ScanGating, pkgModels.ScanGatingReject, pkgModels.ScanGatingQuarantine)
+		}
+		*meta.ScanGating = mode
+	}
+	if meta.ScanGatingTimeout != nil {
+		timeout, err := strconv.ParseInt(*meta.ScanGatingTimeout, 10, 64)
+		if err != nil || timeout <= 0 || timeout > maxScanGatingTimeout {
+			return errors.BadRequestError(nil).WithMessage("invalid value of %s: %s, it should be an integer of seconds between 1 and %d",
+				pkgModels.ProMetaScanGatingTimeout, *meta.ScanGatingTimeout, maxScanGatingTimeout)
+		}
+		*meta.ScanGatingTimeout = strconv.FormatInt(timeout, 10)
+	}
 	return nil
 }
 
@@ -812,12 +970,22 @@ func highestRole(roles []int) int {
 		common.RoleGuest:        20,
 		common.RoleLimitedGuest: 10,
 	}
-	var highest, highestPower int
+	var highest, highestPower, custom int
 	for _, role := range roles {
-		if p, ok := rolePower[role]; ok && p > highestPower {
-			highest = role
-			highestPower = p
+		if p, ok := rolePower[role]; ok {
+			if p > highestPower {
+				highest = role
+				highestPower = p
+			}
+			continue
 		}
+		// the custom roles have no ranking, the earliest created one is used when there is no built-in role
+		if custom == 0 || role < custom {
+			custom = role
+		}
+	}
+	if highest == 0 {
+		return custom
 	}
 	return highest
 }
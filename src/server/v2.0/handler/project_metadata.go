@@ -140,8 +140,8 @@ func (p *projectMetadataAPI) validate(metas map[string]string) (map[string]strin
 	}
 
 	switch key {
-	case proModels.ProMetaPublic, proModels.ProMetaEnableContentTrust,
-		proModels.ProMetaPreventVul, proModels.ProMetaAutoScan:
+	case proModels.ProMetaPublic, proModels.ProMetaEnableContentTrust, proModels.ProMetaEnableContentTrustCosign,
+		proModels.ProMetaPreventVul, proModels.ProMetaAutoScan, proModels.ProMetaProxyCacheServeStale:
 		v, err := strconv.ParseBool(value)
 		if err != nil {
 			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
@@ -153,6 +153,30 @@ func (p *projectMetadataAPI) validate(metas map[string]string) (map[string]strin
 			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
 		}
 		metas[proModels.ProMetaSeverity] = strings.ToLower(severity.String())
+	case proModels.ProMetaProxyCacheStaleWindow, proModels.ProMetaProxyCacheTagTTL:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
+		}
+		metas[key] = strconv.FormatInt(v, 10)
+	case proModels.ProMetaProxyCacheEvictThreshold:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 || v > 100 {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
+		}
+		metas[key] = strconv.FormatInt(v, 10)
+	case proModels.ProMetaScanGating:
+		mode := strings.ToLower(value)
+		if mode != "" && mode != proModels.ScanGatingReject && mode != proModels.ScanGatingQuarantine {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
+		}
+		metas[key] = mode
+	case proModels.ProMetaScanGatingTimeout:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v <= 0 || v > maxScanGatingTimeout {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
+		}
+		metas[key] = strconv.FormatInt(v, 10)
 	default:
 		return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid key: %s", key)
 	}
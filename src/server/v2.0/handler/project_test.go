@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi"
@@ -25,6 +26,7 @@ import (
 	scannertesting "github.com/goharbor/harbor/src/testing/controller/scanner"
 	"github.com/goharbor/harbor/src/testing/mock"
 	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -196,6 +198,23 @@ func (suite *ProjectTestSuite) TestSetScannerOfProject() {
 	}
 }
 
+func TestHighestRole(t *testing.T) {
+	cases := []struct {
+		roles []int
+		want  int
+	}{
+		{nil, 0},
+		{[]int{common.RoleGuest, common.RoleDeveloper}, common.RoleDeveloper},
+		// the built-in role takes precedence over the custom roles
+		{[]int{12, common.RoleLimitedGuest}, common.RoleLimitedGuest},
+		// the member whose only roles are custom roles
+		{[]int{12, 10}, 10},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, highestRole(c.roles))
+	}
+}
+
 func TestProjectTestSuite(t *testing.T) {
 	suite.Run(t, &ProjectTestSuite{})
 }
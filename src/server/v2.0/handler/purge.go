@@ -0,0 +1,249 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/purge"
+	jobPurge "github.com/goharbor/harbor/src/jobservice/job/impl/purge"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/purge"
+)
+
+type purgeAPI struct {
+	BaseAPI
+	purgeCtr purge.Controller
+}
+
+func newPurgeAPI() *purgeAPI {
+	return &purgeAPI{
+		purgeCtr: purge.Ctl,
+	}
+}
+
+func (p *purgeAPI) Prepare(ctx context.Context, operation string, params interface{}) middleware.Responder {
+	return nil
+}
+
+func (p *purgeAPI) CreatePurgeSchedule(ctx context.Context, params operation.CreatePurgeScheduleParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if err := validatePurgeSchedule(params.Schedule); err != nil {
+		return p.SendError(ctx, err)
+	}
+	id, err := p.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	// replace the /api/v2.0/system/purgeaudit/schedule to /api/v2.0/system/purgeaudit/{id}
+	lastSlashIndex := strings.LastIndex(params.HTTPRequest.URL.Path, "/")
+	if lastSlashIndex != -1 {
+		location := fmt.Sprintf("%s/%d", params.HTTPRequest.URL.Path[:lastSlashIndex], id)
+		return operation.NewCreatePurgeScheduleCreated().WithLocation(location)
+	}
+	return operation.NewCreatePurgeScheduleCreated()
+}
+
+func (p *purgeAPI) UpdatePurgeSchedule(ctx context.Context, params operation.UpdatePurgeScheduleParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if err := validatePurgeSchedule(params.Schedule); err != nil {
+		return p.SendError(ctx, err)
+	}
+	_, err := p.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewUpdatePurgeScheduleOK()
+}
+
+func (p *purgeAPI) kick(ctx context.Context, scheType string, cron string, parameters map[string]interface{}) (int64, error) {
+	var err error
+	var id int64
+	switch scheType {
+	case ScheduleManual:
+		id, err = p.purgeCtr.Start(ctx, purgePolicy(parameters), task.ExecutionTriggerManual)
+	case ScheduleNone:
+		err = p.purgeCtr.DeleteSchedule(ctx)
+	case ScheduleHourly, ScheduleDaily, ScheduleWeekly, ScheduleCustom:
+		err = p.updateSchedule(ctx, scheType, cron, purgePolicy(parameters))
+	}
+	return id, err
+}
+
+func (p *purgeAPI) updateSchedule(ctx context.Context, cronType, cron string, policy purge.Policy) error {
+	if cron == "" {
+		return errors.BadRequestError(nil).WithMessage("empty cron string for purge audit log schedule")
+	}
+	if err := p.purgeCtr.DeleteSchedule(ctx); err != nil {
+		return err
+	}
+	_, err := p.purgeCtr.CreateSchedule(ctx, cronType, cron, policy)
+	return err
+}
+
+func (p *purgeAPI) GetPurgeSchedule(ctx context.Context, params operation.GetPurgeScheduleParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	schedule, err := p.purgeCtr.GetSchedule(ctx)
+	if errors.IsNotFoundErr(err) {
+		return operation.NewGetPurgeScheduleOK()
+	}
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	return operation.NewGetPurgeScheduleOK().WithPayload(model.NewPurgeSchedule(schedule).ToSwagger())
+}
+
+func (p *purgeAPI) GetPurgeHistory(ctx context.Context, params operation.GetPurgeHistoryParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	query, err := p.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	total, err := p.purgeCtr.ExecutionCount(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	execs, err := p.purgeCtr.ListExecutions(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	var results []*models.ExecHistory
+	for _, exec := range execs {
+		h, err := toExecHistory(exec)
+		if err != nil {
+			return p.SendError(ctx, err)
+		}
+		results = append(results, h.ToSwagger())
+	}
+
+	return operation.NewGetPurgeHistoryOK().
+		WithXTotalCount(total).
+		WithLink(p.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (p *purgeAPI) GetPurgeJob(ctx context.Context, params operation.GetPurgeJobParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	exec, err := p.purgeCtr.GetExecution(ctx, params.PurgeID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	h, err := toExecHistory(exec)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewGetPurgeJobOK().WithPayload(h.ToSwagger())
+}
+
+func (p *purgeAPI) StopPurge(ctx context.Context, params operation.StopPurgeParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if err := p.purgeCtr.Stop(ctx, params.PurgeID); err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewStopPurgeOK()
+}
+
+func (p *purgeAPI) GetPurgeJobLog(ctx context.Context, params operation.GetPurgeJobLogParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourcePurgeAuditLog); err != nil {
+		return p.SendError(ctx, err)
+	}
+	tasks, err := p.purgeCtr.ListTasks(ctx, q.New(q.KeyWords{
+		"ExecutionID": params.PurgeID,
+	}))
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	if len(tasks) == 0 {
+		return p.SendError(ctx, errors.NotFoundError(nil).WithMessage("purge audit log %d log is not found", params.PurgeID))
+	}
+	log, err := p.purgeCtr.GetTaskLog(ctx, tasks[0].ID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewGetPurgeJobLogOK().WithPayload(string(log))
+}
+
+// validatePurgeSchedule checks the retention hour is provided unless the schedule is removed
+func validatePurgeSchedule(schedule *models.Schedule) error {
+	if schedule == nil || schedule.Schedule == nil {
+		return errors.BadRequestError(nil).WithMessage("the schedule is required")
+	}
+	if schedule.Schedule.Type == ScheduleNone {
+		return nil
+	}
+	if purgePolicy(schedule.Parameters).RetentionHour <= 0 {
+		return errors.BadRequestError(nil).WithMessage("the parameter %s must be greater than 0", jobPurge.ParamRetentionHour)
+	}
+	return nil
+}
+
+func purgePolicy(parameters map[string]interface{}) purge.Policy {
+	policy := purge.Policy{
+		ExtraAttrs: parameters,
+	}
+	// the numbers are decoded as float64 from JSON
+	if retentionHour, ok := parameters[jobPurge.ParamRetentionHour].(float64); ok {
+		policy.RetentionHour = int(retentionHour)
+	}
+	if includeOperations, ok := parameters[jobPurge.ParamIncludeOperations].(string); ok {
+		policy.IncludeOperations = includeOperations
+	}
+	if dryRun, ok := parameters[jobPurge.ParamDryRun].(bool); ok {
+		policy.DryRun = dryRun
+	}
+	return policy
+}
+
+func toExecHistory(exec *task.Execution) (*model.ExecHistory, error) {
+	extraAttrs, err := json.Marshal(exec.ExtraAttrs)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ExecHistory{
+		ID:         exec.ID,
+		Name:       purge.VendorType,
+		Kind:       exec.Trigger,
+		Parameters: string(extraAttrs),
+		Schedule: &model.ScheduleParam{
+			Type: exec.Trigger,
+		},
+		Status:       exec.Status,
+		CreationTime: exec.StartTime,
+		UpdateTime:   exec.EndTime,
+	}, nil
+}
@@ -16,9 +16,11 @@ package handler
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
@@ -31,13 +33,15 @@ import (
 
 func newQuotaAPI() *quotaAPI {
 	return &quotaAPI{
-		quotaCtl: quota.Ctl,
+		quotaCtl:   quota.Ctl,
+		projectCtl: project.Ctl,
 	}
 }
 
 type quotaAPI struct {
 	BaseAPI
-	quotaCtl quota.Controller
+	quotaCtl   quota.Controller
+	projectCtl project.Controller
 }
 
 func (qa *quotaAPI) GetQuota(ctx context.Context, params operation.GetQuotaParams) middleware.Responder {
@@ -103,7 +107,11 @@ func (qa *quotaAPI) UpdateQuota(ctx context.Context, params operation.UpdateQuot
 		return qa.SendError(ctx, err)
 	}
 
-	hard := make(types.ResourceList, len(params.Hard.Hard))
+	// the resources not in the request keep the current hard limits
+	hard, err := q.GetHard()
+	if err != nil {
+		return qa.SendError(ctx, err)
+	}
 	for name, value := range params.Hard.Hard {
 		hard[types.ResourceName(name)] = value
 	}
@@ -120,3 +128,127 @@ func (qa *quotaAPI) UpdateQuota(ctx context.Context, params operation.UpdateQuot
 
 	return operation.NewUpdateQuotaOK()
 }
+
+func (qa *quotaAPI) GetUserGroupQuota(ctx context.Context, params operation.GetUserGroupQuotaParams) middleware.Responder {
+	if err := qa.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceQuota); err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	referenceID := quota.ReferenceID(params.GroupID)
+	q, err := qa.quotaCtl.GetByRef(ctx, quota.GroupReference, referenceID, quota.WithReferenceObject())
+	if err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	usages, err := quota.UsageBreakdown(ctx, quota.GroupReference, referenceID)
+	if err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	m := model.NewQuota(q).ToSwagger(ctx)
+	payload := &models.UserGroupQuota{
+		ID:           m.ID,
+		GroupID:      params.GroupID,
+		Hard:         m.Hard,
+		Used:         m.Used,
+		Projects:     []*models.ProjectQuotaUsage{},
+		CreationTime: m.CreationTime,
+		UpdateTime:   m.UpdateTime,
+	}
+	if name, ok := q.Ref["name"].(string); ok {
+		payload.GroupName = name
+	}
+	for key, used := range usages {
+		projectID, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return qa.SendError(ctx, err)
+		}
+		p, err := qa.projectCtl.Get(ctx, projectID, project.Metadata(false))
+		if err != nil {
+			return qa.SendError(ctx, err)
+		}
+		payload.Projects = append(payload.Projects, &models.ProjectQuotaUsage{
+			ProjectID:   projectID,
+			ProjectName: p.Name,
+			Used:        model.NewResourceList(used).ToSwagger(),
+		})
+	}
+
+	return operation.NewGetUserGroupQuotaOK().WithPayload(payload)
+}
+
+func (qa *quotaAPI) SetUserGroupQuota(ctx context.Context, params operation.SetUserGroupQuotaParams) middleware.Responder {
+	if err := qa.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceQuota); err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	if params.Hard == nil || len(params.Hard.Hard) == 0 {
+		return qa.SendError(ctx, errors.BadRequestError(nil).WithMessage("hard required in body"))
+	}
+
+	driver, err := quota.Driver(ctx, quota.GroupReference)
+	if err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	referenceID := quota.ReferenceID(params.GroupID)
+	// make sure the user group exists
+	if _, err := driver.Load(ctx, referenceID); err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	q, err := qa.quotaCtl.GetByRef(ctx, quota.GroupReference, referenceID)
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return qa.SendError(ctx, err)
+	}
+
+	// the resources not in the request keep the current hard limits, or the default ones when the quota is new
+	hard := driver.HardLimits(ctx)
+	if q != nil {
+		if hard, err = q.GetHard(); err != nil {
+			return qa.SendError(ctx, err)
+		}
+	}
+	for name, value := range params.Hard.Hard {
+		hard[types.ResourceName(name)] = value
+	}
+
+	if err := driver.Validate(hard); err != nil {
+		return qa.SendError(ctx, errors.BadRequestError(nil).WithMessage(err.Error()))
+	}
+
+	if q == nil {
+		if _, err := qa.quotaCtl.Create(ctx, quota.GroupReference, referenceID, hard); err != nil {
+			return qa.SendError(ctx, err)
+		}
+	} else {
+		q.SetHard(hard)
+		if err := qa.quotaCtl.Update(ctx, q); err != nil {
+			return qa.SendError(ctx, err)
+		}
+	}
+
+	// the usage of the projects owned by the user group may exceed the hard limits already
+	if err := qa.quotaCtl.Refresh(ctx, quota.GroupReference, referenceID, quota.IgnoreLimitation(true)); err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	return operation.NewSetUserGroupQuotaOK()
+}
+
+func (qa *quotaAPI) DeleteUserGroupQuota(ctx context.Context, params operation.DeleteUserGroupQuotaParams) middleware.Responder {
+	if err := qa.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceQuota); err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	q, err := qa.quotaCtl.GetByRef(ctx, quota.GroupReference, quota.ReferenceID(params.GroupID))
+	if err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	if err := qa.quotaCtl.Delete(ctx, q.ID); err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	return operation.NewDeleteUserGroupQuotaOK()
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/project/metadata"
 	"github.com/goharbor/harbor/src/pkg/retention/policy"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/index"
 	"github.com/goharbor/harbor/src/pkg/task"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
@@ -37,6 +38,10 @@ type retentionAPI struct {
 
 var (
 	rentenitionMetadataPayload = &models.RetentionMetadata{
+		Algorithms: []string{
+			policy.AlgorithmOR,
+			policy.AlgorithmAND,
+		},
 		Templates: []*models.RetentionRuleMetadata{
 			{
 				Action:       "retain",
@@ -92,6 +97,84 @@ var (
 				Action:       "retain",
 				Params:       []*models.RetentionRuleParamMetadata{},
 			},
+			{
+				RuleTemplate: "severityBelow",
+				DisplayText:  "with no vulnerabilities of severity # or higher",
+				Action:       "retain",
+				Params: []*models.RetentionRuleParamMetadata{
+					{
+						Type:     "string",
+						Unit:     "SEVERITY",
+						Required: true,
+					},
+				},
+			},
+			{
+				RuleTemplate: "largestN",
+				DisplayText:  "the # largest artifacts",
+				Action:       "retain",
+				Params: []*models.RetentionRuleParamMetadata{
+					{
+						Type:     "int",
+						Unit:     "COUNT",
+						Required: true,
+					},
+				},
+			},
+			{
+				RuleTemplate: "smallestN",
+				DisplayText:  "the # smallest artifacts",
+				Action:       "retain",
+				Params: []*models.RetentionRuleParamMetadata{
+					{
+						Type:     "int",
+						Unit:     "COUNT",
+						Required: true,
+					},
+				},
+			},
+			{
+				RuleTemplate: "artifactTypes",
+				DisplayText:  "the artifacts of type #",
+				Action:       "retain",
+				Params: []*models.RetentionRuleParamMetadata{
+					{
+						Type:     "[]string",
+						Unit:     "TYPE",
+						Required: true,
+					},
+				},
+			},
+			{
+				RuleTemplate: "pushedBeforeNDays",
+				DisplayText:  "pushed more than # days ago",
+				Action:       "delete",
+				Params: []*models.RetentionRuleParamMetadata{
+					{
+						Type:     "int",
+						Unit:     "DAYS",
+						Required: true,
+					},
+				},
+			},
+			{
+				RuleTemplate: "notPulledInNDays",
+				DisplayText:  "not pulled within the last # days",
+				Action:       "delete",
+				Params: []*models.RetentionRuleParamMetadata{
+					{
+						Type:     "int",
+						Unit:     "DAYS",
+						Required: true,
+					},
+				},
+			},
+			{
+				RuleTemplate: "untagged",
+				DisplayText:  "untagged",
+				Action:       "delete",
+				Params:       []*models.RetentionRuleParamMetadata{},
+			},
 		},
 		ScopeSelectors: []*models.RetentionSelectorMetadata{
 			{
@@ -146,6 +229,9 @@ func (r *retentionAPI) CreateRetention(ctx context.Context, params operation.Cre
 	if len(p.Rules) > 15 {
 		return r.SendError(ctx, errors.BadRequestError(fmt.Errorf("only 15 rules are allowed at most")))
 	}
+	if err := r.checkRuleAction(p); err != nil {
+		return r.SendError(ctx, errors.BadRequestError(err))
+	}
 	if err := r.checkRuleConflict(p); err != nil {
 		return r.SendError(ctx, errors.ConflictError(err))
 	}
@@ -199,6 +285,9 @@ func (r *retentionAPI) UpdateRetention(ctx context.Context, params operation.Upd
 	if len(p.Rules) > 15 {
 		return r.SendError(ctx, errors.BadRequestError(fmt.Errorf("only 15 rules are allowed at most")))
 	}
+	if err := r.checkRuleAction(p); err != nil {
+		return r.SendError(ctx, errors.BadRequestError(err))
+	}
 	if err := r.checkRuleConflict(p); err != nil {
 		return r.SendError(ctx, errors.ConflictError(err))
 	}
@@ -213,6 +302,23 @@ func (r *retentionAPI) UpdateRetention(ctx context.Context, params operation.Upd
 	return operation.NewUpdateRetentionOK()
 }
 
+// checkRuleAction checks the algorithm of the policy and the actions of the rules,
+// the rules with different actions cannot be mixed in one policy
+func (r *retentionAPI) checkRuleAction(p *policy.Metadata) error {
+	if p.Algorithm != policy.AlgorithmOR && p.Algorithm != policy.AlgorithmAND {
+		return fmt.Errorf("algorithm %s is not supported", p.Algorithm)
+	}
+	for n, rule := range p.Rules {
+		if err := index.ValidAction(rule.Template, rule.Action); err != nil {
+			return err
+		}
+		if rule.Action != p.Rules[0].Action {
+			return fmt.Errorf("the action %s of rule %d is different from the action %s of rule 0", rule.Action, n, p.Rules[0].Action)
+		}
+	}
+	return nil
+}
+
 func (r *retentionAPI) checkRuleConflict(p *policy.Metadata) error {
 	temp := make(map[string]int)
 	for n, rule := range p.Rules {
@@ -20,9 +20,11 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/quota"
 	ugCtl "github.com/goharbor/harbor/src/controller/usergroup"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/usergroup/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
@@ -32,11 +34,12 @@ import (
 
 type userGroupAPI struct {
 	BaseAPI
-	ctl ugCtl.Controller
+	ctl      ugCtl.Controller
+	quotaCtl quota.Controller
 }
 
 func newUserGroupAPI() *userGroupAPI {
-	return &userGroupAPI{ctl: ugCtl.Ctl}
+	return &userGroupAPI{ctl: ugCtl.Ctl, quotaCtl: quota.Ctl}
 }
 
 func (u *userGroupAPI) CreateUserGroup(ctx context.Context, params operation.CreateUserGroupParams) middleware.Responder {
@@ -73,6 +76,14 @@ func (u *userGroupAPI) DeleteUserGroup(ctx context.Context, params operation.Del
 	if err != nil {
 		return u.SendError(ctx, err)
 	}
+	q, err := u.quotaCtl.GetByRef(ctx, quota.GroupReference, quota.ReferenceID(params.GroupID))
+	if err == nil {
+		if err := u.quotaCtl.Delete(ctx, q.ID); err != nil {
+			return u.SendError(ctx, fmt.Errorf("failed to delete quota for user group: %v", err))
+		}
+	} else if !errors.IsNotFoundErr(err) {
+		log.Warningf("failed to get quota for user group %d, error: %v", params.GroupID, err)
+	}
 	return operation.NewDeleteUserGroupOK()
 }
 
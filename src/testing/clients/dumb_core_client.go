@@ -16,7 +16,7 @@ package clients
 
 import (
 	"github.com/goharbor/harbor/src/chartserver"
-	modelsv2 "github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/pkg/clients/core"
 )
 
 // DumbCoreClient provides an empty implement for pkg/clients/core.Client
@@ -24,7 +24,7 @@ import (
 type DumbCoreClient struct{}
 
 // ListAllArtifacts ...
-func (d *DumbCoreClient) ListAllArtifacts(project, repository string) ([]*modelsv2.Artifact, error) {
+func (d *DumbCoreClient) ListAllArtifacts(project, repository string) ([]*core.Artifact, error) {
 	return nil, nil
 }
 
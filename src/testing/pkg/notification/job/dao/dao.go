@@ -38,6 +38,27 @@ func (_m *DAO) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return r0, r1
 }
 
+// CountDeliveries provides a mock function with given fields: ctx, query
+func (_m *DAO) CountDeliveries(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, n
 func (_m *DAO) Create(ctx context.Context, n *model.Job) (int64, error) {
 	ret := _m.Called(ctx, n)
@@ -59,6 +80,27 @@ func (_m *DAO) Create(ctx context.Context, n *model.Job) (int64, error) {
 	return r0, r1
 }
 
+// CreateDelivery provides a mock function with given fields: ctx, delivery
+func (_m *DAO) CreateDelivery(ctx context.Context, delivery *model.Delivery) (int64, error) {
+	ret := _m.Called(ctx, delivery)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Delivery) int64); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Delivery) error); ok {
+		r1 = rf(ctx, delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *DAO) Delete(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -156,6 +198,50 @@ func (_m *DAO) List(ctx context.Context, query *q.Query) ([]*model.Job, error) {
 	return r0, r1
 }
 
+// ListDeliveries provides a mock function with given fields: ctx, query
+func (_m *DAO) ListDeliveries(ctx context.Context, query *q.Query) ([]*model.Delivery, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*model.Delivery
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*model.Delivery); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PruneDeliveries provides a mock function with given fields: ctx, policyID, retain
+func (_m *DAO) PruneDeliveries(ctx context.Context, policyID int64, retain int) (int64, error) {
+	ret := _m.Called(ctx, policyID, retain)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) int64); ok {
+		r0 = rf(ctx, policyID, retain)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, policyID, retain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, n, props
 func (_m *DAO) Update(ctx context.Context, n *model.Job, props ...string) error {
 	_va := make([]interface{}, len(props))
@@ -15,6 +15,7 @@
 package pkg
 
 //go:generate mockery --case snake --dir ../../pkg/artifact --name Manager --output ./artifact --outpkg artifact
+//go:generate mockery --case snake --dir ../../pkg/audit --name Manager --output ./audit --outpkg audit
 //go:generate mockery --case snake --dir ../../pkg/blob --name Manager --output ./blob --outpkg blob
 //go:generate mockery --case snake --dir ../../vendor/github.com/docker/distribution --name Manifest --output ./distribution --outpkg distribution
 //go:generate mockery --case snake --dir ../../pkg/project --name Manager --output ./project --outpkg project
@@ -53,3 +54,5 @@ package pkg
 //go:generate mockery --case snake --dir ../../pkg/label/dao --name DAO --output ./label/dao --outpkg dao
 //go:generate mockery --case snake --dir ../../pkg/joblog --name Manager --output ./joblog --outpkg joblog
 //go:generate mockery --case snake --dir ../../pkg/joblog/dao --name DAO --output ./joblog/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/accessory --name Manager --output ./accessory --outpkg accessory
+//go:generate mockery --case snake --dir ../../pkg/accessory/dao --name DAO --output ./accessory/dao --outpkg dao
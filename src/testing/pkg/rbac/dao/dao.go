@@ -17,6 +17,48 @@ type DAO struct {
 	mock.Mock
 }
 
+// CountRoleMembers provides a mock function with given fields: ctx, id
+func (_m *DAO) CountRoleMembers(ctx context.Context, id int64) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountRoles provides a mock function with given fields: ctx, query
+func (_m *DAO) CountRoles(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePermission provides a mock function with given fields: ctx, rp
 func (_m *DAO) CreatePermission(ctx context.Context, rp *model.RolePermission) (int64, error) {
 	ret := _m.Called(ctx, rp)
@@ -59,6 +101,27 @@ func (_m *DAO) CreateRbacPolicy(ctx context.Context, pp *model.PermissionPolicy)
 	return r0, r1
 }
 
+// CreateRole provides a mock function with given fields: ctx, role
+func (_m *DAO) CreateRole(ctx context.Context, role *model.Role) (int64, error) {
+	ret := _m.Called(ctx, role)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Role) int64); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Role) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeletePermission provides a mock function with given fields: ctx, id
 func (_m *DAO) DeletePermission(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -101,6 +164,20 @@ func (_m *DAO) DeleteRbacPolicy(ctx context.Context, id int64) error {
 	return r0
 }
 
+// DeleteRole provides a mock function with given fields: ctx, id
+func (_m *DAO) DeleteRole(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetPermissionsByRole provides a mock function with given fields: ctx, roleType, roleID
 func (_m *DAO) GetPermissionsByRole(ctx context.Context, roleType string, roleID int64) ([]*model.UniversalRolePermission, error) {
 	ret := _m.Called(ctx, roleType, roleID)
@@ -124,6 +201,29 @@ func (_m *DAO) GetPermissionsByRole(ctx context.Context, roleType string, roleID
 	return r0, r1
 }
 
+// GetRole provides a mock function with given fields: ctx, id
+func (_m *DAO) GetRole(ctx context.Context, id int64) (*model.Role, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Role
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *model.Role); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListPermissions provides a mock function with given fields: ctx, query
 func (_m *DAO) ListPermissions(ctx context.Context, query *q.Query) ([]*model.RolePermission, error) {
 	ret := _m.Called(ctx, query)
@@ -169,3 +269,47 @@ func (_m *DAO) ListRbacPolicies(ctx context.Context, query *q.Query) ([]*model.P
 
 	return r0, r1
 }
+
+// ListRoles provides a mock function with given fields: ctx, query
+func (_m *DAO) ListRoles(ctx context.Context, query *q.Query) ([]*model.Role, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*model.Role
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*model.Role); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function with given fields: ctx, role, props
+func (_m *DAO) UpdateRole(ctx context.Context, role *model.Role, props ...string) error {
+	_va := make([]interface{}, len(props))
+	for _i := range props {
+		_va[_i] = props[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, role)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Role, ...string) error); ok {
+		r0 = rf(ctx, role, props...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
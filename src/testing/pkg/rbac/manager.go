@@ -16,6 +16,48 @@ type Manager struct {
 	mock.Mock
 }
 
+// CountRoleMembers provides a mock function with given fields: ctx, id
+func (_m *Manager) CountRoleMembers(ctx context.Context, id int64) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountRoles provides a mock function with given fields: ctx, query
+func (_m *Manager) CountRoles(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePermission provides a mock function with given fields: ctx, rp
 func (_m *Manager) CreatePermission(ctx context.Context, rp *model.RolePermission) (int64, error) {
 	ret := _m.Called(ctx, rp)
@@ -58,6 +100,27 @@ func (_m *Manager) CreateRbacPolicy(ctx context.Context, pp *model.PermissionPol
 	return r0, r1
 }
 
+// CreateRole provides a mock function with given fields: ctx, role
+func (_m *Manager) CreateRole(ctx context.Context, role *model.Role) (int64, error) {
+	ret := _m.Called(ctx, role)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Role) int64); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Role) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeletePermission provides a mock function with given fields: ctx, id
 func (_m *Manager) DeletePermission(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -100,6 +163,20 @@ func (_m *Manager) DeleteRbacPolicy(ctx context.Context, id int64) error {
 	return r0
 }
 
+// DeleteRole provides a mock function with given fields: ctx, id
+func (_m *Manager) DeleteRole(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetPermissionsByRole provides a mock function with given fields: ctx, roleType, roleID
 func (_m *Manager) GetPermissionsByRole(ctx context.Context, roleType string, roleID int64) ([]*model.UniversalRolePermission, error) {
 	ret := _m.Called(ctx, roleType, roleID)
@@ -123,6 +200,29 @@ func (_m *Manager) GetPermissionsByRole(ctx context.Context, roleType string, ro
 	return r0, r1
 }
 
+// GetRole provides a mock function with given fields: ctx, id
+func (_m *Manager) GetRole(ctx context.Context, id int64) (*model.Role, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Role
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *model.Role); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListPermissions provides a mock function with given fields: ctx, query
 func (_m *Manager) ListPermissions(ctx context.Context, query *q.Query) ([]*model.RolePermission, error) {
 	ret := _m.Called(ctx, query)
@@ -168,3 +268,47 @@ func (_m *Manager) ListRbacPolicies(ctx context.Context, query *q.Query) ([]*mod
 
 	return r0, r1
 }
+
+// ListRoles provides a mock function with given fields: ctx, query
+func (_m *Manager) ListRoles(ctx context.Context, query *q.Query) ([]*model.Role, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*model.Role
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*model.Role); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Role)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function with given fields: ctx, role, props
+func (_m *Manager) UpdateRole(ctx context.Context, role *model.Role, props ...string) error {
+	_va := make([]interface{}, len(props))
+	for _i := range props {
+		_va[_i] = props[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, role)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Role, ...string) error); ok {
+		r0 = rf(ctx, role, props...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
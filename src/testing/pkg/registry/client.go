@@ -56,8 +56,8 @@ func (f *FakeClient) ListTags(repository string) ([]string, error) {
 func (f *FakeClient) ManifestExist(repository, reference string) (bool, *distribution.Descriptor, error) {
 	args := f.Called()
 	var desc *distribution.Descriptor
-	if args[0] != nil {
-		desc = args[0].(*distribution.Descriptor)
+	if args[1] != nil {
+		desc = args[1].(*distribution.Descriptor)
 	}
 	return args.Bool(0), desc, args.Error(2)
 }